@@ -2,13 +2,12 @@ package main
 
 import (
 	"log"
+	"os"
 
+	"github.com/mhs294/mulhall/internals/cli"
 	"github.com/mhs294/mulhall/internals/env"
-	"github.com/mhs294/mulhall/internals/server"
 )
 
-var app *server.AppServer
-
 func init() {
 	log.Println("init started")
 
@@ -16,14 +15,10 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
-	app, err = server.NewAppServer()
-	if err != nil {
-		panic(err)
-	}
-
-	log.Println("init complete")
 }
 
 func main() {
-	app.Start()
+	if err := cli.Run(os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,245 @@
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mhs294/mulhall/internals/ioc"
+	"github.com/mhs294/mulhall/internals/server"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/utils"
+)
+
+// generatedPasswordLength is the length of the password generated for a new Administrator when none is provided.
+const generatedPasswordLength = 24
+
+const usage = `Usage: mulhall <command> [arguments]
+
+Commands:
+  serve                                    Start the application server (default)
+  admin create --email <email> [--password-stdin]
+                                           Create an Administrator without an Invite
+  migrate                                  Create database indexes
+  seed-teams                               Insert any missing NFL teams
+  import-schedule [--dry-run] <file>       Import a season's schedules from a CSV, JSON or iCalendar file
+`
+
+// Run executes the subcommand named by the first of the provided command-line arguments. If no subcommand
+// is provided, the application server is started.
+//
+// args is the slice of command-line arguments, excluding the program name.
+func Run(args []string) error {
+	if len(args) == 0 {
+		return serve()
+	}
+
+	switch args[0] {
+	case "serve":
+		return serve()
+	case "admin":
+		return admin(args[1:])
+	case "migrate":
+		return migrate()
+	case "seed-teams":
+		return seedTeams()
+	case "import-schedule":
+		return importSchedule(args[1:])
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return nil
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+func serve() error {
+	app, err := server.NewAppServer()
+	if err != nil {
+		return err
+	}
+
+	log.Println("init complete")
+	app.Start()
+	return nil
+}
+
+func admin(args []string) error {
+	if len(args) == 0 || args[0] != "create" {
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("expected admin subcommand \"create\"")
+	}
+
+	// Parse the arguments
+	fs := flag.NewFlagSet("admin create", flag.ContinueOnError)
+	email := fs.String("email", "", "email address of the new Administrator")
+	pwdStdin := fs.Bool("password-stdin", false, "read the password from the first line of standard input")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if len(strings.TrimSpace(*email)) == 0 {
+		return fmt.Errorf("--email is required")
+	}
+
+	// Read the password, or generate one if none was provided
+	var pwd string
+	generated := !*pwdStdin
+	if *pwdStdin {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("failed to read password from stdin: %v", err)
+		}
+		pwd = strings.TrimRight(line, "\r\n")
+	} else {
+		var err error
+		if pwd, err = utils.CreateSecureAlphaNumToken(generatedPasswordLength); err != nil {
+			return fmt.Errorf("failed to generate password: %v", err)
+		}
+	}
+
+	// Create the Administrator
+	u, err := ioc.UserService().CreateAdministrator(strings.TrimSpace(*email), pwd)
+	if err != nil {
+		switch e := err.(type) {
+		case *types.EmailInUseError:
+			return fmt.Errorf("a user already exists with email %s", e.Email)
+		case *types.PasswordPolicyError:
+			return fmt.Errorf("password rejected: %s", e.Reason)
+		default:
+			return err
+		}
+	}
+
+	fmt.Printf("Created administrator %s (id=%s)\n", u.Email, u.ID)
+	if generated {
+		fmt.Printf("Password: %s\n", pwd)
+		fmt.Println("This password will not be shown again. Change it from the account settings page after logging in.")
+	}
+
+	return nil
+}
+
+func migrate() error {
+	migrations := []struct {
+		name   string
+		ensure func() error
+	}{
+		{"users", ioc.UserRepository().EnsureIndexes},
+		{"sessions", ioc.SessionRepository().EnsureIndexes},
+		{"invites", ioc.InviteRepository().EnsureIndexes},
+		{"apiTokens", ioc.APITokenRepository().EnsureIndexes},
+		{"oidcStates", ioc.OIDCStateRepository().EnsureIndexes},
+		{"loginAttempts", ioc.LoginAttemptRepository().EnsureIndexes},
+		{"auditEvents", ioc.AuditRepository().EnsureIndexes},
+		{"notifications", ioc.NotificationRepository().EnsureIndexes},
+		{"teams", ioc.TeamRepository().EnsureIndexes},
+		{"pools", ioc.PoolRepository().EnsureIndexes},
+		{"contestants", ioc.ContestantRepository().EnsureIndexes},
+		{"schedules", ioc.ScheduleRepository().EnsureIndexes},
+		{"entries", ioc.EntryRepository().EnsureIndexes},
+	}
+
+	for _, m := range migrations {
+		if err := m.ensure(); err != nil {
+			return err
+		}
+		fmt.Printf("Ensured indexes on %s\n", m.name)
+	}
+
+	return nil
+}
+
+func seedTeams() error {
+	repo := ioc.TeamRepository()
+
+	inserted := 0
+	for _, t := range nflTeams {
+		ok, err := repo.Insert(&t)
+		if err != nil {
+			return err
+		}
+		if ok {
+			inserted++
+		}
+	}
+
+	fmt.Printf("Inserted %d of %d teams (the rest already existed)\n", inserted, len(nflTeams))
+	return nil
+}
+
+func importSchedule(args []string) error {
+	// Parse the arguments
+	fs := flag.NewFlagSet("import-schedule", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "describe the changes without making them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("expected a single schedule file")
+	}
+
+	// Read the games from the file
+	name := fs.Arg(0)
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	importer := ioc.ScheduleImportService()
+	games, err := importer.Parse(name, f)
+	if err != nil {
+		return describeImportError(err)
+	}
+
+	// Import the games, describing the changes to each week
+	resp, err := importer.Import(games, *dryRun)
+	if err != nil {
+		return describeImportError(err)
+	}
+
+	for _, w := range resp.Weeks {
+		action := "existing"
+		if w.Created {
+			action = "new"
+		}
+		fmt.Printf("%d week %d (%s): %d added, %d kickoff(s) changed, %d unchanged, %d not in file\n",
+			w.Year, w.Week, action, len(w.Added), len(w.Updated), w.Unchanged, len(w.Unlisted))
+		for _, m := range w.Added {
+			fmt.Printf("  + %s at %s, %s\n", m.AwayTeam, m.HomeTeam, m.Kickoff.Format(time.RFC3339))
+		}
+		for _, m := range w.Updated {
+			fmt.Printf("  ~ %s at %s, %s -> %s\n", m.AwayTeam, m.HomeTeam, m.PreviousKickoff.Format(time.RFC3339), m.Kickoff.Format(time.RFC3339))
+		}
+	}
+	switch {
+	case !resp.Changed():
+		fmt.Println("The schedules are already up to date")
+	case *dryRun:
+		fmt.Println("Dry run: no changes were made")
+	}
+
+	return nil
+}
+
+// describeImportError describes errors in the schedule file in terms of the lines that they occurred on.
+func describeImportError(err error) error {
+	switch e := err.(type) {
+	case *types.ScheduleImportInvalidError:
+		if e.Line > 0 {
+			return fmt.Errorf("line %d: %s", e.Line, e.Reason)
+		}
+		return fmt.Errorf("%s", e.Reason)
+	case *types.ScheduleConflictError:
+		return fmt.Errorf("%d week %d would overlap with an existing schedule (id=%s)", e.Year, e.Week, e.ID)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,40 @@
+package cli
+
+import "github.com/mhs294/mulhall/internals/types"
+
+// nflTeams is the set of NFL Teams inserted by the seed-teams command. Each Team's shorthand matches its
+// logo under static/img and its style in static/css/team_button.css.
+var nflTeams = []types.Team{
+	{ID: "ARI", Shorthand: "ARI", Location: "Arizona", Name: "Cardinals"},
+	{ID: "ATL", Shorthand: "ATL", Location: "Atlanta", Name: "Falcons"},
+	{ID: "BAL", Shorthand: "BAL", Location: "Baltimore", Name: "Ravens"},
+	{ID: "BUF", Shorthand: "BUF", Location: "Buffalo", Name: "Bills"},
+	{ID: "CAR", Shorthand: "CAR", Location: "Carolina", Name: "Panthers"},
+	{ID: "CHI", Shorthand: "CHI", Location: "Chicago", Name: "Bears"},
+	{ID: "CIN", Shorthand: "CIN", Location: "Cincinnati", Name: "Bengals"},
+	{ID: "CLE", Shorthand: "CLE", Location: "Cleveland", Name: "Browns"},
+	{ID: "DAL", Shorthand: "DAL", Location: "Dallas", Name: "Cowboys"},
+	{ID: "DEN", Shorthand: "DEN", Location: "Denver", Name: "Broncos"},
+	{ID: "DET", Shorthand: "DET", Location: "Detroit", Name: "Lions"},
+	{ID: "GB", Shorthand: "GB", Location: "Green Bay", Name: "Packers"},
+	{ID: "HOU", Shorthand: "HOU", Location: "Houston", Name: "Texans"},
+	{ID: "IND", Shorthand: "IND", Location: "Indianapolis", Name: "Colts"},
+	{ID: "JAX", Shorthand: "JAX", Location: "Jacksonville", Name: "Jaguars"},
+	{ID: "KC", Shorthand: "KC", Location: "Kansas City", Name: "Chiefs"},
+	{ID: "LAC", Shorthand: "LAC", Location: "Los Angeles", Name: "Chargers"},
+	{ID: "LAR", Shorthand: "LAR", Location: "Los Angeles", Name: "Rams"},
+	{ID: "LV", Shorthand: "LV", Location: "Las Vegas", Name: "Raiders"},
+	{ID: "MIA", Shorthand: "MIA", Location: "Miami", Name: "Dolphins"},
+	{ID: "MIN", Shorthand: "MIN", Location: "Minnesota", Name: "Vikings"},
+	{ID: "NE", Shorthand: "NE", Location: "New England", Name: "Patriots"},
+	{ID: "NO", Shorthand: "NO", Location: "New Orleans", Name: "Saints"},
+	{ID: "NYG", Shorthand: "NYG", Location: "New York", Name: "Giants"},
+	{ID: "NYJ", Shorthand: "NYJ", Location: "New York", Name: "Jets"},
+	{ID: "PHI", Shorthand: "PHI", Location: "Philadelphia", Name: "Eagles"},
+	{ID: "PIT", Shorthand: "PIT", Location: "Pittsburgh", Name: "Steelers"},
+	{ID: "SEA", Shorthand: "SEA", Location: "Seattle", Name: "Seahawks"},
+	{ID: "SF", Shorthand: "SF", Location: "San Francisco", Name: "49ers"},
+	{ID: "TB", Shorthand: "TB", Location: "Tampa Bay", Name: "Buccaneers"},
+	{ID: "TEN", Shorthand: "TEN", Location: "Tennessee", Name: "Titans"},
+	{ID: "WAS", Shorthand: "WAS", Location: "Washington", Name: "Commanders"},
+}
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/middleware"
+	"github.com/mhs294/mulhall/internals/openapi"
+	"github.com/mhs294/mulhall/internals/services"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/utils"
+	"github.com/mhs294/mulhall/views/components"
+)
+
+// AdminController is responsible for handling requests for site Administration HTTP APIs.
+type AdminController struct {
+	logger          *log.Logger
+	userAuth        *middleware.UserAuthMiddleware
+	settingsService *services.SettingsService
+	userAdmin       *services.UserAdminService
+}
+
+// NewAdminController creates a new instance of an AdminController and returns a pointer to it.
+//
+// l is the pointer to the [log.Logger] that will be used at runtime by the AdminController.
+//
+// ua is the pointer to the UserAuthMiddleware used to authenticate and authorize Administrators.
+//
+// ss is the pointer to the SettingsService that will be used at runtime by the AdminController.
+//
+// uas is the pointer to the UserAdminService that will be used at runtime by the AdminController.
+func NewAdminController(l *log.Logger, ua *middleware.UserAuthMiddleware, ss *services.SettingsService, uas *services.UserAdminService) *AdminController {
+	return &AdminController{logger: l, userAuth: ua, settingsService: ss, userAdmin: uas}
+}
+
+// RegisterHandlers defines this controller's HTTP routes and their corresponding handler functions.
+func (c *AdminController) RegisterHandlers(e *gin.Engine) {
+	adm := newAPIGroup(e, "/admin", c.userAuth.APIAuth, c.userAuth.AdminAuth)
+	{
+		adm.GET("/settings", c.settings)
+		adm.POST("/settings/mfa", c.setMFARequirement)
+		adm.GET("/users", c.users)
+		adm.GET("/users/:id", c.user)
+		adm.POST("/users/:id/active", c.setActive)
+		adm.POST("/users/:id/administrator", c.setAdministrator)
+	}
+}
+
+// DescribeRoutes describes this controller's HTTP routes for the OpenAPI document.
+func (c *AdminController) DescribeRoutes() []openapi.Route {
+	return apiRoutes([]openapi.Route{
+		{Method: http.MethodGet, Path: "/admin/settings", Summary: "Get the application Settings",
+			Auth: openapi.AuthAdmin, Response: types.Settings{}},
+		{Method: http.MethodPost, Path: "/admin/settings/mfa", Summary: "Require (or stop requiring) a second authentication factor for Administrators",
+			Auth: openapi.AuthAdmin, Request: types.SetMFARequirementRequest{}},
+		{Method: http.MethodGet, Path: "/admin/users", Summary: "Search Users by email address",
+			Auth: openapi.AuthAdmin, Query: []openapi.Param{{Name: "q", Description: "Text to search for"}}, Response: []types.User{}},
+		{Method: http.MethodGet, Path: "/admin/users/:id", Summary: "Get a User's account details and Contestant memberships",
+			Auth: openapi.AuthAdmin, Response: types.UserDetailsResponse{}},
+		{Method: http.MethodPost, Path: "/admin/users/:id/active", Summary: "Activate or deactivate a User's account",
+			Auth: openapi.AuthAdmin, Request: types.SetUserActiveRequest{}, Response: types.User{}},
+		{Method: http.MethodPost, Path: "/admin/users/:id/administrator", Summary: "Grant or revoke a User's Administrator privileges",
+			Auth: openapi.AuthAdmin, Request: types.SetAdministratorRequest{}, Response: types.User{}},
+	})
+}
+
+func (c *AdminController) settings(ctx *gin.Context) {
+	settings, err := c.settingsService.Get()
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+func (c *AdminController) setMFARequirement(ctx *gin.Context) {
+	var req *types.SetMFARequirementRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal SetMFARequirementRequest from json: %v", err)
+		return
+	}
+
+	if err := c.settingsService.SetRequireAdministratorMFA(req.Required); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (c *AdminController) users(ctx *gin.Context) {
+	users, err := c.userAdmin.Search(ctx.Query("q"))
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, users)
+}
+
+func (c *AdminController) user(ctx *gin.Context) {
+	details, err := c.userAdmin.GetDetails(types.UserID(ctx.Param("id")))
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, details)
+}
+
+func (c *AdminController) setActive(ctx *gin.Context) {
+	var req *types.SetUserActiveRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal SetUserActiveRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	u, err := c.userAdmin.SetActive(sess.User, types.UserID(ctx.Param("id")), req.Active)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusOK, u, components.AdminUserActions(u))
+}
+
+func (c *AdminController) setAdministrator(ctx *gin.Context) {
+	var req *types.SetAdministratorRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal SetAdministratorRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	u, err := c.userAdmin.SetAdministrator(sess.User, types.UserID(ctx.Param("id")), req.Administrator)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusOK, u, components.AdminUserActions(u))
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/live"
+	"github.com/mhs294/mulhall/internals/middleware"
+	"github.com/mhs294/mulhall/internals/openapi"
+)
+
+const (
+	// apiPrefix is the path prefix under which the current version of the JSON API is registered.
+	apiPrefix = "/api/v1"
+
+	// keepAliveInterval is how often an idle stream of live updates sends a comment, so that proxies do not close it.
+	keepAliveInterval = 30 * time.Second
+)
+
+// apiGroup registers JSON API routes under apiPrefix. During the transition to the versioned API, each route is
+// also registered at its legacy (unversioned) path, where responses are marked as deprecated.
+type apiGroup struct {
+	current *gin.RouterGroup
+	legacy  *gin.RouterGroup
+}
+
+// newAPIGroup creates a new apiGroup for the routes under the provided (unversioned) path.
+//
+// e is the pointer to the [gin.Engine] with which the routes are registered.
+//
+// path is the unversioned path prefix shared by the routes in the group (e.g. - "/pool").
+//
+// handlers are the middleware handlers applied to every route in the group.
+func newAPIGroup(e *gin.Engine, path string, handlers ...gin.HandlerFunc) *apiGroup {
+	legacy := append([]gin.HandlerFunc{middleware.Deprecated(apiPrefix)}, handlers...)
+	return &apiGroup{current: e.Group(apiPrefix+path, handlers...), legacy: e.Group(path, legacy...)}
+}
+
+// GET registers the handlers for GET requests to the provided path, relative to the group.
+func (g *apiGroup) GET(path string, handlers ...gin.HandlerFunc) {
+	g.current.GET(path, handlers...)
+	g.legacy.GET(path, handlers...)
+}
+
+// POST registers the handlers for POST requests to the provided path, relative to the group.
+func (g *apiGroup) POST(path string, handlers ...gin.HandlerFunc) {
+	g.current.POST(path, handlers...)
+	g.legacy.POST(path, handlers...)
+}
+
+// apiRoutes describes JSON API routes (whose paths are unversioned) as they are registered by an apiGroup:
+// once under apiPrefix, and once, deprecated, at the legacy path.
+func apiRoutes(routes []openapi.Route) []openapi.Route {
+	described := make([]openapi.Route, 0, len(routes)*2)
+	for _, r := range routes {
+		legacy := r
+		legacy.Deprecated = true
+		r.Path = apiPrefix + r.Path
+		described = append(described, r, legacy)
+	}
+
+	return described
+}
+
+// respond writes a successful response in the representation requested by the client: htmx requests receive
+// the rendered HTML fragment, while all other requests receive the data as JSON (or no body, if data is nil).
+func respond(ctx *gin.Context, status int, data any, fragment templ.Component) {
+	if middleware.IsHTMXRequest(ctx) && fragment != nil {
+		render(ctx, status, fragment)
+		return
+	}
+
+	if data == nil {
+		ctx.Status(status)
+		return
+	}
+	ctx.JSON(status, data)
+}
+
+// stream writes the live updates received by the Subscription to the client as Server-Sent Events, named for each
+// update's Event and containing the update as JSON, until the client disconnects. The Subscription is closed once
+// the client has disconnected.
+func stream(ctx *gin.Context, sub *live.Subscription) {
+	defer sub.Close()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	io.WriteString(ctx.Writer, ": connected\n\n")
+	ctx.Writer.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case u, ok := <-sub.Updates:
+			if !ok {
+				return false
+			}
+			ctx.SSEvent(string(u.Event), u)
+		case <-keepAlive.C:
+			io.WriteString(w, ": keep-alive\n\n")
+		}
+		return true
+	})
+}
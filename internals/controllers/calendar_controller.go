@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/ical"
+	"github.com/mhs294/mulhall/internals/middleware"
+	"github.com/mhs294/mulhall/internals/openapi"
+	"github.com/mhs294/mulhall/internals/services"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/views/components"
+)
+
+// feedPath is the path of the calendar feed, which is authenticated by a feed token rather than a Session.
+const feedPath = "/calendar/feed.ics"
+
+// CalendarController is responsible for handling requests for the calendar feed and the HTTP APIs that manage it.
+type CalendarController struct {
+	logger     *log.Logger
+	userAuth   *middleware.UserAuthMiddleware
+	calService *services.CalendarService
+}
+
+// NewCalendarController creates a new instance of a CalendarController and returns a pointer to it.
+//
+// l is the pointer to the [log.Logger] that will be used at runtime by the CalendarController.
+//
+// ua is the pointer to the UserAuthMiddleware used to authenticate requests to manage the logged in User's feed.
+//
+// s is the pointer to the CalendarService that will be used at runtime by the CalendarController.
+func NewCalendarController(l *log.Logger, ua *middleware.UserAuthMiddleware, s *services.CalendarService) *CalendarController {
+	return &CalendarController{logger: l, userAuth: ua, calService: s}
+}
+
+// RegisterHandlers defines this controller's HTTP routes and their corresponding handler functions.
+func (c *CalendarController) RegisterHandlers(e *gin.Engine) {
+	e.GET(feedPath, c.feed)
+
+	cal := newAPIGroup(e, "/user/calendar", c.userAuth.APIAuth)
+	{
+		cal.POST("/reset", c.reset)
+		cal.POST("/revoke", c.revoke)
+	}
+}
+
+// DescribeRoutes describes this controller's HTTP routes for the OpenAPI document.
+func (c *CalendarController) DescribeRoutes() []openapi.Route {
+	return append([]openapi.Route{
+		{Method: http.MethodGet, Path: feedPath, Summary: "iCalendar feed of the season's pick deadlines and the User's picked Matchups",
+			Query: []openapi.Param{{Name: "token", Description: "Feed token of the User whose calendar is described", Required: true}}},
+	}, apiRoutes([]openapi.Route{
+		{Method: http.MethodPost, Path: "/user/calendar/reset", Summary: "Create a new calendar feed link, disabling the previous one",
+			Auth: openapi.AuthAPI, Response: types.CalendarFeedResponse{}},
+		{Method: http.MethodPost, Path: "/user/calendar/revoke", Summary: "Disable the User's calendar feed",
+			Auth: openapi.AuthAPI},
+	})...)
+}
+
+func (c *CalendarController) feed(ctx *gin.Context) {
+	cal, err := c.calService.GetFeed(ctx.Query("token"))
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Type", "text/calendar; charset=utf-8")
+	ctx.Header("Content-Disposition", `inline; filename="mulhall.ics"`)
+	ctx.Status(http.StatusOK)
+	if err := ical.Write(ctx.Writer, cal); err != nil {
+		c.logger.Printf("failed to write calendar feed: %v", err)
+	}
+}
+
+func (c *CalendarController) reset(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	token, err := c.calService.ResetFeedToken(sess.User)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	res := &types.CalendarFeedResponse{URL: feedURL(ctx, token)}
+	respond(ctx, http.StatusOK, res, components.CalendarFeed(true, res.URL))
+}
+
+func (c *CalendarController) revoke(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	if err := c.calService.RevokeFeedToken(sess.User); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusOK, nil, components.CalendarFeed(false, ""))
+}
+
+// feedURL returns the absolute URL of the calendar feed authenticated by the provided feed token, relative to the
+// host that the request was made to.
+func feedURL(ctx *gin.Context, token string) string {
+	scheme := "http"
+	if ctx.Request.TLS != nil || ctx.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+
+	return fmt.Sprintf("%s://%s%s?token=%s", scheme, ctx.Request.Host, feedPath, url.QueryEscape(token))
+}
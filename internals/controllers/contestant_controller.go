@@ -0,0 +1,220 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/middleware"
+	"github.com/mhs294/mulhall/internals/openapi"
+	"github.com/mhs294/mulhall/internals/services"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/utils"
+)
+
+// ContestantController is responsible for handling requests for Contestant HTTP APIs.
+type ContestantController struct {
+	logger       *log.Logger
+	userAuth     *middleware.UserAuthMiddleware
+	conService   *services.ContestantService
+	liveService  *services.LiveUpdateService
+	notifService *services.NotificationService
+}
+
+// NewContestantController creates a new instance of a ContestantController and returns a pointer to it.
+//
+// l is the pointer to the [log.Logger] that will be used at runtime by the ContestantController.
+//
+// ua is the pointer to the UserAuthMiddleware used to authenticate requests from Contestant members and
+// authorize Administrators.
+//
+// s is the pointer to the ContestantService that will be used at runtime by the ContestantController.
+//
+// ls is the pointer to the LiveUpdateService used to stream live updates to a Contestant's members and to publish
+// eliminations.
+//
+// ns is the pointer to the NotificationService used to notify a Contestant's members that it was eliminated.
+func NewContestantController(l *log.Logger, ua *middleware.UserAuthMiddleware, s *services.ContestantService, ls *services.LiveUpdateService, ns *services.NotificationService) *ContestantController {
+	return &ContestantController{logger: l, userAuth: ua, conService: s, liveService: ls, notifService: ns}
+}
+
+// RegisterHandlers defines this controller's HTTP routes and their corresponding handler functions.
+func (c *ContestantController) RegisterHandlers(e *gin.Engine) {
+	con := newAPIGroup(e, "/contestant", c.userAuth.APIAuth)
+	{
+		con.GET("", c.list)
+		con.GET("/:id", c.get)
+		con.GET("/:id/events", c.events)
+		con.POST("/create", c.userAuth.AdminAuth, c.create)
+		con.POST("/:id/status", c.userAuth.AdminAuth, c.setStatus)
+		con.POST("/:id/deactivate", c.userAuth.AdminAuth, c.deactivate)
+		con.POST("/:id/role", c.setRole)
+		con.POST("/:id/transfer", c.transfer)
+		con.POST("/:id/leave", c.leave)
+	}
+}
+
+// DescribeRoutes describes this controller's HTTP routes for the OpenAPI document.
+func (c *ContestantController) DescribeRoutes() []openapi.Route {
+	return apiRoutes([]openapi.Route{
+		{Method: http.MethodGet, Path: "/contestant", Summary: "List the Contestants that the User is a member of",
+			Auth: openapi.AuthAPI, Response: []types.Contestant{}},
+		{Method: http.MethodGet, Path: "/contestant/:id", Summary: "Get a Contestant that the User is a member of",
+			Auth: openapi.AuthAPI, Response: types.Contestant{}},
+		{Method: http.MethodGet, Path: "/contestant/:id/events", Summary: "Stream live updates to the picks of a Contestant that the User is a member of",
+			Auth: openapi.AuthAPI, Response: types.LiveUpdateResponse{}, Stream: true},
+		{Method: http.MethodPost, Path: "/contestant/create", Summary: "Create a Contestant",
+			Auth: openapi.AuthAdmin, Request: types.CreateContestantRequest{}, Response: types.Contestant{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/contestant/:id/status", Summary: "Set a Contestant's Status",
+			Auth: openapi.AuthAdmin, Request: types.SetContestantStatusRequest{}},
+		{Method: http.MethodPost, Path: "/contestant/:id/deactivate", Summary: "Deactivate a Contestant",
+			Auth: openapi.AuthAdmin},
+		{Method: http.MethodPost, Path: "/contestant/:id/role", Summary: "Promote or demote one of a Contestant's members (OWNER only)",
+			Auth: openapi.AuthAPI, Request: types.SetContestantRoleRequest{}},
+		{Method: http.MethodPost, Path: "/contestant/:id/transfer", Summary: "Transfer ownership of a Contestant to another member (OWNER only)",
+			Auth: openapi.AuthAPI, Request: types.TransferOwnershipRequest{}},
+		{Method: http.MethodPost, Path: "/contestant/:id/leave", Summary: "Leave a Contestant",
+			Auth: openapi.AuthAPI},
+	})
+}
+
+func (c *ContestantController) list(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	cons, err := c.conService.GetByAuthorizedUser(sess.User)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cons)
+}
+
+func (c *ContestantController) get(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	con, err := c.conService.GetAsMember(sess.User, types.ContestantID(ctx.Param("id")))
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, con)
+}
+
+func (c *ContestantController) events(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	sub, err := c.liveService.SubscribeContestant(sess.User, types.ContestantID(ctx.Param("id")))
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	stream(ctx, sub)
+}
+
+func (c *ContestantController) create(ctx *gin.Context) {
+	var req *types.CreateContestantRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal CreateContestantRequest from json: %v", err)
+		return
+	}
+
+	con, err := c.conService.Create(req)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, con)
+}
+
+func (c *ContestantController) setStatus(ctx *gin.Context) {
+	var req *types.SetContestantStatusRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal SetContestantStatusRequest from json: %v", err)
+		return
+	}
+
+	id := types.ContestantID(ctx.Param("id"))
+	changed, err := c.conService.SetStatus(id, req.Status)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+	if !changed {
+		ctx.Status(http.StatusOK)
+		return
+	}
+	if err := c.liveService.StatusChanged(id, req.Status); err != nil {
+		c.logger.Printf("failed to publish status change of contestant %s: %v", id, err)
+	}
+	if _, err := c.notifService.SendElimination(id, req.Status, 0); err != nil {
+		c.logger.Printf("failed to notify status change of contestant %s: %v", id, err)
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (c *ContestantController) deactivate(ctx *gin.Context) {
+	// Verify that the Contestant exists before deactivating it
+	id := types.ContestantID(ctx.Param("id"))
+	if _, err := c.conService.GetByID(id); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	if err := c.conService.Deactivate(id); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (c *ContestantController) setRole(ctx *gin.Context) {
+	var req *types.SetContestantRoleRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal SetContestantRoleRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	conID := types.ContestantID(ctx.Param("id"))
+	if err := c.conService.ChangeMemberRole(sess.User, conID, req.UserID, req.Role); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (c *ContestantController) transfer(ctx *gin.Context) {
+	var req *types.TransferOwnershipRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal TransferOwnershipRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	conID := types.ContestantID(ctx.Param("id"))
+	if err := c.conService.TransferOwnership(sess.User, conID, req.UserID); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (c *ContestantController) leave(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	conID := types.ContestantID(ctx.Param("id"))
+	if err := c.conService.Leave(sess.User, conID); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
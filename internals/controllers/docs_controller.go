@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/openapi"
+	"github.com/mhs294/mulhall/views"
+)
+
+// DocsController is responsible for serving the OpenAPI document describing the application's HTTP routes.
+type DocsController struct {
+	doc *openapi.Document
+}
+
+// NewDocsController creates a new instance of a DocsController and returns a pointer to it.
+//
+// d is the pointer to the OpenAPI Document describing the application's HTTP routes.
+func NewDocsController(d *openapi.Document) *DocsController {
+	return &DocsController{doc: d}
+}
+
+// RegisterHandlers defines this controller's HTTP routes and their corresponding handler functions.
+func (c *DocsController) RegisterHandlers(e *gin.Engine) {
+	api := e.Group("/api")
+	{
+		api.GET("/openapi.json", c.spec)
+		api.GET("/docs", c.docs)
+	}
+}
+
+// DescribeRoutes describes this controller's HTTP routes for the OpenAPI document.
+func (c *DocsController) DescribeRoutes() []openapi.Route {
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/api/openapi.json", Summary: "Get this OpenAPI document",
+			Response: map[string]any{}},
+		{Method: http.MethodGet, Path: "/api/docs", Summary: "API documentation page", HTML: true},
+	}
+}
+
+func (c *DocsController) spec(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.doc)
+}
+
+func (c *DocsController) docs(ctx *gin.Context) {
+	render(ctx, http.StatusOK, views.APIDocs(c.doc))
+}
@@ -0,0 +1,219 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/middleware"
+	"github.com/mhs294/mulhall/internals/openapi"
+	"github.com/mhs294/mulhall/internals/services"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/scopes"
+	"github.com/mhs294/mulhall/internals/utils"
+	"github.com/mhs294/mulhall/views/components"
+)
+
+// EntryController is responsible for handling requests for Entry and pick HTTP APIs.
+type EntryController struct {
+	logger       *log.Logger
+	userAuth     *middleware.UserAuthMiddleware
+	entryService *services.EntryService
+	picksService *services.PicksService
+	liveService  *services.LiveUpdateService
+}
+
+// NewEntryController creates a new instance of an EntryController and returns a pointer to it.
+//
+// l is the pointer to the [log.Logger] that will be used at runtime by the EntryController.
+//
+// ua is the pointer to the UserAuthMiddleware used to authenticate requests from Contestant members.
+//
+// s is the pointer to the EntryService that will be used at runtime by the EntryController.
+//
+// ps is the pointer to the PicksService used to render the PicksBoard in response to htmx requests.
+//
+// ls is the pointer to the LiveUpdateService used to notify a Contestant's other members of changes to its picks.
+func NewEntryController(l *log.Logger, ua *middleware.UserAuthMiddleware, s *services.EntryService, ps *services.PicksService, ls *services.LiveUpdateService) *EntryController {
+	return &EntryController{logger: l, userAuth: ua, entryService: s, picksService: ps, liveService: ls}
+}
+
+// RegisterHandlers defines this controller's HTTP routes and their corresponding handler functions.
+func (c *EntryController) RegisterHandlers(e *gin.Engine) {
+	// Picks can be made with a personal APIToken that has the PICKS_WRITE Scope
+	pickAuth := c.userAuth.ScopedAPIAuth(scopes.PICKS_WRITE)
+
+	ent := newAPIGroup(e, "/entry")
+	{
+		ent.GET("", c.userAuth.APIAuth, c.list)
+		ent.GET("/:id", c.userAuth.APIAuth, c.get)
+		ent.POST("/create", c.userAuth.APIAuth, c.create)
+		ent.POST("/pick/select", pickAuth, c.selectPick)
+		ent.POST("/pick/clear", pickAuth, c.clearPick)
+		ent.POST("/pick/suggest", pickAuth, c.suggestPick)
+		ent.POST("/pick/unsuggest", pickAuth, c.unsuggestPick)
+		ent.POST("/:id/deactivate", c.userAuth.APIAuth, c.userAuth.AdminAuth, c.deactivate)
+	}
+}
+
+// DescribeRoutes describes this controller's HTTP routes for the OpenAPI document.
+func (c *EntryController) DescribeRoutes() []openapi.Route {
+	return apiRoutes([]openapi.Route{
+		{Method: http.MethodGet, Path: "/entry", Summary: "List a Contestant's Entries",
+			Auth: openapi.AuthAPI, Query: []openapi.Param{{Name: "contestant", Required: true}}, Response: []types.Entry{}},
+		{Method: http.MethodGet, Path: "/entry/:id", Summary: "Get an Entry",
+			Auth: openapi.AuthAPI, Response: types.Entry{}},
+		{Method: http.MethodPost, Path: "/entry/create", Summary: "Create an Entry for a Contestant and Schedule (OWNER or MANAGER only)",
+			Auth: openapi.AuthAPI, Request: types.CreateEntryRequest{}, Response: types.Entry{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/entry/pick/select", Summary: "Select the pick for an Entry (OWNER or MANAGER only)",
+			Auth: openapi.AuthAPI, Scope: scopes.PICKS_WRITE, Request: types.SavePickRequest{}, Response: types.Entry{}},
+		{Method: http.MethodPost, Path: "/entry/pick/clear", Summary: "Clear the Selected pick for an Entry (OWNER or MANAGER only)",
+			Auth: openapi.AuthAPI, Scope: scopes.PICKS_WRITE, Request: types.ClearPickRequest{}, Response: types.Entry{}},
+		{Method: http.MethodPost, Path: "/entry/pick/suggest", Summary: "Suggest a pick for an Entry",
+			Auth: openapi.AuthAPI, Scope: scopes.PICKS_WRITE, Request: types.SavePickRequest{}, Response: types.Entry{}},
+		{Method: http.MethodPost, Path: "/entry/pick/unsuggest", Summary: "Remove a Suggested pick from an Entry (OWNER or MANAGER only, unless the User made the suggestion)",
+			Auth: openapi.AuthAPI, Scope: scopes.PICKS_WRITE, Request: types.RemoveSuggestedPickRequest{}, Response: types.Entry{}},
+		{Method: http.MethodPost, Path: "/entry/:id/deactivate", Summary: "Deactivate an Entry",
+			Auth: openapi.AuthAdmin},
+	})
+}
+
+func (c *EntryController) list(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	entries, err := c.entryService.GetByContestant(sess.User, types.ContestantID(ctx.Query("contestant")))
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entries)
+}
+
+func (c *EntryController) get(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	entry, err := c.entryService.GetByID(sess.User, types.EntryID(ctx.Param("id")))
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entry)
+}
+
+func (c *EntryController) create(ctx *gin.Context) {
+	var req *types.CreateEntryRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal CreateEntryRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	entry, err := c.entryService.Create(sess.User, req)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entry)
+}
+
+func (c *EntryController) selectPick(ctx *gin.Context) {
+	var req *types.SavePickRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal SavePickRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	entry, err := c.entryService.SetSelectedPick(sess.User, req)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	c.respondWithPicks(ctx, entry)
+}
+
+func (c *EntryController) clearPick(ctx *gin.Context) {
+	var req *types.ClearPickRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal ClearPickRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	entry, err := c.entryService.ClearSelectedPick(sess.User, req.EntryID)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	c.respondWithPicks(ctx, entry)
+}
+
+func (c *EntryController) suggestPick(ctx *gin.Context) {
+	var req *types.SavePickRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal SavePickRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	entry, err := c.entryService.AddSuggestedPick(sess.User, req)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	c.respondWithPicks(ctx, entry)
+}
+
+func (c *EntryController) unsuggestPick(ctx *gin.Context) {
+	var req *types.RemoveSuggestedPickRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal RemoveSuggestedPickRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	entry, err := c.entryService.RemoveSuggestedPick(sess.User, req.EntryID, req.MatchupID)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	c.respondWithPicks(ctx, entry)
+}
+
+// respondWithPicks responds to a successful change to an Entry's picks, after notifying the Contestant's members of
+// the change. htmx requests receive the re-rendered PicksBoard, while all other requests receive the updated Entry
+// as JSON.
+func (c *EntryController) respondWithPicks(ctx *gin.Context, entry *types.Entry) {
+	c.liveService.PicksChanged(entry)
+	if !middleware.IsHTMXRequest(ctx) {
+		ctx.JSON(http.StatusOK, entry)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	picks, err := c.picksService.GetEntryPicks(sess.User, entry)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+	respond(ctx, http.StatusOK, entry, components.PicksBoard(picks))
+}
+
+func (c *EntryController) deactivate(ctx *gin.Context) {
+	if err := c.entryService.Deactivate(types.EntryID(ctx.Param("id"))); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
@@ -5,14 +5,18 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/middleware"
+	"github.com/mhs294/mulhall/internals/openapi"
 	"github.com/mhs294/mulhall/internals/services"
 	"github.com/mhs294/mulhall/internals/types"
 	"github.com/mhs294/mulhall/internals/utils"
+	"github.com/mhs294/mulhall/views/components"
 )
 
 // InviteController is responsible for handling requests for Invite HTTP APIs.
 type InviteController struct {
 	logger        *log.Logger
+	userAuth      *middleware.UserAuthMiddleware
 	inviteService *services.InviteService
 }
 
@@ -20,69 +24,100 @@ type InviteController struct {
 //
 // l is the pointer to the [log.Logger] that will be used at runtime by the InviteController.
 //
+// ua is the pointer to the UserAuthMiddleware used to authenticate requests from inviting Users.
+//
 // s is the pointer to the InviteService that will be used at runtime by the InviteController.
-func NewInviteController(l *log.Logger, s *services.InviteService) *InviteController {
-	return &InviteController{logger: l, inviteService: s}
+func NewInviteController(l *log.Logger, ua *middleware.UserAuthMiddleware, s *services.InviteService) *InviteController {
+	return &InviteController{logger: l, userAuth: ua, inviteService: s}
 }
 
 // RegisterHandlers defines this controller's HTTP routes and their corresponding handler functions.
 func (c *InviteController) RegisterHandlers(e *gin.Engine) {
-	inv := e.Group("/invite")
+	inv := newAPIGroup(e, "/invite")
 	{
-		inv.POST("/create", c.create)
+		inv.POST("/check", c.userAuth.APIAuth, c.check)
+		inv.POST("/create", c.userAuth.APIAuth, c.create)
 		inv.GET("/accept", c.accept)
 	}
 }
 
+// DescribeRoutes describes this controller's HTTP routes for the OpenAPI document.
+func (c *InviteController) DescribeRoutes() []openapi.Route {
+	return apiRoutes([]openapi.Route{
+		{Method: http.MethodPost, Path: "/invite/check", Summary: "Check a partially completed Invite and describe the Contestants and Roles available",
+			Auth: openapi.AuthAPI, Request: types.CheckInviteRequest{}, Response: types.InviteFormResponse{}},
+		{Method: http.MethodPost, Path: "/invite/create", Summary: "Create an Invite for a new User to join a Contestant (OWNER or MANAGER only) or own a new one (Administrators only)",
+			Auth: openapi.AuthAPI, Request: types.CreateInviteRequest{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/invite/accept", Summary: "Verify that an Invite is valid and can be accepted",
+			Query: []openapi.Param{{Name: "email", Required: true}, {Name: "token", Required: true}}},
+	})
+}
+
 func (c *InviteController) create(ctx *gin.Context) {
 	var req *types.CreateInviteRequest
 	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
-		ctx.AbortWithStatus(http.StatusBadRequest)
+		middleware.AbortWithError(ctx, err)
 		c.logger.Printf("failed to unmarhsal CreateInviteRequest from json: %v", err)
 		return
 	}
 
-	_, err := c.inviteService.Create(req)
+	sess := middleware.SessionFromContext(ctx)
+	inv, err := c.inviteService.Create(sess.User, req)
 	if err != nil {
-		ctx.AbortWithStatus(http.StatusInternalServerError)
-		c.logger.Printf("failed to create invite: %v", err)
+		middleware.AbortWithError(ctx, err)
 		return
 	}
 
-	ctx.Status(http.StatusCreated)
+	// htmx requests receive a fresh InviteForm so that another Invite can be sent
+	if !middleware.IsHTMXRequest(ctx) {
+		ctx.Status(http.StatusCreated)
+		return
+	}
+	form, err := c.inviteService.Check(sess.User, &types.CheckInviteRequest{ContestantID: inv.Contestant, NewContestant: len(inv.Contestant) == 0})
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+	form.Sent = inv.Email
+	respond(ctx, http.StatusCreated, nil, components.InviteForm(form))
+}
+
+func (c *InviteController) check(ctx *gin.Context) {
+	var req *types.CheckInviteRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal CheckInviteRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	form, err := c.inviteService.Check(sess.User, req)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusOK, form, components.InviteForm(form))
 }
 
 func (c *InviteController) accept(ctx *gin.Context) {
 	email := ctx.Query("email")
 	if len(email) == 0 {
 		c.logger.Printf("attempted to validate an invite with no email")
-		ctx.AbortWithStatus(http.StatusBadRequest)
+		middleware.AbortWithError(ctx, &types.RequestInvalidError{Reason: "email is required"})
 		return
 	}
 
 	token := ctx.Query("token")
 	if len(token) == 0 {
 		c.logger.Printf("attempted to validate an invite with no token")
-		ctx.AbortWithStatus(http.StatusBadRequest)
+		middleware.AbortWithError(ctx, &types.RequestInvalidError{Reason: "token is required"})
 		return
 	}
 
 	if _, err := c.inviteService.Validate(email, token); err != nil {
-		switch err.(type) {
-		case *types.InviteNotFoundError:
-			ctx.AbortWithStatus(http.StatusNotFound)
-			return
-		case *types.InviteExpiredError:
-			ctx.AbortWithStatus(http.StatusGone)
-			return
-		case *types.InviteAlreadyAcceptedError:
-			ctx.AbortWithStatus(http.StatusConflict)
-			return
-		default:
-			ctx.AbortWithStatus(http.StatusInternalServerError)
-			c.logger.Printf("unexpected error occurred while validating invite: %v", err)
-			return
-		}
+		middleware.AbortWithError(ctx, err)
+		return
 	}
 
 	ctx.Status(http.StatusOK)
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/middleware"
+	"github.com/mhs294/mulhall/internals/openapi"
+	"github.com/mhs294/mulhall/internals/services"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/utils"
+	"github.com/mhs294/mulhall/views/components"
+)
+
+// NotificationController is responsible for handling requests for the logged in User's Notification HTTP APIs.
+type NotificationController struct {
+	logger       *log.Logger
+	userAuth     *middleware.UserAuthMiddleware
+	notifService *services.NotificationService
+}
+
+// NewNotificationController creates a new instance of a NotificationController and returns a pointer to it.
+//
+// l is the pointer to the [log.Logger] that will be used at runtime by the NotificationController.
+//
+// ua is the pointer to the UserAuthMiddleware used to authenticate requests for the logged in User's Notifications.
+//
+// s is the pointer to the NotificationService that will be used at runtime by the NotificationController.
+func NewNotificationController(l *log.Logger, ua *middleware.UserAuthMiddleware, s *services.NotificationService) *NotificationController {
+	return &NotificationController{logger: l, userAuth: ua, notifService: s}
+}
+
+// RegisterHandlers defines this controller's HTTP routes and their corresponding handler functions.
+func (c *NotificationController) RegisterHandlers(e *gin.Engine) {
+	notif := newAPIGroup(e, "/user/notifications", c.userAuth.APIAuth)
+	{
+		notif.GET("", c.list)
+		notif.POST("/read", c.markRead)
+		notif.GET("/preferences", c.preferences)
+		notif.POST("/preferences", c.updatePreferences)
+		notif.POST("/mute", middleware.Superseded(apiPrefix+"/user/notifications/preferences"), c.mute)
+	}
+}
+
+// DescribeRoutes describes this controller's HTTP routes for the OpenAPI document.
+func (c *NotificationController) DescribeRoutes() []openapi.Route {
+	return apiRoutes([]openapi.Route{
+		{Method: http.MethodGet, Path: "/user/notifications", Summary: "List the Notifications delivered in the app to the User, newest first",
+			Auth: openapi.AuthAPI, Response: []types.Notification{}},
+		{Method: http.MethodPost, Path: "/user/notifications/read", Summary: "Mark the User's Notifications as read",
+			Auth: openapi.AuthAPI, Request: types.MarkNotificationsReadRequest{}, Response: []types.Notification{}},
+		{Method: http.MethodGet, Path: "/user/notifications/preferences", Summary: "Describe how and when the User is notified",
+			Auth: openapi.AuthAPI, Response: types.NotificationPreferencesResponse{}},
+		{Method: http.MethodPost, Path: "/user/notifications/preferences", Summary: "Change how and when the User is notified",
+			Auth: openapi.AuthAPI, Request: types.UpdateNotificationPreferencesRequest{}, Response: types.NotificationPreferencesResponse{}},
+		{Method: http.MethodPost, Path: "/user/notifications/mute", Summary: "Opt the User out of (or back in to) a kind of Notification (use /user/notifications/preferences instead)",
+			Deprecated: true, Auth: openapi.AuthAPI, Request: types.MuteNotificationsRequest{}, Response: types.User{}},
+	})
+}
+
+func (c *NotificationController) list(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	ns, err := c.notifService.GetByUser(sess.User)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusOK, ns, components.NotificationList(ns))
+}
+
+func (c *NotificationController) markRead(ctx *gin.Context) {
+	var req *types.MarkNotificationsReadRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal MarkNotificationsReadRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	if err := c.notifService.MarkRead(sess.User, req.IDs); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	c.list(ctx)
+}
+
+func (c *NotificationController) preferences(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	res, err := c.notifService.GetPreferences(sess.User)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusOK, res, components.NotificationPreferencesForm(res))
+}
+
+func (c *NotificationController) updatePreferences(ctx *gin.Context) {
+	var req *types.UpdateNotificationPreferencesRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal UpdateNotificationPreferencesRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	res, err := c.notifService.UpdatePreferences(sess.User, req)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusOK, res, components.NotificationPreferencesForm(res))
+}
+
+func (c *NotificationController) mute(ctx *gin.Context) {
+	var req *types.MuteNotificationsRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal MuteNotificationsRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	u, err := c.notifService.SetMuted(sess.User, req)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, u)
+}
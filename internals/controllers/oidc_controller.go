@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/env"
+	"github.com/mhs294/mulhall/internals/middleware"
+	"github.com/mhs294/mulhall/internals/openapi"
+	"github.com/mhs294/mulhall/internals/services"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/utils"
+)
+
+// oidcStateCookie is the name of the cookie that binds an in-progress OpenID Connect login to the browser
+// that started it, so that a callback cannot be replayed into another User's browser.
+const oidcStateCookie = "mulhall.oidcState"
+
+// OIDCController is responsible for handling requests for OpenID Connect login HTTP APIs.
+type OIDCController struct {
+	logger      *log.Logger
+	oidcService *services.OIDCService
+}
+
+// NewOIDCController creates a new instance of an OIDCController and returns a pointer to it.
+//
+// l is the pointer to the [log.Logger] that will be used at runtime by the OIDCController.
+//
+// s is the pointer to the OIDCService that will be used at runtime by the OIDCController.
+func NewOIDCController(l *log.Logger, s *services.OIDCService) *OIDCController {
+	return &OIDCController{logger: l, oidcService: s}
+}
+
+// RegisterHandlers defines this controller's HTTP routes and their corresponding handler functions.
+func (c *OIDCController) RegisterHandlers(e *gin.Engine) {
+	api := newAPIGroup(e, "/auth/oidc")
+	{
+		api.GET("/providers", c.providers)
+	}
+
+	// The login flow is navigated by the browser (and the callback URL is registered with each provider),
+	// so it remains outside of the versioned API
+	auth := e.Group("/auth/oidc")
+	{
+		auth.GET("/:provider/login", c.login)
+		auth.GET("/:provider/callback", c.callback)
+	}
+}
+
+// DescribeRoutes describes this controller's HTTP routes for the OpenAPI document.
+func (c *OIDCController) DescribeRoutes() []openapi.Route {
+	routes := apiRoutes([]openapi.Route{
+		{Method: http.MethodGet, Path: "/auth/oidc/providers", Summary: "List the identity providers that Users can log in with",
+			Response: []types.OIDCProviderResponse{}},
+	})
+
+	return append(routes, []openapi.Route{
+		{Method: http.MethodGet, Path: "/auth/oidc/:provider/login", Summary: "Begin logging in with an identity provider",
+			Status: http.StatusFound, RedirectsTo: "the identity provider"},
+		{Method: http.MethodGet, Path: "/auth/oidc/:provider/callback", Summary: "Complete logging in with an identity provider",
+			Query:  []openapi.Param{{Name: "state", Required: true}, {Name: "code", Required: true}, {Name: "error"}, {Name: "error_description"}},
+			Status: http.StatusFound, RedirectsTo: "/ (or /login if a second factor is required)"},
+	}...)
+}
+
+func (c *OIDCController) providers(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.oidcService.GetProviders())
+}
+
+func (c *OIDCController) login(ctx *gin.Context) {
+	url, st, err := c.oidcService.Begin(ctx.Param("provider"))
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	// SameSite=Lax so that the cookie is sent when the identity provider redirects back to the callback
+	maxAge := int(env.OIDCStateExpiration.Seconds())
+	utils.SetCookie(ctx, oidcStateCookie, st.State, maxAge, "/auth/oidc", http.SameSiteLaxMode)
+	ctx.Redirect(http.StatusFound, url)
+}
+
+func (c *OIDCController) callback(ctx *gin.Context) {
+	// The identity provider reports failures (e.g. - the User denied consent) via the error parameter
+	if e := ctx.Query("error"); len(e) > 0 {
+		c.logger.Printf("oidc provider returned an error: %s (%s)", e, ctx.Query("error_description"))
+		middleware.AbortWithError(ctx, &types.OIDCProviderError{Name: ctx.Param("provider"), Code: e, Description: ctx.Query("error_description")})
+		return
+	}
+
+	state := ctx.Query("state")
+	code := ctx.Query("code")
+	if len(state) == 0 || len(code) == 0 {
+		c.logger.Printf("attempted to complete an oidc login with no state or code")
+		middleware.AbortWithError(ctx, &types.RequestInvalidError{Reason: "state and code are required"})
+		return
+	}
+
+	// Verify that the callback belongs to the login started by this browser
+	bound, err := ctx.Cookie(oidcStateCookie)
+	if err != nil || bound != state {
+		c.logger.Printf("attempted to complete an oidc login that was not started by the client")
+		middleware.AbortWithError(ctx, &types.OIDCStateInvalidError{State: state})
+		return
+	}
+
+	sess, err := c.oidcService.Complete(ctx.Param("provider"), state, code)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	// Log the User in and clear the state cookie
+	setSessionCookie(ctx, sess)
+	utils.SetCookie(ctx, oidcStateCookie, "", -1, "/auth/oidc", http.SameSiteLaxMode)
+	if sess.Pending {
+		// A second authentication factor must be verified (or enrolled) before the Session can be used
+		ctx.Redirect(http.StatusFound, "/login")
+		return
+	}
+	ctx.Redirect(http.StatusFound, "/")
+}
@@ -0,0 +1,233 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/middleware"
+	"github.com/mhs294/mulhall/internals/openapi"
+	"github.com/mhs294/mulhall/internals/services"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/standings"
+	"github.com/mhs294/mulhall/internals/types/status"
+	"github.com/mhs294/mulhall/internals/utils"
+)
+
+// PoolController is responsible for handling requests for Pool HTTP APIs.
+type PoolController struct {
+	logger       *log.Logger
+	userAuth     *middleware.UserAuthMiddleware
+	poolService  *services.PoolService
+	conService   *services.ContestantService
+	standings    *services.StandingsService
+	liveService  *services.LiveUpdateService
+	notifService *services.NotificationService
+}
+
+// NewPoolController creates a new instance of a PoolController and returns a pointer to it.
+//
+// l is the pointer to the [log.Logger] that will be used at runtime by the PoolController.
+//
+// ua is the pointer to the UserAuthMiddleware used to authenticate requests and authorize Administrators.
+//
+// ps is the pointer to the PoolService that will be used at runtime by the PoolController.
+//
+// cs is the pointer to the ContestantService used to look up the Contestants in a Pool.
+//
+// ss is the pointer to the StandingsService used to describe the standings of the Contestants in a Pool.
+//
+// ls is the pointer to the LiveUpdateService used to stream live updates to the standings of a Pool.
+//
+// ns is the pointer to the NotificationService used to notify the members of a Pool of its result.
+func NewPoolController(l *log.Logger, ua *middleware.UserAuthMiddleware, ps *services.PoolService, cs *services.ContestantService, ss *services.StandingsService, ls *services.LiveUpdateService, ns *services.NotificationService) *PoolController {
+	return &PoolController{logger: l, userAuth: ua, poolService: ps, conService: cs, standings: ss, liveService: ls, notifService: ns}
+}
+
+// RegisterHandlers defines this controller's HTTP routes and their corresponding handler functions.
+func (c *PoolController) RegisterHandlers(e *gin.Engine) {
+	pool := newAPIGroup(e, "/pool", c.userAuth.APIAuth)
+	{
+		pool.GET("", c.list)
+		pool.GET("/:id", c.get)
+		pool.GET("/:id/contestants", c.contestants)
+		pool.GET("/:id/standings", c.getStandings)
+		pool.GET("/:id/events", c.events)
+		pool.POST("/create", c.userAuth.AdminAuth, c.create)
+		pool.POST("/:id/contestants/add", c.userAuth.AdminAuth, c.addContestant)
+		pool.POST("/:id/contestants/remove", c.userAuth.AdminAuth, c.removeContestant)
+		pool.POST("/:id/complete", c.userAuth.AdminAuth, c.complete)
+		pool.POST("/:id/deactivate", c.userAuth.AdminAuth, c.deactivate)
+	}
+}
+
+// DescribeRoutes describes this controller's HTTP routes for the OpenAPI document.
+func (c *PoolController) DescribeRoutes() []openapi.Route {
+	return apiRoutes([]openapi.Route{
+		{Method: http.MethodGet, Path: "/pool", Summary: "List the active Pools that the User is a member of",
+			Auth: openapi.AuthAPI, Response: []types.Pool{}},
+		{Method: http.MethodGet, Path: "/pool/:id", Summary: "Get a Pool that the User is a member of",
+			Auth: openapi.AuthAPI, Response: types.Pool{}},
+		{Method: http.MethodGet, Path: "/pool/:id/contestants", Summary: "List the Contestants in a Pool that the User is a member of",
+			Auth: openapi.AuthAPI, Response: []types.Contestant{}},
+		{Method: http.MethodGet, Path: "/pool/:id/standings", Summary: "Get the standings of the Contestants in a Pool that the User is a member of",
+			Auth: openapi.AuthAPI, Query: standingsParams, Response: types.StandingsResponse{}},
+		{Method: http.MethodGet, Path: "/pool/:id/events", Summary: "Stream live updates to the standings of a Pool that the User is a member of",
+			Auth: openapi.AuthAPI, Response: types.LiveUpdateResponse{}, Stream: true},
+		{Method: http.MethodPost, Path: "/pool/create", Summary: "Create a Pool",
+			Auth: openapi.AuthAdmin, Request: types.CreatePoolRequest{}, Response: types.Pool{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/pool/:id/contestants/add", Summary: "Add a Contestant to a Pool",
+			Auth: openapi.AuthAdmin, Request: types.PoolContestantRequest{}},
+		{Method: http.MethodPost, Path: "/pool/:id/contestants/remove", Summary: "Remove a Contestant from a Pool",
+			Auth: openapi.AuthAdmin, Request: types.PoolContestantRequest{}},
+		{Method: http.MethodPost, Path: "/pool/:id/complete", Summary: "Mark a Pool as complete",
+			Auth: openapi.AuthAdmin},
+		{Method: http.MethodPost, Path: "/pool/:id/deactivate", Summary: "Deactivate a Pool",
+			Auth: openapi.AuthAdmin},
+	})
+}
+
+func (c *PoolController) list(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	pools, err := c.conService.GetPoolsAsMember(sess.User)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pools)
+}
+
+func (c *PoolController) get(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	p, err := c.conService.GetPoolAsMember(sess.User, types.PoolID(ctx.Param("id")))
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, p)
+}
+
+func (c *PoolController) contestants(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	cons, err := c.conService.GetByPoolAsMember(sess.User, types.PoolID(ctx.Param("id")))
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cons)
+}
+
+func (c *PoolController) getStandings(ctx *gin.Context) {
+	year, err := queryYear(ctx)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	resp, err := c.standings.GetStandings(sess.User, types.PoolID(ctx.Param("id")), year, status.Status(ctx.Query("status")), standings.Sort(ctx.Query("sort")))
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *PoolController) events(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	sub, err := c.liveService.SubscribePool(sess.User, types.PoolID(ctx.Param("id")))
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	stream(ctx, sub)
+}
+
+func (c *PoolController) create(ctx *gin.Context) {
+	var req *types.CreatePoolRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal CreatePoolRequest from json: %v", err)
+		return
+	}
+
+	p, err := c.poolService.Create(req)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, p)
+}
+
+func (c *PoolController) addContestant(ctx *gin.Context) {
+	var req *types.PoolContestantRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal PoolContestantRequest from json: %v", err)
+		return
+	}
+
+	// Verify that the Contestant exists before adding it to the Pool
+	if _, err := c.conService.GetByID(req.ContestantID); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	if err := c.poolService.AddContestant(types.PoolID(ctx.Param("id")), req.ContestantID); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (c *PoolController) removeContestant(ctx *gin.Context) {
+	var req *types.PoolContestantRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal PoolContestantRequest from json: %v", err)
+		return
+	}
+
+	if err := c.poolService.RemoveContestant(types.PoolID(ctx.Param("id")), req.ContestantID); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (c *PoolController) complete(ctx *gin.Context) {
+	id := types.PoolID(ctx.Param("id"))
+	if err := c.poolService.Complete(id); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+	if _, err := c.notifService.SendPoolResult(id); err != nil {
+		c.logger.Printf("failed to notify result of pool %s: %v", id, err)
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (c *PoolController) deactivate(ctx *gin.Context) {
+	// Verify that the Pool exists before deactivating it
+	id := types.PoolID(ctx.Param("id"))
+	if _, err := c.poolService.GetByID(id); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	if err := c.poolService.Deactivate(id); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
@@ -0,0 +1,317 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/middleware"
+	"github.com/mhs294/mulhall/internals/openapi"
+	"github.com/mhs294/mulhall/internals/services"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/scopes"
+	"github.com/mhs294/mulhall/internals/types/status"
+	"github.com/mhs294/mulhall/internals/utils"
+	"github.com/mhs294/mulhall/views/components"
+)
+
+// ScheduleController is responsible for handling requests for Schedule HTTP APIs.
+type ScheduleController struct {
+	logger       *log.Logger
+	userAuth     *middleware.UserAuthMiddleware
+	schService   *services.ScheduleService
+	importer     *services.ScheduleImportService
+	entryService *services.EntryService
+	liveService  *services.LiveUpdateService
+	notifService *services.NotificationService
+}
+
+// NewScheduleController creates a new instance of a ScheduleController and returns a pointer to it.
+//
+// l is the pointer to the [log.Logger] that will be used at runtime by the ScheduleController.
+//
+// ua is the pointer to the UserAuthMiddleware used to authenticate requests and authorize Administrators.
+//
+// s is the pointer to the ScheduleService that will be used at runtime by the ScheduleController.
+//
+// is is the pointer to the ScheduleImportService used to import a season's Schedules from an uploaded file.
+//
+// es is the pointer to the EntryService used to eliminate (or reinstate) Contestants according to recorded results.
+//
+// ls is the pointer to the LiveUpdateService used to publish recorded results.
+//
+// ns is the pointer to the NotificationService used to notify the Users of Contestants eliminated by a result.
+func NewScheduleController(l *log.Logger, ua *middleware.UserAuthMiddleware, s *services.ScheduleService, is *services.ScheduleImportService, es *services.EntryService, ls *services.LiveUpdateService, ns *services.NotificationService) *ScheduleController {
+	return &ScheduleController{logger: l, userAuth: ua, schService: s, importer: is, entryService: es, liveService: ls, notifService: ns}
+}
+
+// RegisterHandlers defines this controller's HTTP routes and their corresponding handler functions.
+func (c *ScheduleController) RegisterHandlers(e *gin.Engine) {
+	// Results can be recorded with a personal APIToken that has the RESULTS_WRITE Scope
+	resultAuth := c.userAuth.ScopedAPIAuth(scopes.RESULTS_WRITE)
+
+	sch := newAPIGroup(e, "/schedule")
+	{
+		sch.GET("", c.userAuth.APIAuth, c.find)
+		sch.GET("/:id", c.userAuth.APIAuth, c.get)
+		sch.POST("/create", c.userAuth.APIAuth, c.userAuth.AdminAuth, c.create)
+		sch.POST("/closes", c.userAuth.APIAuth, c.userAuth.AdminAuth, c.updateCloses)
+		sch.POST("/import", c.userAuth.APIAuth, c.userAuth.AdminAuth, c.importSeason)
+		sch.POST("/matchup/add", c.userAuth.APIAuth, c.userAuth.AdminAuth, c.addMatchup)
+		sch.POST("/matchup/update", c.userAuth.APIAuth, c.userAuth.AdminAuth, c.updateMatchup)
+		sch.POST("/matchup/remove", c.userAuth.APIAuth, c.userAuth.AdminAuth, c.removeMatchup)
+		sch.POST("/matchup/result", resultAuth, c.userAuth.AdminAuth, c.recordResult)
+		sch.POST("/:id/deactivate", c.userAuth.APIAuth, c.userAuth.AdminAuth, c.deactivate)
+	}
+}
+
+// DescribeRoutes describes this controller's HTTP routes for the OpenAPI document.
+func (c *ScheduleController) DescribeRoutes() []openapi.Route {
+	return apiRoutes([]openapi.Route{
+		{Method: http.MethodGet, Path: "/schedule", Summary: "Find the Schedule for a week, or the current Schedule if no week is provided",
+			Auth: openapi.AuthAPI, Query: []openapi.Param{{Name: "year"}, {Name: "week"}}, Response: types.Schedule{}},
+		{Method: http.MethodGet, Path: "/schedule/:id", Summary: "Get a Schedule",
+			Auth: openapi.AuthAPI, Response: types.Schedule{}},
+		{Method: http.MethodPost, Path: "/schedule/create", Summary: "Create an empty Schedule for a week",
+			Auth: openapi.AuthAdmin, Request: types.CreateScheduleRequest{}, Response: types.Schedule{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/schedule/import", Summary: "Import a season's Schedules and Matchups from a CSV, JSON or iCalendar file",
+			Auth: openapi.AuthAdmin, Upload: "file", Response: types.ScheduleImportResponse{},
+			Query: []openapi.Param{{Name: "dryRun", Description: "If true, describe the changes without making them"}}},
+		{Method: http.MethodPost, Path: "/schedule/closes", Summary: "Change when picks close for a Schedule",
+			Auth: openapi.AuthAdmin, Request: types.UpdateScheduleClosesRequest{}, Response: types.Schedule{}},
+		{Method: http.MethodPost, Path: "/schedule/matchup/add", Summary: "Add a Matchup to a Schedule",
+			Auth: openapi.AuthAdmin, Request: types.CreateMatchupRequest{}, Response: types.Schedule{}},
+		{Method: http.MethodPost, Path: "/schedule/matchup/update", Summary: "Update a Matchup within a Schedule",
+			Auth: openapi.AuthAdmin, Request: types.UpdateMatchupRequest{}, Response: types.Schedule{}},
+		{Method: http.MethodPost, Path: "/schedule/matchup/remove", Summary: "Remove a Matchup from a Schedule",
+			Auth: openapi.AuthAdmin, Request: types.RemoveMatchupRequest{}, Response: types.Schedule{}},
+		{Method: http.MethodPost, Path: "/schedule/matchup/result", Summary: "Record the final score of a Matchup that has started",
+			Auth: openapi.AuthAdmin, Scope: scopes.RESULTS_WRITE, Request: types.RecordResultRequest{}, Response: types.Schedule{}},
+		{Method: http.MethodPost, Path: "/schedule/:id/deactivate", Summary: "Deactivate a Schedule",
+			Auth: openapi.AuthAdmin},
+	})
+}
+
+// find loads the Schedule for the "year" and "week" query parameters, or the current Schedule if neither is provided.
+func (c *ScheduleController) find(ctx *gin.Context) {
+	yearParam, weekParam := ctx.Query("year"), ctx.Query("week")
+	if len(yearParam) == 0 && len(weekParam) == 0 {
+		sch, err := c.schService.GetByDateTime(time.Now())
+		if err != nil {
+			middleware.AbortWithError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, sch)
+		return
+	}
+
+	// Parse the query parameters
+	year, err := strconv.Atoi(yearParam)
+	if err != nil {
+		middleware.AbortWithError(ctx, &types.RequestInvalidError{Reason: fmt.Sprintf("invalid year %q", yearParam)})
+		return
+	}
+	week, err := strconv.Atoi(weekParam)
+	if err != nil {
+		middleware.AbortWithError(ctx, &types.RequestInvalidError{Reason: fmt.Sprintf("invalid week %q", weekParam)})
+		return
+	}
+
+	sch, err := c.schService.GetByYearAndWeek(year, week)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sch)
+}
+
+func (c *ScheduleController) get(ctx *gin.Context) {
+	sch, err := c.schService.GetByID(types.ScheduleID(ctx.Param("id")))
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sch)
+}
+
+func (c *ScheduleController) create(ctx *gin.Context) {
+	var req *types.CreateScheduleRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal CreateScheduleRequest from json: %v", err)
+		return
+	}
+
+	sch, err := c.schService.CreateSchedule(req)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	c.respondWithEditor(ctx, http.StatusCreated, sch)
+}
+
+func (c *ScheduleController) updateCloses(ctx *gin.Context) {
+	var req *types.UpdateScheduleClosesRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal UpdateScheduleClosesRequest from json: %v", err)
+		return
+	}
+
+	sch, err := c.schService.UpdateCloses(req)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	c.respondWithEditor(ctx, http.StatusOK, sch)
+}
+
+func (c *ScheduleController) importSeason(ctx *gin.Context) {
+	// Read the uploaded file
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		middleware.AbortWithError(ctx, &types.RequestInvalidError{Reason: "file is required"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+	defer file.Close()
+
+	games, err := c.importer.Parse(header.Filename, file)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	resp, err := c.importer.Import(games, ctx.Query("dryRun") == "true")
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusOK, resp, components.ScheduleImportResult(resp))
+}
+
+func (c *ScheduleController) addMatchup(ctx *gin.Context) {
+	var req *types.CreateMatchupRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal CreateMatchupRequest from json: %v", err)
+		return
+	}
+
+	sch, err := c.schService.AddMatchup(req)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	c.respondWithEditor(ctx, http.StatusOK, sch)
+}
+
+func (c *ScheduleController) updateMatchup(ctx *gin.Context) {
+	var req *types.UpdateMatchupRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal UpdateMatchupRequest from json: %v", err)
+		return
+	}
+
+	sch, err := c.schService.UpdateMatchup(req)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	c.respondWithEditor(ctx, http.StatusOK, sch)
+}
+
+func (c *ScheduleController) removeMatchup(ctx *gin.Context) {
+	var req *types.RemoveMatchupRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal RemoveMatchupRequest from json: %v", err)
+		return
+	}
+
+	sch, err := c.schService.RemoveMatchup(req.ScheduleID, req.MatchupID)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	c.respondWithEditor(ctx, http.StatusOK, sch)
+}
+
+func (c *ScheduleController) recordResult(ctx *gin.Context) {
+	var req *types.RecordResultRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal RecordResultRequest from json: %v", err)
+		return
+	}
+
+	sch, err := c.schService.RecordResult(req)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+	eliminated, reinstated, err := c.entryService.ApplyResult(sch, req.MatchupID)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+	if err := c.liveService.ResultRecorded(sch, req.MatchupID, eliminated, reinstated); err != nil {
+		c.logger.Printf("failed to publish result of matchup %s: %v", req.MatchupID, err)
+	}
+	for _, conID := range eliminated {
+		if _, err := c.notifService.SendElimination(conID, status.ELIMINATED, sch.Week); err != nil {
+			c.logger.Printf("failed to notify elimination of contestant %s: %v", conID, err)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, sch)
+}
+
+// respondWithEditor responds to a successful change to a Schedule. htmx requests receive the re-rendered
+// ScheduleEditor, while all other requests receive the updated Schedule as JSON.
+func (c *ScheduleController) respondWithEditor(ctx *gin.Context, status int, sch *types.Schedule) {
+	if !middleware.IsHTMXRequest(ctx) {
+		ctx.JSON(status, sch)
+		return
+	}
+
+	editor, err := c.schService.DescribeEditor(sch)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+	respond(ctx, status, sch, components.ScheduleEditor(editor))
+}
+
+func (c *ScheduleController) deactivate(ctx *gin.Context) {
+	// Verify that the Schedule exists before deactivating it
+	id := types.ScheduleID(ctx.Param("id"))
+	if _, err := c.schService.GetByID(id); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	if err := c.schService.Deactivate(id); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
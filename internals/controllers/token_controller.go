@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/middleware"
+	"github.com/mhs294/mulhall/internals/openapi"
+	"github.com/mhs294/mulhall/internals/services"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/utils"
+)
+
+// TokenController is responsible for handling requests for personal APIToken HTTP APIs.
+type TokenController struct {
+	logger       *log.Logger
+	userAuth     *middleware.UserAuthMiddleware
+	tokenService *services.APITokenService
+}
+
+// NewTokenController creates a new instance of a TokenController and returns a pointer to it.
+//
+// l is the pointer to the [log.Logger] that will be used at runtime by the TokenController.
+//
+// ua is the pointer to the UserAuthMiddleware used to authenticate requests for the logged in User's tokens.
+//
+// s is the pointer to the APITokenService that will be used at runtime by the TokenController.
+func NewTokenController(l *log.Logger, ua *middleware.UserAuthMiddleware, s *services.APITokenService) *TokenController {
+	return &TokenController{logger: l, userAuth: ua, tokenService: s}
+}
+
+// RegisterHandlers defines this controller's HTTP routes and their corresponding handler functions.
+func (c *TokenController) RegisterHandlers(e *gin.Engine) {
+	tok := newAPIGroup(e, "/user/tokens", c.userAuth.APIAuth)
+	{
+		tok.GET("", c.list)
+		tok.POST("/create", c.create)
+		tok.POST("/revoke", c.revoke)
+	}
+}
+
+// DescribeRoutes describes this controller's HTTP routes for the OpenAPI document.
+func (c *TokenController) DescribeRoutes() []openapi.Route {
+	return apiRoutes([]openapi.Route{
+		{Method: http.MethodGet, Path: "/user/tokens", Summary: "List the User's personal API tokens",
+			Auth: openapi.AuthAPI, Response: []types.APIToken{}},
+		{Method: http.MethodPost, Path: "/user/tokens/create", Summary: "Create a personal API token, returning its secret once",
+			Auth: openapi.AuthAPI, Request: types.CreateAPITokenRequest{}, Response: types.CreateAPITokenResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/user/tokens/revoke", Summary: "Revoke one of the User's personal API tokens",
+			Auth: openapi.AuthAPI, Request: types.RevokeAPITokenRequest{}},
+	})
+}
+
+func (c *TokenController) list(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	tokens, err := c.tokenService.GetByUser(sess.User)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tokens)
+}
+
+func (c *TokenController) create(ctx *gin.Context) {
+	var req *types.CreateAPITokenRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal CreateAPITokenRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	res, err := c.tokenService.Create(sess.User, req)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (c *TokenController) revoke(ctx *gin.Context) {
+	var req *types.RevokeAPITokenRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal RevokeAPITokenRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	if err := c.tokenService.Revoke(sess.User, req.TokenID); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
@@ -1,20 +1,23 @@
 package controllers
 
 import (
-	"fmt"
 	"log"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/middleware"
+	"github.com/mhs294/mulhall/internals/openapi"
 	"github.com/mhs294/mulhall/internals/services"
 	"github.com/mhs294/mulhall/internals/types"
 	"github.com/mhs294/mulhall/internals/utils"
+	"github.com/mhs294/mulhall/views/components"
 )
 
 // UserController is responsible for handling requests for Account HTTP APIs.
 type UserController struct {
 	logger      *log.Logger
+	userAuth    *middleware.UserAuthMiddleware
 	userService *services.UserService
 }
 
@@ -22,30 +25,72 @@ type UserController struct {
 //
 // l is the pointer to the [log.Logger] that will be used at runtime by the UserController.
 //
+// ua is the pointer to the UserAuthMiddleware used to authenticate requests for the logged in User's account.
+//
 // s is the pointer to the UserService that will be used at runtime by the UserController.
-func NewUserController(l *log.Logger, s *services.UserService) *UserController {
-	return &UserController{logger: l, userService: s}
+func NewUserController(l *log.Logger, ua *middleware.UserAuthMiddleware, s *services.UserService) *UserController {
+	return &UserController{logger: l, userAuth: ua, userService: s}
 }
 
 // RegisterHandlers defines this controller's HTTP routes and their corresponding handler functions.
 func (c *UserController) RegisterHandlers(e *gin.Engine) {
-	acc := e.Group("/user")
+	acc := newAPIGroup(e, "/user")
 	{
 		acc.POST("/register", c.register)
 		acc.POST("/login", c.login)
+		acc.POST("/login/verify", c.userAuth.PendingAuth, c.verifyLogin)
+		acc.GET("/2fa", c.userAuth.PendingAuth, c.mfaStatus)
+		acc.POST("/2fa/enroll", c.userAuth.PendingAuth, c.enrollMFA)
+		acc.POST("/2fa/confirm", c.userAuth.PendingAuth, c.confirmMFA)
+		acc.POST("/2fa/disable", c.userAuth.APIAuth, c.disableMFA)
+		acc.POST("/password", c.userAuth.APIAuth, c.changePassword)
+		acc.POST("/email", c.userAuth.APIAuth, c.changeEmail)
+		acc.GET("/email/verify", c.verifyEmail)
+		acc.GET("/sessions", c.userAuth.APIAuth, c.sessions)
+		acc.POST("/sessions/revoke", c.userAuth.APIAuth, c.revokeSession)
 	}
 }
 
+// DescribeRoutes describes this controller's HTTP routes for the OpenAPI document.
+func (c *UserController) DescribeRoutes() []openapi.Route {
+	return apiRoutes([]openapi.Route{
+		{Method: http.MethodPost, Path: "/user/register", Summary: "Register an account for an invited User",
+			Request: types.RegisterUserRequest{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/user/login", Summary: "Log in, setting the Session cookie (202 if a second factor is required)",
+			Request: types.LoginRequest{}},
+		{Method: http.MethodPost, Path: "/user/login/verify", Summary: "Complete a login by verifying the second authentication factor",
+			Auth: openapi.AuthPending, Request: types.VerifyMFARequest{}},
+		{Method: http.MethodGet, Path: "/user/2fa", Summary: "Get the status of the User's second authentication factor",
+			Auth: openapi.AuthPending, Response: types.MFAStatusResponse{}},
+		{Method: http.MethodPost, Path: "/user/2fa/enroll", Summary: "Generate a TOTP secret for the User to enroll",
+			Auth: openapi.AuthPending, Response: types.MFAEnrollmentResponse{}},
+		{Method: http.MethodPost, Path: "/user/2fa/confirm", Summary: "Confirm TOTP enrollment, returning single-use recovery codes",
+			Auth: openapi.AuthPending, Request: types.VerifyMFARequest{}, Response: types.RecoveryCodesResponse{}},
+		{Method: http.MethodPost, Path: "/user/2fa/disable", Summary: "Disable the User's second authentication factor",
+			Auth: openapi.AuthAPI, Request: types.DisableMFARequest{}},
+		{Method: http.MethodPost, Path: "/user/password", Summary: "Change the User's password",
+			Auth: openapi.AuthAPI, Request: types.ChangePasswordRequest{}},
+		{Method: http.MethodPost, Path: "/user/email", Summary: "Request a change to the User's email address",
+			Auth: openapi.AuthAPI, Request: types.ChangeEmailRequest{}, Status: http.StatusAccepted},
+		{Method: http.MethodGet, Path: "/user/email/verify", Summary: "Verify a pending change to a User's email address",
+			Query: []openapi.Param{{Name: "email", Required: true}, {Name: "token", Required: true}}},
+		{Method: http.MethodGet, Path: "/user/sessions", Summary: "List the User's active Sessions",
+			Auth: openapi.AuthAPI, Response: []types.SessionResponse{}},
+		{Method: http.MethodPost, Path: "/user/sessions/revoke", Summary: "Revoke one of the User's Sessions",
+			Auth: openapi.AuthAPI, Request: types.RevokeSessionRequest{}},
+	})
+}
+
 func (c *UserController) register(ctx *gin.Context) {
 	var req *types.RegisterUserRequest
 	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
-		ctx.AbortWithStatus(http.StatusBadRequest)
+		middleware.AbortWithError(ctx, err)
 		c.logger.Printf("failed to unmarhsal RegisterUserRequest from json: %v", err)
 		return
 	}
 
 	if _, err := c.userService.Register(req); err != nil {
-		ctx.AbortWithStatus(http.StatusInternalServerError)
+		middleware.AbortWithError(ctx, err)
 		return
 	}
 
@@ -55,27 +100,208 @@ func (c *UserController) register(ctx *gin.Context) {
 func (c *UserController) login(ctx *gin.Context) {
 	var req *types.LoginRequest
 	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
-		ctx.AbortWithStatus(http.StatusBadRequest)
+		middleware.AbortWithError(ctx, err)
 		c.logger.Printf("failed to unmarhsal LoginRequest from json: %v", err)
 		return
 	}
 
-	sess, err := c.userService.Login(req.Email, req.Password)
+	sess, err := c.userService.Login(req.Email, req.Password, ctx.ClientIP())
 	if err != nil {
 		switch err.(type) {
-		case *types.UserNotFoundError:
-		case *types.PasswordIncorrectError:
-			ctx.AbortWithStatus(http.StatusUnauthorized)
-			return
-		default:
-			ctx.AbortWithStatus(http.StatusInternalServerError)
-			c.logger.Printf("unexpected error occurred while attempting to login: %v", err)
+		case *types.UserNotFoundError, *types.PasswordIncorrectError:
+			// Respond identically for unknown accounts and wrong passwords to avoid revealing which emails exist
+			err = &types.LoginFailedError{}
+		}
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	setSessionCookie(ctx, sess)
+	if sess.Pending {
+		// A second authentication factor must be verified (or enrolled) before the Session can be used
+		status, err := c.userService.GetMFAStatus(sess.User)
+		if err != nil {
+			middleware.AbortWithError(ctx, err)
 			return
 		}
+		ctx.JSON(http.StatusAccepted, status)
+		return
+	}
+	ctx.Status(http.StatusOK)
+}
+
+func (c *UserController) verifyLogin(ctx *gin.Context) {
+	var req *types.VerifyMFARequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal VerifyMFARequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	sess, err := c.userService.VerifySecondFactor(sess, req.Code, ctx.ClientIP())
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	setSessionCookie(ctx, sess)
+	if middleware.IsHTMXRequest(ctx) {
+		// The login view completes the login by navigating to the home page
+		ctx.Header("HX-Redirect", "/")
+	}
+	ctx.Status(http.StatusOK)
+}
+
+func (c *UserController) mfaStatus(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	status, err := c.userService.GetMFAStatus(sess.User)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, status)
+}
+
+func (c *UserController) enrollMFA(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	enrollment, err := c.userService.EnrollMFA(sess.User)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, enrollment)
+}
+
+func (c *UserController) confirmMFA(ctx *gin.Context) {
+	var req *types.VerifyMFARequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal VerifyMFARequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	newSess, codes, err := c.userService.ConfirmMFA(sess, req.Code, ctx.ClientIP())
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	if newSess.ID != sess.ID {
+		setSessionCookie(ctx, newSess)
+	}
+	ctx.JSON(http.StatusOK, &types.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+func (c *UserController) disableMFA(ctx *gin.Context) {
+	var req *types.DisableMFARequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal DisableMFARequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	if err := c.userService.DisableMFA(sess.User, req, ctx.ClientIP()); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
 	}
 
-	maxAge := int64(sess.Expiration.Sub(time.Now().UTC()).Seconds())
-	cookie := fmt.Sprintf("mulhall.sessionID=%s; Max-Age=%d; HttpOnly", sess.ID, maxAge)
-	ctx.Header(http.CanonicalHeaderKey("set-cookie"), cookie)
 	ctx.Status(http.StatusOK)
 }
+
+func (c *UserController) changePassword(ctx *gin.Context) {
+	var req *types.ChangePasswordRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal ChangePasswordRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	if err := c.userService.ChangePassword(sess, req); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusOK, nil, components.PasswordForm(true))
+}
+
+func (c *UserController) changeEmail(ctx *gin.Context) {
+	var req *types.ChangeEmailRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal ChangeEmailRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	u, err := c.userService.RequestEmailChange(sess.User, req)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusAccepted, nil, components.EmailForm(u.PendingEmail))
+}
+
+func (c *UserController) verifyEmail(ctx *gin.Context) {
+	email := ctx.Query("email")
+	if len(email) == 0 {
+		c.logger.Printf("attempted to verify an email change with no email")
+		middleware.AbortWithError(ctx, &types.RequestInvalidError{Reason: "email is required"})
+		return
+	}
+
+	token := ctx.Query("token")
+	if len(token) == 0 {
+		c.logger.Printf("attempted to verify an email change with no token")
+		middleware.AbortWithError(ctx, &types.RequestInvalidError{Reason: "token is required"})
+		return
+	}
+
+	if err := c.userService.VerifyEmailChange(email, token); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (c *UserController) sessions(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	sessions, err := c.userService.GetSessions(sess.User, sess.ID)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sessions)
+}
+
+func (c *UserController) revokeSession(ctx *gin.Context) {
+	var req *types.RevokeSessionRequest
+	if err := utils.ParseRequestJSON(&req, ctx); err != nil {
+		middleware.AbortWithError(ctx, err)
+		c.logger.Printf("failed to unmarhsal RevokeSessionRequest from json: %v", err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	if err := c.userService.RevokeSession(sess.User, req.Handle); err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func setSessionCookie(ctx *gin.Context, sess *types.Session) {
+	// SameSite=Lax so that the Session survives top-level navigation from other sites (e.g. - returning from
+	// an identity provider), while state-changing requests are covered by the CSRF middleware
+	maxAge := int(sess.Expiration.Sub(time.Now().UTC()).Seconds())
+	utils.SetCookie(ctx, "mulhall.sessionID", string(sess.ID), maxAge, "/", http.SameSiteLaxMode)
+}
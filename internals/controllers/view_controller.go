@@ -1,56 +1,300 @@
 package controllers
 
 import (
-	"context"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/a-h/templ"
 	"github.com/gin-gonic/gin"
-	"github.com/mhs294/mulhall/internals/env"
 	"github.com/mhs294/mulhall/internals/middleware"
-	"github.com/mhs294/mulhall/internals/repos"
+	"github.com/mhs294/mulhall/internals/openapi"
+	"github.com/mhs294/mulhall/internals/services"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/standings"
+	"github.com/mhs294/mulhall/internals/types/status"
 	"github.com/mhs294/mulhall/views"
 )
 
 // ViewController is responsible for serving HTML views to the end user via HTTP.
 type ViewController struct {
-	userAuth *middleware.UserAuthMiddleware
-	teamRepo *repos.TeamRepository
+	userAuth     *middleware.UserAuthMiddleware
+	userService  *services.UserService
+	oidcService  *services.OIDCService
+	userAdmin    *services.UserAdminService
+	picksService *services.PicksService
+	invService   *services.InviteService
+	poolService  *services.PoolService
+	standings    *services.StandingsService
+	schService   *services.ScheduleService
+	notifService *services.NotificationService
 }
 
 // NewViewController creates a new instance of a ViewController and returns a pointer to it.
-func NewViewController(ua *middleware.UserAuthMiddleware, tr *repos.TeamRepository) *ViewController {
-	return &ViewController{userAuth: ua, teamRepo: tr}
+func NewViewController(ua *middleware.UserAuthMiddleware, us *services.UserService, o *services.OIDCService, uas *services.UserAdminService, ps *services.PicksService, is *services.InviteService, pls *services.PoolService, ss *services.StandingsService, schs *services.ScheduleService, ns *services.NotificationService) *ViewController {
+	return &ViewController{
+		userAuth:     ua,
+		userService:  us,
+		oidcService:  o,
+		userAdmin:    uas,
+		picksService: ps,
+		invService:   is,
+		poolService:  pls,
+		standings:    ss,
+		schService:   schs,
+		notifService: ns,
+	}
 }
 
 // RegisterHandlers defines this controller's HTTP routes and their corresponding handler functions.
 func (c *ViewController) RegisterHandlers(e *gin.Engine) {
 	e.GET("/", c.userAuth.ViewAuth, c.index)
+	e.GET("/picks", c.userAuth.ViewAuth, c.picksByWeek)
+	e.GET("/standings/:id", c.userAuth.ViewAuth, c.poolStandings)
+	e.GET("/invite", c.userAuth.ViewAuth, c.inviteUser)
 	e.GET("/login", c.login)
+	e.GET("/user/settings", c.userAuth.ViewAuth, c.settings)
+	e.GET("/notifications", c.userAuth.ViewAuth, c.notifications)
+	e.GET("/admin/accounts", c.userAuth.ViewAuth, c.userAuth.AdminAuth, c.adminUsers)
+	e.GET("/admin/accounts/:id", c.userAuth.ViewAuth, c.userAuth.AdminAuth, c.adminUser)
+	e.GET("/admin/schedules", c.userAuth.ViewAuth, c.userAuth.AdminAuth, c.adminSchedules)
+	e.GET("/admin/schedules/:id", c.userAuth.ViewAuth, c.userAuth.AdminAuth, c.adminSchedule)
+}
+
+// DescribeRoutes describes this controller's HTTP routes for the OpenAPI document.
+func (c *ViewController) DescribeRoutes() []openapi.Route {
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/", Summary: "Weekly Picks page for the current week's Schedule", Auth: openapi.AuthView,
+			Query: []openapi.Param{{Name: "contestant", Description: "Contestant to make picks for (defaults to the first membership)"}}, HTML: true},
+		{Method: http.MethodGet, Path: "/picks", Summary: "My Picks by Week page for a season", Auth: openapi.AuthView,
+			Query: []openapi.Param{
+				{Name: "contestant", Description: "Contestant whose picks to show (defaults to the first membership)"},
+				{Name: "year", Description: "Starting year of the season (defaults to the current season)"},
+			}, HTML: true},
+		{Method: http.MethodGet, Path: "/standings/:id", Summary: "Standings page for a Pool", Auth: openapi.AuthView,
+			Query: standingsParams, HTML: true},
+		{Method: http.MethodGet, Path: "/invite", Summary: "Invite User page", Auth: openapi.AuthView,
+			Query: []openapi.Param{{Name: "contestant", Description: "Contestant to invite the User to (defaults to the first one managed)"}}, HTML: true},
+		{Method: http.MethodGet, Path: "/login", Summary: "Login page", HTML: true},
+		{Method: http.MethodGet, Path: "/user/settings", Summary: "Account settings page", Auth: openapi.AuthView, HTML: true},
+		{Method: http.MethodGet, Path: "/notifications", Summary: "Notifications delivered in the app to the logged in User", Auth: openapi.AuthView, HTML: true},
+		{Method: http.MethodGet, Path: "/admin/accounts", Summary: "User administration page",
+			Auth: openapi.AuthView, Query: []openapi.Param{{Name: "q", Description: "Text to search for"}}, HTML: true},
+		{Method: http.MethodGet, Path: "/admin/accounts/:id", Summary: "User account administration page", Auth: openapi.AuthView, HTML: true},
+		{Method: http.MethodGet, Path: "/admin/schedules", Summary: "Schedule administration page for a season", Auth: openapi.AuthView,
+			Query: []openapi.Param{{Name: "year", Description: "Starting year of the season (defaults to the current season)"}}, HTML: true},
+		{Method: http.MethodGet, Path: "/admin/schedules/:id", Summary: "Schedule editor page", Auth: openapi.AuthView, HTML: true},
+	}
 }
 
 func (c *ViewController) index(ctx *gin.Context) {
-	_, cancel := context.WithTimeout(context.Background(), env.Timeout)
-	defer cancel()
+	sess := middleware.SessionFromContext(ctx)
+	picks, err := c.picksService.GetWeeklyPicks(sess.User, types.ContestantID(ctx.Query("contestant")), time.Now())
+	if err != nil {
+		// TODO - replace with error view
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+	render(ctx, http.StatusOK, views.WeeklyPicks(picks))
+}
 
-	teams, err := c.teamRepo.GetAll()
+func (c *ViewController) picksByWeek(ctx *gin.Context) {
+	year, err := queryYear(ctx)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	picks, err := c.picksService.GetSeasonPicks(sess.User, types.ContestantID(ctx.Query("contestant")), year)
 	if err != nil {
 		// TODO - replace with error view
-		ctx.AbortWithStatus(http.StatusInternalServerError)
+		middleware.AbortWithError(ctx, err)
 		return
 	}
-	render(ctx, http.StatusOK, views.Index(teams))
+	render(ctx, http.StatusOK, views.PicksByWeek(picks))
+}
+
+func (c *ViewController) poolStandings(ctx *gin.Context) {
+	year, err := queryYear(ctx)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	sess := middleware.SessionFromContext(ctx)
+	resp, err := c.standings.GetStandings(sess.User, types.PoolID(ctx.Param("id")), year, status.Status(ctx.Query("status")), standings.Sort(ctx.Query("sort")))
+	if err != nil {
+		// TODO - replace with error view
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	pools, err := c.poolService.GetAll()
+	if err != nil {
+		// TODO - replace with error view
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+	render(ctx, http.StatusOK, views.PoolStandings(resp, pools))
+}
+
+func (c *ViewController) inviteUser(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	form, err := c.invService.Check(sess.User, &types.CheckInviteRequest{ContestantID: types.ContestantID(ctx.Query("contestant"))})
+	if err != nil {
+		// TODO - replace with error view
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+	render(ctx, http.StatusOK, views.InviteUser(form))
 }
 
 func (c *ViewController) login(ctx *gin.Context) {
 	// TODO - render login view
-	render(ctx, http.StatusOK, views.Login())
+
+	// Logins awaiting a second authentication factor are prompted for it
+	var mfa *types.MFAStatusResponse
+	if sess := c.userAuth.PendingLogin(ctx); sess != nil {
+		status, err := c.userService.GetMFAStatus(sess.User)
+		if err != nil {
+			// TODO - replace with error view
+			middleware.AbortWithError(ctx, err)
+			return
+		}
+		mfa = status
+	}
+
+	render(ctx, http.StatusOK, views.Login(c.oidcService.GetProviders(), mfa))
 }
 
-func render(ctx *gin.Context, status int, template templ.Component) {
-	if err := template.Render(ctx.Request.Context(), ctx.Writer); err != nil {
-		ctx.AbortWithError(http.StatusInternalServerError, err)
+func (c *ViewController) settings(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	u, err := c.userService.GetByID(sess.User)
+	if err != nil {
+		// TODO - replace with error view
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	sessions, err := c.userService.GetSessions(sess.User, sess.ID)
+	if err != nil {
+		// TODO - replace with error view
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	prefs, err := c.notifService.GetPreferences(sess.User)
+	if err != nil {
+		// TODO - replace with error view
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+	render(ctx, http.StatusOK, views.Settings(u, sessions, prefs))
+}
+
+func (c *ViewController) notifications(ctx *gin.Context) {
+	sess := middleware.SessionFromContext(ctx)
+	ns, err := c.notifService.GetByUser(sess.User)
+	if err != nil {
+		// TODO - replace with error view
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	render(ctx, http.StatusOK, views.Notifications(ns))
+}
+
+func (c *ViewController) adminUsers(ctx *gin.Context) {
+	query := ctx.Query("q")
+	users, err := c.userAdmin.Search(query)
+	if err != nil {
+		// TODO - replace with error view
+		middleware.AbortWithError(ctx, err)
+		return
 	}
+	render(ctx, http.StatusOK, views.AdminUsers(users, query))
+}
 
+func (c *ViewController) adminUser(ctx *gin.Context) {
+	details, err := c.userAdmin.GetDetails(types.UserID(ctx.Param("id")))
+	if err != nil {
+		// TODO - replace with error view
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+	render(ctx, http.StatusOK, views.AdminUser(details))
+}
+
+func (c *ViewController) adminSchedules(ctx *gin.Context) {
+	year, err := queryYear(ctx)
+	if err != nil {
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+	if year == 0 {
+		if year, err = c.picksService.GetCurrentSeason(); err != nil {
+			// TODO - replace with error view
+			middleware.AbortWithError(ctx, err)
+			return
+		}
+	}
+
+	schedules, err := c.schService.GetByYear(year)
+	if err != nil {
+		// TODO - replace with error view
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+	render(ctx, http.StatusOK, views.AdminSchedules(year, schedules))
+}
+
+func (c *ViewController) adminSchedule(ctx *gin.Context) {
+	sch, err := c.schService.GetByID(types.ScheduleID(ctx.Param("id")))
+	if err != nil {
+		// TODO - replace with error view
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+
+	editor, err := c.schService.DescribeEditor(sch)
+	if err != nil {
+		// TODO - replace with error view
+		middleware.AbortWithError(ctx, err)
+		return
+	}
+	render(ctx, http.StatusOK, views.AdminSchedule(editor))
+}
+
+// standingsParams describes the query parameters accepted by the standings routes.
+var standingsParams = []openapi.Param{
+	{Name: "year", Description: "Starting year of the season (defaults to the current season)"},
+	{Name: "status", Description: "Only include Contestants with this status"},
+	{Name: "sort", Description: "Order of the standings: status (default), survived or name"},
+}
+
+// queryYear parses the optional "year" query parameter, returning 0 if it was not provided.
+// Returns RequestInvalidError if the year is not an integer.
+func queryYear(ctx *gin.Context) (int, error) {
+	yearParam := ctx.Query("year")
+	if len(yearParam) == 0 {
+		return 0, nil
+	}
+
+	year, err := strconv.Atoi(yearParam)
+	if err != nil {
+		return 0, &types.RequestInvalidError{Reason: fmt.Sprintf("invalid year %q", yearParam)}
+	}
+
+	return year, nil
+}
+
+func render(ctx *gin.Context, status int, template templ.Component) {
+	// The status and headers must be written before the body
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
 	ctx.Status(status)
+	if err := template.Render(ctx.Request.Context(), ctx.Writer); err != nil {
+		middleware.AbortWithError(ctx, err)
+	}
 }
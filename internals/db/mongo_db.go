@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -232,6 +233,85 @@ func (mdb *MongoDB) UpdateOne(dbName string, collName string, filter bson.M, upd
 	return nil
 }
 
+// InsertIfAbsent inserts the provided document into the specified database collection, unless a document matching
+// the provided filter already exists (in which case the existing document is left unchanged). Returns whether the
+// document was inserted.
+//
+// dbName is the name of the database containing the collection.
+//
+// collName is the name of the collection into which the document will be inserted.
+//
+// filter is the bson.M representing the query to find an existing document.
+//
+// doc is the document to insert.
+func (mdb *MongoDB) InsertIfAbsent(dbName string, collName string, filter bson.M, doc any) (bool, error) {
+	// Create a new client and connect to the server
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, mdb.timeout)
+	defer cancel()
+	client, err := createClient(mdb.connStr, ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to the database: %v", err)
+	}
+
+	// Setup deferred connection closure for when function completes
+	defer func() {
+		if err = client.Disconnect(ctx); err != nil {
+			mdb.logger.Print(err)
+		}
+	}()
+
+	// Acquire reference to the database collection
+	coll := client.Database(dbName).Collection(collName)
+
+	// Perform the upsert
+	update := bson.M{"$setOnInsert": doc}
+	res, err := coll.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, fmt.Errorf("failed to upsert document (filter=%v): %v", filter, err)
+	}
+
+	return res.UpsertedCount > 0, nil
+}
+
+// UpdateMany updates all documents in the specified database collection matching the provided filter
+// using the provided update query.
+//
+// dbName is the name of the database containing the documents to update.
+//
+// collName is the name of the collection containing the documents to update.
+//
+// filter is the bson.M representing the query to find the documents to update.
+//
+// update is the bson.M representing the query to update the found documents.
+func (mdb *MongoDB) UpdateMany(dbName string, collName string, filter bson.M, update bson.M) error {
+	// Create a new client and connect to the server
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, mdb.timeout)
+	defer cancel()
+	client, err := createClient(mdb.connStr, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the database: %v", err)
+	}
+
+	// Setup deferred connection closure for when function completes
+	defer func() {
+		if err = client.Disconnect(ctx); err != nil {
+			mdb.logger.Print(err)
+		}
+	}()
+
+	// Acquire reference to the database collection
+	coll := client.Database(dbName).Collection(collName)
+
+	// Perform the update
+	if _, err := coll.UpdateMany(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to update documents (filter=%v, update=%v): %v", filter, update, err)
+	}
+
+	return nil
+}
+
 // ReplaceOne replaces a single document in the specified database collection corresponding to the
 // provided filter with an updated version of that document.
 //
@@ -274,13 +354,188 @@ func (mdb *MongoDB) ReplaceOne(dbName string, collName string, filter bson.M, re
 	}
 
 	// Perform the replace
-	if _, err := coll.UpdateOne(ctx, filter, replace); err != nil {
+	if _, err := coll.ReplaceOne(ctx, filter, bsonMap); err != nil {
 		return fmt.Errorf("failed to replace document (filter=%v, replace=%v): %v", filter, replace, err)
 	}
 
 	return nil
 }
 
+// UpsertOne atomically updates a single document in the specified database collection using the provided
+// filter and update queries (inserting a new document if none matches the filter), then loads the updated
+// document into the provided result object.
+//
+// dbName is the name of the database containing the document to upsert.
+//
+// collName is the name of the collection containing the document to upsert.
+//
+// filter is the bson.M representing the query to find the document to upsert.
+//
+// update is the bson.M representing the query to update the found (or inserted) document.
+//
+// result is the provided object into which the updated document will be deserialized and stored.
+func (mdb *MongoDB) UpsertOne(dbName string, collName string, filter bson.M, update bson.M, result any) error {
+	// Create a new client and connect to the server
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, mdb.timeout)
+	defer cancel()
+	client, err := createClient(mdb.connStr, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the database: %v", err)
+	}
+
+	// Setup deferred connection closure for when function completes
+	defer func() {
+		if err = client.Disconnect(ctx); err != nil {
+			mdb.logger.Print(err)
+		}
+	}()
+
+	// Acquire reference to the database collection
+	coll := client.Database(dbName).Collection(collName)
+
+	// Perform the upsert, returning the document as it exists after the update
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	if err := coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(result); err != nil {
+		return fmt.Errorf("failed to upsert document (filter=%v, update=%v): %v", filter, update, err)
+	}
+
+	return nil
+}
+
+// DeleteMany deletes all documents in the specified database collection matching the provided filter query.
+//
+// dbName is the name of the database containing the documents to delete.
+//
+// collName is the name of the collection containing the documents to delete.
+//
+// filter is the bson.M representing the query to find the documents to delete.
+func (mdb *MongoDB) DeleteMany(dbName string, collName string, filter bson.M) error {
+	// Create a new client and connect to the server
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, mdb.timeout)
+	defer cancel()
+	client, err := createClient(mdb.connStr, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the database: %v", err)
+	}
+
+	// Setup deferred connection closure for when function completes
+	defer func() {
+		if err = client.Disconnect(ctx); err != nil {
+			mdb.logger.Print(err)
+		}
+	}()
+
+	// Acquire reference to the database collection
+	coll := client.Database(dbName).Collection(collName)
+
+	// Perform the delete
+	if _, err := coll.DeleteMany(ctx, filter); err != nil {
+		return fmt.Errorf("failed to delete documents (filter=%v): %v", filter, err)
+	}
+
+	return nil
+}
+
+// Index describes a single index on a database collection.
+type Index struct {
+	Keys        bson.D         // Indexed fields and their sort order (1 or -1)
+	Unique      bool           // Whether the index rejects documents with duplicate keys
+	ExpireAfter *time.Duration // If set, documents expire this long after the date in the (single) indexed field
+	Partial     bson.M         // If set, only documents matching this filter are indexed
+	Name        string         // If set, overrides the default name generated from the Keys
+}
+
+// CreateIndexes creates the provided indexes on the specified database collection. Indexes that already
+// exist with the same definition are left unchanged.
+//
+// dbName is the name of the database containing the collection to index.
+//
+// collName is the name of the collection to index.
+//
+// indexes is the slice of Indexes to create.
+func (mdb *MongoDB) CreateIndexes(dbName string, collName string, indexes []Index) error {
+	// Create a new client and connect to the server
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, mdb.timeout)
+	defer cancel()
+	client, err := createClient(mdb.connStr, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the database: %v", err)
+	}
+
+	// Setup deferred connection closure for when function completes
+	defer func() {
+		if err = client.Disconnect(ctx); err != nil {
+			mdb.logger.Print(err)
+		}
+	}()
+
+	// Acquire reference to the database collection
+	coll := client.Database(dbName).Collection(collName)
+
+	// Convert the indexes into driver index models
+	models := make([]mongo.IndexModel, 0, len(indexes))
+	for _, idx := range indexes {
+		opts := options.Index().SetUnique(idx.Unique)
+		if idx.ExpireAfter != nil {
+			opts.SetExpireAfterSeconds(int32(idx.ExpireAfter.Seconds()))
+		}
+		if idx.Partial != nil {
+			opts.SetPartialFilterExpression(idx.Partial)
+		}
+		if len(idx.Name) > 0 {
+			opts.SetName(idx.Name)
+		}
+		models = append(models, mongo.IndexModel{Keys: idx.Keys, Options: opts})
+	}
+
+	// Create the indexes
+	if _, err := coll.Indexes().CreateMany(ctx, models); err != nil {
+		return fmt.Errorf("failed to create indexes on %s.%s: %v", dbName, collName, err)
+	}
+
+	return nil
+}
+
+// DropIndex drops the named index from the specified database collection, if it exists.
+//
+// dbName is the name of the database containing the collection.
+//
+// collName is the name of the collection containing the index.
+//
+// name is the name of the index to drop.
+func (mdb *MongoDB) DropIndex(dbName string, collName string, name string) error {
+	// Create a new client and connect to the server
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, mdb.timeout)
+	defer cancel()
+	client, err := createClient(mdb.connStr, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the database: %v", err)
+	}
+
+	// Setup deferred connection closure for when function completes
+	defer func() {
+		if err = client.Disconnect(ctx); err != nil {
+			mdb.logger.Print(err)
+		}
+	}()
+
+	// Drop the index, ignoring indexes (and collections) that do not exist
+	coll := client.Database(dbName).Collection(collName)
+	if _, err := coll.Indexes().DropOne(ctx, name); err != nil {
+		var cmdErr mongo.CommandError
+		if errors.As(err, &cmdErr) && (cmdErr.Code == 26 || cmdErr.Code == 27) { // NamespaceNotFound, IndexNotFound
+			return nil
+		}
+		return fmt.Errorf("failed to drop index %s on %s.%s: %v", name, dbName, collName, err)
+	}
+
+	return nil
+}
+
 func createClient(connStr string, ctx context.Context) (*mongo.Client, error) {
 	// Use the SetServerAPIOptions() method to set the Stable API version to 1
 	serverAPI := options.ServerAPI(options.ServerAPIVersion1)
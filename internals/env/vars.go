@@ -2,7 +2,10 @@ package env
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -10,6 +13,50 @@ var MongoDBConnStr string
 var Timeout time.Duration
 var InviteExpiration time.Duration
 var SessionExpiration time.Duration
+var EmailVerificationExpiration time.Duration
+var PendingSessionExpiration time.Duration
+
+// Login brute-force protection configuration
+var LoginFreeAttempts int
+var LoginLockoutThreshold int
+var LoginAddressLockoutThreshold int
+var LoginLockoutDuration time.Duration
+var LoginAttemptWindow time.Duration
+
+// Password hashing/policy configuration
+var PasswordAlgorithm string
+var BcryptCost int
+var Argon2Time int
+var Argon2Memory int
+var Argon2Threads int
+var PasswordMinLength int
+var BreachedPasswordsFile string
+
+// Cross-origin request and cookie configuration
+var CORSOrigins []string
+var SecureCookies bool
+
+// Notification configuration
+var ReminderOffsets []time.Duration
+var BaseURL string // Absolute URL of the site, used to link to it from outside of the browser (e.g. - in emails)
+var SMTPAddr string
+var SMTPUsername string
+var SMTPPassword string
+var SMTPFrom string
+
+// OpenID Connect login configuration
+var OIDCProviders []OIDCProviderConfig
+var OIDCStateExpiration time.Duration
+
+// OIDCProviderConfig contains the client registration details for a single OpenID Connect identity provider.
+type OIDCProviderConfig struct {
+	Name         string // Short name used in login URLs (e.g. - "google")
+	DisplayName  string // Name shown to Users on the login page
+	Issuer       string // Issuer URL used for OpenID Connect discovery
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string // Absolute URL of the provider's callback route
+}
 
 func LoadVars() error {
 	var err error
@@ -22,10 +69,83 @@ func LoadVars() error {
 	Timeout = time.Second * 10
 	InviteExpiration = time.Hour * 24 * 7
 	SessionExpiration = time.Hour * 24 * 7
+	EmailVerificationExpiration = time.Hour * 24
+	PendingSessionExpiration = time.Minute * 5
+	LoginFreeAttempts = 3
+	LoginLockoutThreshold = 10
+	LoginAddressLockoutThreshold = 50
+	LoginLockoutDuration = time.Minute * 15
+	LoginAttemptWindow = time.Hour * 24
+
+	PasswordAlgorithm = loadOptionalVar("MULHALL_PASSWORD_ALGORITHM", "argon2id")
+	if BcryptCost, err = loadIntVar("MULHALL_BCRYPT_COST", 12); err != nil {
+		return err
+	}
+	if Argon2Time, err = loadIntRangeVar("MULHALL_ARGON2_TIME", 2, 1, math.MaxInt32); err != nil {
+		return err
+	}
+	if Argon2Memory, err = loadIntRangeVar("MULHALL_ARGON2_MEMORY_KIB", 19*1024, 1, math.MaxInt32); err != nil {
+		return err
+	}
+	if Argon2Threads, err = loadIntRangeVar("MULHALL_ARGON2_THREADS", 1, 1, math.MaxUint8); err != nil {
+		return err
+	}
+	if PasswordMinLength, err = loadIntVar("MULHALL_PASSWORD_MIN_LENGTH", 10); err != nil {
+		return err
+	}
+	BreachedPasswordsFile = loadOptionalVar("MULHALL_BREACHED_PASSWORDS_FILE", "")
+
+	CORSOrigins = loadListVar("MULHALL_CORS_ORIGINS")
+	if SecureCookies, err = loadBoolVar("MULHALL_SECURE_COOKIES", true); err != nil {
+		return err
+	}
+
+	if ReminderOffsets, err = loadDurationListVar("MULHALL_REMINDER_OFFSETS", []time.Duration{time.Hour * 24, time.Hour}); err != nil {
+		return err
+	}
+	BaseURL = strings.TrimSuffix(loadOptionalVar("MULHALL_BASE_URL", ""), "/")
+	SMTPAddr = loadOptionalVar("MULHALL_SMTP_ADDR", "")
+	SMTPUsername = loadOptionalVar("MULHALL_SMTP_USERNAME", "")
+	SMTPPassword = loadOptionalVar("MULHALL_SMTP_PASSWORD", "")
+	SMTPFrom = loadOptionalVar("MULHALL_SMTP_FROM", "")
+
+	OIDCStateExpiration = time.Minute * 10
+	if OIDCProviders, err = loadOIDCProviders(); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// loadOIDCProviders loads the configuration of each provider named in MULHALL_OIDC_PROVIDERS
+// (e.g. - "google,okta") from its MULHALL_OIDC_<NAME>_* variables.
+func loadOIDCProviders() ([]OIDCProviderConfig, error) {
+	providers := make([]OIDCProviderConfig, 0)
+	for _, name := range loadListVar("MULHALL_OIDC_PROVIDERS") {
+		name = strings.ToLower(name)
+		prefix := "MULHALL_OIDC_" + strings.ToUpper(name) + "_"
+		p := OIDCProviderConfig{Name: name}
+		var err error
+		if p.Issuer, err = loadVar(prefix + "ISSUER"); err != nil {
+			return nil, err
+		}
+		if p.ClientID, err = loadVar(prefix + "CLIENT_ID"); err != nil {
+			return nil, err
+		}
+		if p.ClientSecret, err = loadVar(prefix + "CLIENT_SECRET"); err != nil {
+			return nil, err
+		}
+		if p.RedirectURL, err = loadVar(prefix + "REDIRECT_URL"); err != nil {
+			return nil, err
+		}
+		p.DisplayName = loadOptionalVar(prefix+"DISPLAY_NAME", name)
+
+		providers = append(providers, p)
+	}
+
+	return providers, nil
+}
+
 func loadVar(name string) (string, error) {
 	value := os.Getenv(name)
 	if len(value) == 0 {
@@ -34,3 +154,83 @@ func loadVar(name string) (string, error) {
 
 	return value, nil
 }
+
+func loadOptionalVar(name string, def string) string {
+	value := os.Getenv(name)
+	if len(value) == 0 {
+		return def
+	}
+
+	return value
+}
+
+func loadIntVar(name string, def int) (int, error) {
+	value := os.Getenv(name)
+	if len(value) == 0 {
+		return def, nil
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("environment variable %s must be an integer: %v", name, err)
+	}
+
+	return i, nil
+}
+
+// loadIntRangeVar loads an integer that must fall within the inclusive range [min, max].
+func loadIntRangeVar(name string, def int, min int, max int) (int, error) {
+	i, err := loadIntVar(name, def)
+	if err != nil {
+		return 0, err
+	}
+	if i < min || i > max {
+		return 0, fmt.Errorf("environment variable %s must be between %d and %d", name, min, max)
+	}
+
+	return i, nil
+}
+
+func loadBoolVar(name string, def bool) (bool, error) {
+	value := os.Getenv(name)
+	if len(value) == 0 {
+		return def, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("environment variable %s must be a boolean: %v", name, err)
+	}
+
+	return b, nil
+}
+
+// loadListVar loads a comma-separated list, ignoring surrounding whitespace and empty entries.
+func loadListVar(name string) []string {
+	values := make([]string, 0)
+	for _, v := range strings.Split(os.Getenv(name), ",") {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+func loadDurationListVar(name string, def []time.Duration) ([]time.Duration, error) {
+	values := loadListVar(name)
+	if len(values) == 0 {
+		return def, nil
+	}
+
+	durations := make([]time.Duration, 0, len(values))
+	for _, v := range values {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("environment variable %s must be a list of positive durations (e.g. - 24h,1h): %q", name, v)
+		}
+		durations = append(durations, d)
+	}
+
+	return durations, nil
+}
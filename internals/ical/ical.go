@@ -0,0 +1,234 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Minimal iCalendar (RFC 5545) support: reading the VEVENTs of a calendar and writing a calendar of VEVENTs.
+const (
+	dateTimeLayout    = "20060102T150405"
+	utcDateTimeLayout = "20060102T150405Z"
+	maxLineLength     = 75 // octets, excluding the line break
+)
+
+// Calendar is a named collection of Events.
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Event is a single VEVENT of a Calendar.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Categories  []string
+	Start       time.Time
+	End         time.Time
+	Line        int // Line on which the event began, when read by Parse
+}
+
+// Parse reads the VEVENTs of an iCalendar file. Date/times with a TZID are read in that time zone, and date/times
+// without one (floating date/times) are read in the provided default location.
+//
+// r is the [io.Reader] from which the calendar is read.
+//
+// loc is the default [time.Location] of floating date/times.
+func Parse(r io.Reader, loc *time.Location) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0)
+	var current *Event
+	for _, l := range lines {
+		name, params, value := splitLine(l.text)
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			current = &Event{Line: l.number}
+		case name == "END" && strings.EqualFold(value, "VEVENT") && current != nil:
+			events = append(events, *current)
+			current = nil
+		case current == nil:
+			continue
+		case name == "UID":
+			current.UID = unescape(value)
+		case name == "SUMMARY":
+			current.Summary = unescape(value)
+		case name == "DESCRIPTION":
+			current.Description = unescape(value)
+		case name == "CATEGORIES":
+			for _, c := range strings.Split(value, ",") {
+				current.Categories = append(current.Categories, unescape(c))
+			}
+		case name == "DTSTART", name == "DTEND":
+			t, err := parseDateTime(value, params, loc)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", l.number, err)
+			}
+			if name == "DTSTART" {
+				current.Start = t
+			} else {
+				current.End = t
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// Write writes the Calendar in iCalendar format, with all date/times in UTC.
+//
+// w is the [io.Writer] to which the calendar is written.
+//
+// cal is the pointer to the Calendar to write.
+func Write(w io.Writer, cal *Calendar) error {
+	stamp := time.Now().UTC().Format(utcDateTimeLayout)
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Mulhall//Mulhall//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:" + escape(cal.Name),
+	}
+	for _, e := range cal.Events {
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+escape(e.UID),
+			"DTSTAMP:"+stamp,
+			"DTSTART:"+e.Start.UTC().Format(utcDateTimeLayout),
+			"DTEND:"+e.End.UTC().Format(utcDateTimeLayout),
+			"SUMMARY:"+escape(e.Summary),
+		)
+		if len(e.Description) > 0 {
+			lines = append(lines, "DESCRIPTION:"+escape(e.Description))
+		}
+		if len(e.Categories) > 0 {
+			categories := make([]string, 0, len(e.Categories))
+			for _, c := range e.Categories {
+				categories = append(categories, escape(c))
+			}
+			lines = append(lines, "CATEGORIES:"+strings.Join(categories, ","))
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, l := range lines {
+		if _, err := io.WriteString(w, fold(l)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// line is a single content line of a calendar, after unfolding.
+type line struct {
+	number int
+	text   string
+}
+
+// unfold reads the content lines of a calendar, joining lines that were folded onto continuation lines (which
+// begin with a space or tab).
+func unfold(r io.Reader) ([]line, error) {
+	lines := make([]line, 0)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if len(text) == 0 {
+			continue
+		}
+		if (text[0] == ' ' || text[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		lines = append(lines, line{number: number, text: text})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %v", err)
+	}
+
+	return lines, nil
+}
+
+// fold splits a content line into lines of at most maxLineLength octets, without splitting UTF-8 characters.
+func fold(text string) string {
+	var sb strings.Builder
+	length := 0
+	for _, r := range text {
+		size := len(string(r))
+		if length+size > maxLineLength {
+			sb.WriteString("\r\n ")
+			length = 1
+		}
+		sb.WriteRune(r)
+		length += size
+	}
+	sb.WriteString("\r\n")
+
+	return sb.String()
+}
+
+// splitLine splits a content line into its upper-cased name, its parameters (keyed by upper-cased name) and its value.
+func splitLine(text string) (string, map[string]string, string) {
+	// The value follows the first colon that is not within a quoted parameter value
+	quoted := false
+	sep := -1
+	for i, r := range text {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			sep = i
+			break
+		}
+	}
+	if sep < 0 {
+		return strings.ToUpper(text), nil, ""
+	}
+
+	parts := strings.Split(text[:sep], ";")
+	params := make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		if k, v, ok := strings.Cut(p, "="); ok {
+			params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+
+	return strings.ToUpper(parts[0]), params, text[sep+1:]
+}
+
+func parseDateTime(value string, params map[string]string, loc *time.Location) (time.Time, error) {
+	if strings.EqualFold(params["VALUE"], "DATE") {
+		return time.Time{}, fmt.Errorf("date %q has no time of day", value)
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(utcDateTimeLayout, value)
+	}
+	if tzid, ok := params["TZID"]; ok {
+		tz, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unknown time zone %q", tzid)
+		}
+		loc = tz
+	}
+
+	return time.ParseInLocation(dateTimeLayout, value, loc)
+}
+
+func escape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+func unescape(text string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(text)
+}
@@ -0,0 +1,15 @@
+package ioc
+
+import (
+	"github.com/mhs294/mulhall/internals/live"
+)
+
+var liveHub *live.Hub
+
+func LiveHub() *live.Hub {
+	if liveHub == nil {
+		liveHub = live.NewHub()
+	}
+
+	return liveHub
+}
@@ -5,13 +5,25 @@ import (
 )
 
 var userAuthMiddleWare *middleware.UserAuthMiddleware
+var errorMiddleware *middleware.ErrorMiddleware
 
 func UserAuthMiddleware() *middleware.UserAuthMiddleware {
 	if userAuthMiddleWare == nil {
 		logger := Logger()
 		sessRepo := SessionRepository()
-		userAuthMiddleWare = middleware.NewUserAuthMiddleware(logger, sessRepo)
+		userRepo := UserRepository()
+		tokenServ := APITokenService()
+		userAuthMiddleWare = middleware.NewUserAuthMiddleware(logger, sessRepo, userRepo, tokenServ)
 	}
 
 	return userAuthMiddleWare
 }
+
+func ErrorMiddleware() *middleware.ErrorMiddleware {
+	if errorMiddleware == nil {
+		logger := Logger()
+		errorMiddleware = middleware.NewErrorMiddleware(logger)
+	}
+
+	return errorMiddleware
+}
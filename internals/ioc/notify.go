@@ -0,0 +1,27 @@
+package ioc
+
+import (
+	"github.com/mhs294/mulhall/internals/env"
+	"github.com/mhs294/mulhall/internals/notify"
+	"github.com/mhs294/mulhall/internals/types/notifications"
+)
+
+var dispatcher *notify.Dispatcher
+
+func NotificationDispatcher() *notify.Dispatcher {
+	if dispatcher == nil {
+		logger := Logger()
+		notifRepo := NotificationRepository()
+		userRepo := UserRepository()
+		senders := map[notifications.Channel]notify.Sender{
+			notifications.WEBHOOK: notify.NewWebhookSender(env.Timeout),
+		}
+		// Email is only available once an SMTP server (and the URL that emails link to) has been configured
+		if len(env.SMTPAddr) > 0 && len(env.SMTPFrom) > 0 && len(env.BaseURL) > 0 {
+			senders[notifications.EMAIL] = notify.NewEmailSender(env.SMTPAddr, env.SMTPUsername, env.SMTPPassword, env.SMTPFrom, env.BaseURL)
+		}
+		dispatcher = notify.NewDispatcher(logger, notifRepo, userRepo, senders)
+	}
+
+	return dispatcher
+}
@@ -0,0 +1,19 @@
+package ioc
+
+import (
+	"github.com/mhs294/mulhall/internals/env"
+	"github.com/mhs294/mulhall/internals/oidc"
+)
+
+var oidcProviders []*oidc.Provider
+
+func OIDCProviders() []*oidc.Provider {
+	if oidcProviders == nil {
+		oidcProviders = make([]*oidc.Provider, 0, len(env.OIDCProviders))
+		for _, cfg := range env.OIDCProviders {
+			oidcProviders = append(oidcProviders, oidc.NewProvider(cfg))
+		}
+	}
+
+	return oidcProviders
+}
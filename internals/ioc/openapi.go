@@ -0,0 +1,15 @@
+package ioc
+
+import (
+	"github.com/mhs294/mulhall/internals/openapi"
+)
+
+var openAPIDocument *openapi.Document
+
+func OpenAPIDocument() *openapi.Document {
+	if openAPIDocument == nil {
+		openAPIDocument = openapi.NewDocument("Mulhall API", "1.0.0")
+	}
+
+	return openAPIDocument
+}
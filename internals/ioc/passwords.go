@@ -0,0 +1,52 @@
+package ioc
+
+import (
+	"fmt"
+
+	"github.com/mhs294/mulhall/internals/env"
+	"github.com/mhs294/mulhall/internals/passwords"
+	"github.com/mhs294/mulhall/internals/types/algorithms"
+)
+
+var pwdHasher passwords.Hasher
+var pwdPolicy *passwords.Policy
+
+func PasswordHasher() passwords.Hasher {
+	if pwdHasher == nil {
+		var err error
+		switch algorithms.Algorithm(env.PasswordAlgorithm) {
+		case algorithms.BCRYPT:
+			pwdHasher, err = passwords.NewBcryptHasher(env.BcryptCost)
+		case algorithms.ARGON2ID:
+			pwdHasher, err = passwords.NewArgon2idHasher(
+				uint32(env.Argon2Time),
+				uint32(env.Argon2Memory),
+				uint8(env.Argon2Threads))
+		default:
+			err = fmt.Errorf("unsupported password algorithm: %s", env.PasswordAlgorithm)
+		}
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	return pwdHasher
+}
+
+func PasswordPolicy() *passwords.Policy {
+	if pwdPolicy == nil {
+		// bcrypt ignores any bytes past the 72nd, so longer passwords must be rejected outright
+		maxBytes := 128
+		if PasswordHasher().Params().Algorithm == algorithms.BCRYPT {
+			maxBytes = 72
+		}
+
+		var err error
+		pwdPolicy, err = passwords.NewPolicy(env.PasswordMinLength, maxBytes, env.BreachedPasswordsFile)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	return pwdPolicy
+}
@@ -12,6 +12,12 @@ var poolRepo *repos.PoolRepository
 var contestantRepo *repos.ContestantRepository
 var entryRepo *repos.EntryRepository
 var scheduleRepo *repos.ScheduleRepository
+var loginAttemptRepo *repos.LoginAttemptRepository
+var auditRepo *repos.AuditRepository
+var settingsRepo *repos.SettingsRepository
+var apiTokenRepo *repos.APITokenRepository
+var oidcStateRepo *repos.OIDCStateRepository
+var notificationRepo *repos.NotificationRepository
 
 func TeamRepository() *repos.TeamRepository {
 	if teamRepo == nil {
@@ -108,3 +114,75 @@ func ScheduleRepository() *repos.ScheduleRepository {
 
 	return scheduleRepo
 }
+
+func LoginAttemptRepository() *repos.LoginAttemptRepository {
+	if loginAttemptRepo == nil {
+		mdb := MongoDB()
+		loginAttemptRepo = repos.NewLoginAttemptRepository(mdb)
+		if err := loginAttemptRepo.TestConnection(); err != nil {
+			panic(err)
+		}
+	}
+
+	return loginAttemptRepo
+}
+
+func AuditRepository() *repos.AuditRepository {
+	if auditRepo == nil {
+		mdb := MongoDB()
+		auditRepo = repos.NewAuditRepository(mdb)
+		if err := auditRepo.TestConnection(); err != nil {
+			panic(err)
+		}
+	}
+
+	return auditRepo
+}
+
+func SettingsRepository() *repos.SettingsRepository {
+	if settingsRepo == nil {
+		mdb := MongoDB()
+		settingsRepo = repos.NewSettingsRepository(mdb)
+		if err := settingsRepo.TestConnection(); err != nil {
+			panic(err)
+		}
+	}
+
+	return settingsRepo
+}
+
+func APITokenRepository() *repos.APITokenRepository {
+	if apiTokenRepo == nil {
+		mdb := MongoDB()
+		apiTokenRepo = repos.NewAPITokenRepository(mdb)
+		if err := apiTokenRepo.TestConnection(); err != nil {
+			panic(err)
+		}
+	}
+
+	return apiTokenRepo
+}
+
+func OIDCStateRepository() *repos.OIDCStateRepository {
+	if oidcStateRepo == nil {
+		mdb := MongoDB()
+		oidcStateRepo = repos.NewOIDCStateRepository(mdb)
+		if err := oidcStateRepo.TestConnection(); err != nil {
+			panic(err)
+		}
+	}
+
+	return oidcStateRepo
+}
+
+func NotificationRepository() *repos.NotificationRepository {
+	if notificationRepo == nil {
+		mdb := MongoDB()
+		notificationRepo = repos.NewNotificationRepository(mdb)
+		if err := notificationRepo.TestConnection(); err != nil {
+			panic(err)
+		}
+	}
+
+	return notificationRepo
+}
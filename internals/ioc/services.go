@@ -1,6 +1,7 @@
 package ioc
 
 import (
+	"github.com/mhs294/mulhall/internals/env"
 	"github.com/mhs294/mulhall/internals/services"
 )
 
@@ -10,11 +11,26 @@ var poolService *services.PoolService
 var conService *services.ContestantService
 var schService *services.ScheduleService
 var entryService *services.EntryService
+var picksService *services.PicksService
+var auditService *services.AuditService
+var attemptService *services.LoginAttemptService
+var settingsService *services.SettingsService
+var tokenService *services.APITokenService
+var oidcService *services.OIDCService
+var userAdminService *services.UserAdminService
+var standingsService *services.StandingsService
+var schImportService *services.ScheduleImportService
+var calendarService *services.CalendarService
+var liveUpdateService *services.LiveUpdateService
+var notificationService *services.NotificationService
 
 func InviteService() *services.InviteService {
 	if invService == nil {
 		repo := InviteRepository()
-		invService = services.NewInviteService(repo)
+		userRepo := UserRepository()
+		conService := ContestantService()
+		notifServ := NotificationService()
+		invService = services.NewInviteService(repo, userRepo, conService, notifServ)
 	}
 
 	return invService
@@ -25,7 +41,12 @@ func UserService() *services.UserService {
 		invServ := InviteService()
 		userRepo := UserRepository()
 		sessRepo := SessionRepository()
-		userService = services.NewUserService(invServ, userRepo, sessRepo)
+		attemptServ := LoginAttemptService()
+		settingsServ := SettingsService()
+		notifServ := NotificationService()
+		hasher := PasswordHasher()
+		policy := PasswordPolicy()
+		userService = services.NewUserService(invServ, userRepo, sessRepo, attemptServ, settingsServ, notifServ, hasher, policy)
 	}
 
 	return userService
@@ -43,8 +64,9 @@ func PoolService() *services.PoolService {
 func ContestantService() *services.ContestantService {
 	if conService == nil {
 		conRepo := ContestantRepository()
+		userRepo := UserRepository()
 		poolService := PoolService()
-		conService = services.NewContestantService(conRepo, poolService)
+		conService = services.NewContestantService(conRepo, userRepo, poolService)
 	}
 
 	return conService
@@ -53,7 +75,8 @@ func ContestantService() *services.ContestantService {
 func ScheduleService() *services.ScheduleService {
 	if schService == nil {
 		repo := ScheduleRepository()
-		schService = services.NewScheduleService(repo)
+		teamRepo := TeamRepository()
+		schService = services.NewScheduleService(repo, teamRepo)
 	}
 
 	return schService
@@ -62,8 +85,146 @@ func ScheduleService() *services.ScheduleService {
 func EntryService() *services.EntryService {
 	if entryService == nil {
 		repo := EntryRepository()
-		entryService = services.NewEntryService(repo)
+		conService := ContestantService()
+		schService := ScheduleService()
+		entryService = services.NewEntryService(repo, conService, schService)
 	}
 
 	return entryService
 }
+
+func PicksService() *services.PicksService {
+	if picksService == nil {
+		conService := ContestantService()
+		schService := ScheduleService()
+		entryService := EntryService()
+		entryRepo := EntryRepository()
+		teamRepo := TeamRepository()
+		userRepo := UserRepository()
+		picksService = services.NewPicksService(conService, schService, entryService, entryRepo, teamRepo, userRepo)
+	}
+
+	return picksService
+}
+
+func AuditService() *services.AuditService {
+	if auditService == nil {
+		repo := AuditRepository()
+		auditService = services.NewAuditService(repo)
+	}
+
+	return auditService
+}
+
+func LoginAttemptService() *services.LoginAttemptService {
+	if attemptService == nil {
+		repo := LoginAttemptRepository()
+		auditServ := AuditService()
+		attemptService = services.NewLoginAttemptService(repo, auditServ)
+	}
+
+	return attemptService
+}
+
+func SettingsService() *services.SettingsService {
+	if settingsService == nil {
+		repo := SettingsRepository()
+		settingsService = services.NewSettingsService(repo)
+	}
+
+	return settingsService
+}
+
+func APITokenService() *services.APITokenService {
+	if tokenService == nil {
+		repo := APITokenRepository()
+		tokenService = services.NewAPITokenService(repo)
+	}
+
+	return tokenService
+}
+
+func OIDCService() *services.OIDCService {
+	if oidcService == nil {
+		providers := OIDCProviders()
+		repo := OIDCStateRepository()
+		userServ := UserService()
+		oidcService = services.NewOIDCService(providers, repo, userServ)
+	}
+
+	return oidcService
+}
+
+func UserAdminService() *services.UserAdminService {
+	if userAdminService == nil {
+		userRepo := UserRepository()
+		sessRepo := SessionRepository()
+		tokenRepo := APITokenRepository()
+		conServ := ContestantService()
+		auditServ := AuditService()
+		userAdminService = services.NewUserAdminService(userRepo, sessRepo, tokenRepo, conServ, auditServ)
+	}
+
+	return userAdminService
+}
+
+func StandingsService() *services.StandingsService {
+	if standingsService == nil {
+		conServ := ContestantService()
+		schServ := ScheduleService()
+		picksServ := PicksService()
+		standingsService = services.NewStandingsService(conServ, schServ, picksServ)
+	}
+
+	return standingsService
+}
+
+func ScheduleImportService() *services.ScheduleImportService {
+	if schImportService == nil {
+		schServ := ScheduleService()
+		teamRepo := TeamRepository()
+		schImportService = services.NewScheduleImportService(schServ, teamRepo)
+	}
+
+	return schImportService
+}
+
+func CalendarService() *services.CalendarService {
+	if calendarService == nil {
+		userRepo := UserRepository()
+		teamRepo := TeamRepository()
+		conServ := ContestantService()
+		schServ := ScheduleService()
+		picksServ := PicksService()
+		calendarService = services.NewCalendarService(userRepo, teamRepo, conServ, schServ, picksServ)
+	}
+
+	return calendarService
+}
+
+func LiveUpdateService() *services.LiveUpdateService {
+	if liveUpdateService == nil {
+		hub := LiveHub()
+		poolServ := PoolService()
+		conServ := ContestantService()
+		schServ := ScheduleService()
+		liveUpdateService = services.NewLiveUpdateService(hub, poolServ, conServ, schServ)
+	}
+
+	return liveUpdateService
+}
+
+func NotificationService() *services.NotificationService {
+	if notificationService == nil {
+		dispatcher := NotificationDispatcher()
+		notifRepo := NotificationRepository()
+		userRepo := UserRepository()
+		entryRepo := EntryRepository()
+		poolServ := PoolService()
+		conServ := ContestantService()
+		schServ := ScheduleService()
+		notificationService = services.NewNotificationService(dispatcher, notifRepo, userRepo, entryRepo, poolServ, conServ, schServ, env.ReminderOffsets)
+	}
+
+	return notificationService
+}
@@ -0,0 +1,17 @@
+package ioc
+
+import (
+	"github.com/mhs294/mulhall/internals/validation"
+)
+
+var requestValidator *validation.RequestValidator
+
+func RequestValidator() *validation.RequestValidator {
+	if requestValidator == nil {
+		logger := Logger()
+		teamRepo := TeamRepository()
+		requestValidator = validation.NewRequestValidator(logger, teamRepo)
+	}
+
+	return requestValidator
+}
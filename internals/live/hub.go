@@ -0,0 +1,115 @@
+package live
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/mhs294/mulhall/internals/types"
+)
+
+// subscriptionBuffer is the number of updates that can be queued for a Subscription before further updates are
+// dropped. Subscribers refresh their whole view on each update, so a dropped update is superseded by the next one.
+const subscriptionBuffer = 16
+
+// Topic identifies the set of live updates that a Subscription receives.
+type Topic string
+
+// PoolTopic returns the Topic of live updates about the standings of a Pool.
+//
+// id is the unique identifier of the Pool.
+func PoolTopic(id types.PoolID) Topic {
+	return Topic(fmt.Sprintf("pool:%s", id))
+}
+
+// ContestantTopic returns the Topic of live updates about the picks of a Contestant, which are only visible to the
+// Contestant's authorized Users.
+//
+// id is the unique identifier of the Contestant.
+func ContestantTopic(id types.ContestantID) Topic {
+	return Topic(fmt.Sprintf("contestant:%s", id))
+}
+
+// Hub is an in-process publish/subscribe hub that delivers live updates to the Subscriptions of each Topic.
+type Hub struct {
+	mu   sync.RWMutex
+	subs map[Topic]map[*Subscription]struct{}
+}
+
+// Subscription receives the live updates published to its Topic until it is closed.
+type Subscription struct {
+	Updates <-chan types.LiveUpdateResponse
+
+	hub     *Hub
+	topic   Topic
+	updates chan types.LiveUpdateResponse
+	once    sync.Once
+}
+
+// NewHub creates a new instance of a Hub and returns a pointer to it.
+func NewHub() *Hub {
+	return &Hub{subs: make(map[Topic]map[*Subscription]struct{})}
+}
+
+// Subscribe creates a Subscription to the live updates published to a Topic. The Subscription must be closed once
+// the subscriber is no longer listening.
+//
+// topic is the Topic whose live updates will be received.
+func (h *Hub) Subscribe(topic Topic) *Subscription {
+	updates := make(chan types.LiveUpdateResponse, subscriptionBuffer)
+	sub := &Subscription{Updates: updates, hub: h, topic: topic, updates: updates}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, exists := h.subs[topic]; !exists {
+		h.subs[topic] = make(map[*Subscription]struct{})
+	}
+	h.subs[topic][sub] = struct{}{}
+
+	return sub
+}
+
+// Publish delivers a live update to every Subscription of the provided Topics, without waiting for slow
+// subscribers (whose queued updates are dropped once their buffer is full).
+//
+// u is the live update to deliver.
+//
+// topics are the Topics to deliver the live update to.
+func (h *Hub) Publish(u types.LiveUpdateResponse, topics ...Topic) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, t := range topics {
+		for sub := range h.subs[t] {
+			select {
+			case sub.updates <- u:
+			default:
+			}
+		}
+	}
+}
+
+// Broadcast delivers a live update to every Subscription of every Topic.
+//
+// u is the live update to deliver.
+func (h *Hub) Broadcast(u types.LiveUpdateResponse) {
+	h.mu.RLock()
+	topics := make([]Topic, 0, len(h.subs))
+	for t := range h.subs {
+		topics = append(topics, t)
+	}
+	h.mu.RUnlock()
+
+	h.Publish(u, topics...)
+}
+
+// Close stops the Subscription from receiving further live updates and closes its Updates channel.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.hub.mu.Lock()
+		defer s.hub.mu.Unlock()
+		delete(s.hub.subs[s.topic], s)
+		if len(s.hub.subs[s.topic]) == 0 {
+			delete(s.hub.subs, s.topic)
+		}
+		close(s.updates)
+	})
+}
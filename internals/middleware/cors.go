@@ -2,16 +2,26 @@ package middleware
 
 import (
 	"net/http"
+	"slices"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/env"
 )
 
 // CORS sets the appropriate request header values for Cross-Origin Resource Sharing (CORS).
+// Cross-origin requests (including credentials) are only permitted from the origins configured in
+// env.CORSOrigins; requests from any other origin receive no CORS headers and are blocked by the browser.
 func CORS(ctx *gin.Context) {
-	ctx.Header("Access-Control-Allow-Origin", "*")
-	ctx.Header("Access-Control-Allow-Credentials", "true")
-	ctx.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-	ctx.Header("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+	ctx.Header("Vary", "Origin")
+
+	origin := ctx.GetHeader("Origin")
+	if len(origin) > 0 && slices.Contains(env.CORSOrigins, origin) {
+		ctx.Header("Access-Control-Allow-Origin", origin)
+		ctx.Header("Access-Control-Allow-Credentials", "true")
+		ctx.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		ctx.Header("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+		ctx.Header("Access-Control-Expose-Headers", csrfHeader)
+	}
 
 	if ctx.Request.Method == http.MethodOptions {
 		// If OPTIONS is requested, return the CORS headers and stop request processing
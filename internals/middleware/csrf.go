@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/env"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/utils"
+)
+
+// Cross-site request forgery (CSRF) protection using the double-submit pattern: a random token is stored in
+// a cookie and must be echoed back in the X-CSRF-Token header of every state-changing request. Another site
+// can cause the browser to send the cookie, but cannot read it (or the page it is embedded in) to set the header.
+const (
+	csrfCookie      = "mulhall.csrfToken"
+	csrfHeader      = "X-CSRF-Token"
+	csrfTokenLength = 32
+)
+
+// csrfContextKey is the key under which the CSRF token is stored in the request's [context.Context],
+// so that it can be embedded in rendered views.
+type csrfContextKey struct{}
+
+// CSRF issues each client a CSRF token and rejects state-changing requests (i.e. - anything other than
+// GET/HEAD/OPTIONS) that do not echo it back in the X-CSRF-Token header. Requests authenticated with a Bearer
+// APIToken are exempt, since the browser never attaches those credentials automatically.
+// The token is available to views via CSRFToken, and to other clients via the X-CSRF-Token response header.
+func CSRF(ctx *gin.Context) {
+	// Load the client's CSRF token, issuing a new one if it doesn't have one yet
+	token, err := ctx.Cookie(csrfCookie)
+	if err != nil || len(token) != csrfTokenLength {
+		if token, err = utils.CreateSecureAlphaNumToken(csrfTokenLength); err != nil {
+			AbortWithError(ctx, err)
+			return
+		}
+		utils.SetCookie(ctx, csrfCookie, token, int(env.SessionExpiration.Seconds()), "/", http.SameSiteStrictMode)
+	}
+	ctx.Header(csrfHeader, token)
+	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), csrfContextKey{}, token))
+
+	// Verify the token on state-changing requests that could have been sent with ambient (cookie) credentials
+	switch ctx.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	default:
+		if _, ok := bearerToken(ctx); !ok {
+			submitted := ctx.GetHeader(csrfHeader)
+			if subtle.ConstantTimeCompare([]byte(submitted), []byte(token)) != 1 {
+				AbortWithError(ctx, &types.CSRFTokenInvalidError{})
+				return
+			}
+		}
+	}
+
+	// Continue with subsequent middleware/request handling
+	ctx.Next()
+}
+
+// CSRFToken returns the CSRF token that was added to the request's [context.Context] by the CSRF middleware
+// (or an empty string if there is none).
+//
+// ctx is the [context.Context] of the HTTP request.
+func CSRFToken(ctx context.Context) string {
+	token, _ := ctx.Value(csrfContextKey{}).(string)
+	return token
+}
@@ -0,0 +1,240 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/views/fragments"
+)
+
+// errorsTarget is the element that htmx requests swap error fragments into.
+const errorsTarget = "#errors"
+
+// ErrorMiddleware is responsible for turning errors reported by request handlers into HTTP responses.
+type ErrorMiddleware struct {
+	logger *log.Logger
+}
+
+// NewErrorMiddleware creates a new ErrorMiddleware instance and returns a pointer to it.
+//
+// l is the pointer to the [log.Logger] used to record unexpected errors.
+func NewErrorMiddleware(l *log.Logger) *ErrorMiddleware {
+	return &ErrorMiddleware{logger: l}
+}
+
+// HandleErrors responds to requests whose handlers aborted with an error (see [gin.Context.Error]) and wrote no
+// response of their own. The error is mapped to an HTTP status code and described by an ErrorResponse, which is
+// returned as JSON, or as an HTML fragment targeting the #errors element for htmx requests.
+// Unexpected errors are logged and described only generically.
+func (m *ErrorMiddleware) HandleErrors(ctx *gin.Context) {
+	ctx.Next()
+
+	// Only handle requests that failed without writing a response
+	if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+		return
+	}
+
+	err := ctx.Errors.Last().Err
+	status, resp := DescribeError(err)
+	if status == http.StatusInternalServerError {
+		m.logger.Printf("unexpected error occurred while handling request (%s %s): %v", ctx.Request.Method, ctx.Request.URL.Path, err)
+	}
+	if e, ok := err.(*types.LoginThrottledError); ok {
+		ctx.Header("Retry-After", fmt.Sprintf("%d", int64(e.RetryAfter.Seconds())))
+	}
+
+	// htmx only swaps error responses that are explicitly retargeted (see the htmx:beforeSwap listener in the Header)
+	if IsHTMXRequest(ctx) {
+		ctx.Header("HX-Retarget", errorsTarget)
+		ctx.Header("HX-Reswap", "innerHTML")
+		ctx.Header("Content-Type", "text/html; charset=utf-8")
+		ctx.Status(status)
+		if err := fragments.Errors(resp).Render(ctx.Request.Context(), ctx.Writer); err != nil {
+			m.logger.Printf("failed to render error fragment: %v", err)
+		}
+		return
+	}
+
+	ctx.JSON(status, resp)
+}
+
+// DescribeError maps an error to the HTTP status code and ErrorResponse that should be returned to the caller.
+// Errors that are not defined by the types package are treated as unexpected (500 Internal Server Error).
+//
+// err is the error that prevented the request from being completed.
+func DescribeError(err error) (int, *types.ErrorResponse) {
+	switch e := err.(type) {
+	// 400 Bad Request
+	case *types.RequestInvalidError:
+		return describe(http.StatusBadRequest, "request_invalid", "The request could not be read.", "reason", e.Reason)
+	case *types.RequestValidationError:
+		resp := &types.ErrorResponse{Code: "request_validation", Message: "One or more fields are missing or invalid.", Details: e.Fields}
+		return http.StatusBadRequest, resp
+	case *types.PasswordMismatchError:
+		return describe(http.StatusBadRequest, "password_mismatch", "The passwords do not match.")
+	case *types.PasswordPolicyError:
+		return describe(http.StatusBadRequest, "password_policy", "The password does not meet the password policy.", "reason", e.Reason)
+	case *types.APITokenRequestInvalidError:
+		return describe(http.StatusBadRequest, "api_token_request_invalid", "The API token request is invalid.", "reason", e.Reason)
+	case *types.OIDCStateInvalidError:
+		return describe(http.StatusBadRequest, "oidc_state_invalid", "The sign in attempt has expired or was already used. Please try again.")
+	case *types.RoleInvalidError:
+		return describe(http.StatusBadRequest, "role_invalid", "The role is not valid.", "role", string(e.Role))
+	case *types.StatusInvalidError:
+		return describe(http.StatusBadRequest, "status_invalid", "The status is not valid.", "status", string(e.Status))
+	case *types.StandingsSortInvalidError:
+		return describe(http.StatusBadRequest, "standings_sort_invalid", "The standings sort order is not valid.", "sort", string(e.Sort))
+	case *types.ScheduleDateInvalidError:
+		return describe(http.StatusBadRequest, "schedule_date_invalid", "The schedule date must be formatted as YYYY-MM-DD.", "date", e.Date)
+	case *types.ScheduleInvalidClosesError:
+		return describe(http.StatusBadRequest, "schedule_closes_invalid", "The schedule must close within its week.",
+			"start", e.Start.Format(time.RFC3339),
+			"end", e.End.Format(time.RFC3339))
+	case *types.ScheduleImportInvalidError:
+		return describe(http.StatusBadRequest, "schedule_import_invalid", "The schedule file is not valid.",
+			"line", fmt.Sprintf("%d", e.Line),
+			"reason", e.Reason)
+	case *types.MatchupInvalidError:
+		return describe(http.StatusBadRequest, "matchup_invalid", "The matchup is not valid.", "reason", e.Reason)
+	case *types.PickInvalidError:
+		return describe(http.StatusBadRequest, "pick_invalid", "The team is not part of the matchup.",
+			"matchupId", string(e.MatchupID),
+			"teamId", string(e.TeamID))
+
+	// 401 Unauthorized
+	case *types.AuthenticationRequiredError:
+		return describe(http.StatusUnauthorized, "authentication_required", "You must be signed in.")
+	case *types.LoginFailedError:
+		return describe(http.StatusUnauthorized, "login_failed", "The email address or password is incorrect.")
+	case *types.MFACodeInvalidError:
+		return describe(http.StatusUnauthorized, "mfa_code_invalid", "The two-factor authentication code is incorrect.")
+	case *types.APITokenInvalidError:
+		return describe(http.StatusUnauthorized, "api_token_invalid", "The API token is invalid, revoked or expired.")
+	case *types.FeedTokenInvalidError:
+		return describe(http.StatusUnauthorized, "feed_token_invalid", "The calendar feed link is invalid or has been reset.")
+	case *types.OIDCProviderError:
+		return describe(http.StatusUnauthorized, "oidc_login_failed", "The identity provider did not complete the sign in.", "error", e.Code)
+
+	// 403 Forbidden
+	case *types.CSRFTokenInvalidError:
+		return describe(http.StatusForbidden, "csrf_token_invalid", "The request could not be verified. Please reload the page and try again.")
+	case *types.AdministratorRequiredError:
+		return describe(http.StatusForbidden, "administrator_required", "You must be an administrator.")
+	case *types.APITokenScopeError:
+		return describe(http.StatusForbidden, "api_token_scope", "The API token is not authorized for this request.", "scope", string(e.Scope))
+	case *types.PasswordIncorrectError:
+		return describe(http.StatusForbidden, "password_incorrect", "The password is incorrect.")
+	case *types.MFARequiredError:
+		return describe(http.StatusForbidden, "mfa_required", "Two-factor authentication is required for your account.")
+	case *types.OIDCEmailNotVerifiedError:
+		return describe(http.StatusForbidden, "oidc_email_not_verified", "The identity provider has not verified your email address.")
+	case *types.OIDCAccountNotFoundError:
+		return describe(http.StatusForbidden, "oidc_account_not_found", "No account or invite exists for your email address.")
+	case *types.AdminSelfModificationError:
+		return describe(http.StatusForbidden, "admin_self_modification", "Administrators cannot make this change to their own account.")
+	case *types.ContestantForbiddenError:
+		return describe(http.StatusForbidden, "contestant_forbidden", "You are not authorized to make this change for the contestant.")
+	case *types.PoolForbiddenError:
+		return describe(http.StatusForbidden, "pool_forbidden", "You are not a member of this pool.")
+
+	// 404 Not Found
+	case *types.InviteNotFoundError:
+		return describe(http.StatusNotFound, "invite_not_found", "The invite could not be found.")
+	case *types.UserNotFoundError:
+		return describe(http.StatusNotFound, "user_not_found", "The user could not be found.")
+	case *types.EmailChangeNotFoundError:
+		return describe(http.StatusNotFound, "email_change_not_found", "The email change could not be found.")
+	case *types.SessionNotFoundError:
+		return describe(http.StatusNotFound, "session_not_found", "The session could not be found.")
+	case *types.OIDCProviderNotFoundError:
+		return describe(http.StatusNotFound, "oidc_provider_not_found", "The identity provider could not be found.", "provider", e.Name)
+	case *types.APITokenNotFoundError:
+		return describe(http.StatusNotFound, "api_token_not_found", "The API token could not be found.")
+	case *types.PoolNotFoundError:
+		return describe(http.StatusNotFound, "pool_not_found", "The pool could not be found.", "id", string(e.ID))
+	case *types.ContestantNotFoundError:
+		return describe(http.StatusNotFound, "contestant_not_found", "The contestant could not be found.", "id", string(e.ID))
+	case *types.ContestantMemberNotFoundError:
+		return describe(http.StatusNotFound, "contestant_member_not_found", "The user is not an eligible member of the contestant.", "userId", string(e.UserID))
+	case *types.ScheduleNotFoundError:
+		return describe(http.StatusNotFound, "schedule_not_found", "The schedule could not be found.")
+	case *types.MatchupNotFoundError:
+		return describe(http.StatusNotFound, "matchup_not_found", "The matchup could not be found.", "matchupId", string(e.MatchupID))
+	case *types.EntryNotFoundError:
+		return describe(http.StatusNotFound, "entry_not_found", "The entry could not be found.", "id", string(e.ID))
+
+	// 409 Conflict
+	case *types.InviteAlreadyAcceptedError:
+		return describe(http.StatusConflict, "invite_already_accepted", "The invite has already been accepted.")
+	case *types.EmailInUseError:
+		return describe(http.StatusConflict, "email_in_use", "The email address is already in use.")
+	case *types.MFAAlreadyEnabledError:
+		return describe(http.StatusConflict, "mfa_already_enabled", "Two-factor authentication is already enabled.")
+	case *types.MFANotEnrolledError:
+		return describe(http.StatusConflict, "mfa_not_enrolled", "Two-factor authentication has not been set up.")
+	case *types.ContestantOwnerRequiredError:
+		return describe(http.StatusConflict, "contestant_owner_required", "The contestant must have at least one owner.")
+	case *types.ScheduleConflictError:
+		return describe(http.StatusConflict, "schedule_conflict", "A schedule already exists for that week.", "id", string(e.ID))
+	case *types.EntryConflictError:
+		return describe(http.StatusConflict, "entry_conflict", "An entry already exists for that contestant and schedule.", "id", string(e.ID))
+	case *types.ScheduleClosedError:
+		return describe(http.StatusConflict, "schedule_closed", "Picks are closed for this schedule.",
+			"opens", e.Opens.Format(time.RFC3339),
+			"closes", e.Closes.Format(time.RFC3339))
+	case *types.ContestantNotActiveError:
+		return describe(http.StatusConflict, "contestant_not_active", "The contestant can no longer make picks.", "status", string(e.Status))
+	case *types.MatchupLockedError:
+		return describe(http.StatusConflict, "matchup_locked", "The matchup has already started.",
+			"matchupId", string(e.MatchupID),
+			"dateTime", e.DateTime.Format(time.RFC3339))
+	case *types.TeamAlreadyPickedError:
+		return describe(http.StatusConflict, "team_already_picked", "The team has already been picked this season.",
+			"teamId", string(e.TeamID),
+			"scheduleId", string(e.ScheduleID))
+
+	// 410 Gone
+	case *types.InviteExpiredError:
+		return describe(http.StatusGone, "invite_expired", "The invite has expired.")
+	case *types.EmailChangeExpiredError:
+		return describe(http.StatusGone, "email_change_expired", "The email change has expired.")
+
+	// 429 Too Many Requests
+	case *types.LoginThrottledError:
+		return describe(http.StatusTooManyRequests, "login_throttled", "Too many failed attempts. Please try again later.",
+			"retryAfter", fmt.Sprintf("%d", int64(e.RetryAfter.Seconds())))
+
+	default:
+		return describe(http.StatusInternalServerError, "internal_error", "An unexpected error occurred.")
+	}
+}
+
+// describe builds an ErrorResponse from the provided code and message, along with any details provided as
+// alternating key/value pairs. Details with empty values are omitted.
+func describe(status int, code string, message string, details ...string) (int, *types.ErrorResponse) {
+	resp := &types.ErrorResponse{Code: code, Message: message}
+	if len(details) > 1 {
+		resp.Details = make(map[string]string, len(details)/2)
+		for i := 0; i+1 < len(details); i += 2 {
+			if len(details[i+1]) > 0 {
+				resp.Details[details[i]] = details[i+1]
+			}
+		}
+	}
+
+	return status, resp
+}
+
+// AbortWithError stops the handling of the request, leaving the error to be described by the ErrorMiddleware.
+//
+// ctx is the pointer to the [gin.Context] containing the HTTP request.
+//
+// err is the error that prevented the request from being completed.
+func AbortWithError(ctx *gin.Context, err error) {
+	ctx.Error(err)
+	ctx.Abort()
+}
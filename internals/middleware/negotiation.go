@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IsHTMXRequest returns whether the request was made by htmx, and so expects an HTML fragment in response
+// rather than JSON.
+//
+// ctx is the pointer to the [gin.Context] containing the HTTP request.
+func IsHTMXRequest(ctx *gin.Context) bool {
+	return ctx.GetHeader("HX-Request") == "true"
+}
+
+// Deprecated returns a handler that marks responses from legacy routes as deprecated, linking each request to
+// its successor under the provided path prefix (see RFC 9745 and RFC 8288).
+//
+// successorPrefix is the path prefix under which the successor of each legacy route is registered (e.g. - "/api/v1").
+func Deprecated(successorPrefix string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		successor := successorPrefix + ctx.Request.URL.Path
+		if len(ctx.Request.URL.RawQuery) > 0 {
+			successor += "?" + ctx.Request.URL.RawQuery
+		}
+		ctx.Header("Deprecation", "true")
+		ctx.Header("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", strings.ReplaceAll(successor, ">", "%3E")))
+		ctx.Next()
+	}
+}
+
+// Superseded returns a handler that marks responses from a route as deprecated, linking each request to the
+// provided successor route (see RFC 9745 and RFC 8288).
+//
+// successor is the path of the route that replaces the deprecated route (e.g. - "/api/v1/user/notifications/preferences").
+func Superseded(successor string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Header("Deprecation", "true")
+		ctx.Header("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
+		ctx.Next()
+	}
+}
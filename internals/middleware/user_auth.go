@@ -3,17 +3,28 @@ package middleware
 import (
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mhs294/mulhall/internals/repos"
+	"github.com/mhs294/mulhall/internals/services"
 	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/scopes"
 )
 
+// sessionKey is the key under which an authenticated User's Session is stored in the request context.
+const sessionKey = "session"
+
+// apiTokenKey is the key under which the APIToken used to authenticate a request is stored in the request context.
+const apiTokenKey = "apiToken"
+
 // UserAuthMiddleware is responsible for handling user authentication in view/API requests.
 type UserAuthMiddleware struct {
-	logger   *log.Logger
-	sessRepo *repos.SessionRepository
+	logger       *log.Logger
+	sessRepo     *repos.SessionRepository
+	userRepo     *repos.UserRepository
+	tokenService *services.APITokenService
 }
 
 // NewUserAuthMiddleware creates a new UserAuthMiddleware instance and returns a pointer to it.
@@ -21,14 +32,18 @@ type UserAuthMiddleware struct {
 // l is the pointer to the [log.Logger] that will be used at runtime by the UserAuthMiddleware.
 //
 // r is the SessionRepository used to look up Sessions for User authentication.
-func NewUserAuthMiddleware(l *log.Logger, r *repos.SessionRepository) *UserAuthMiddleware {
-	return &UserAuthMiddleware{logger: l, sessRepo: r}
+//
+// ur is the UserRepository used to look up Users for APIToken authentication and Administrator authorization.
+//
+// ts is the APITokenService used to authenticate API requests made with personal APITokens.
+func NewUserAuthMiddleware(l *log.Logger, r *repos.SessionRepository, ur *repos.UserRepository, ts *services.APITokenService) *UserAuthMiddleware {
+	return &UserAuthMiddleware{logger: l, sessRepo: r, userRepo: ur, tokenService: ts}
 }
 
 // ViewAuth handles the validation of user authentication for View (webpage) requests,
 // which will involve redirection to a Login page if unsuccessful.
 func (m *UserAuthMiddleware) ViewAuth(ctx *gin.Context) {
-	sess, err := m.userAuth(ctx)
+	sess, err := m.userAuth(ctx, false)
 	if err != nil {
 		// User is unauthorized, redirect to Login view
 		ctx.Header("Location", "/login")
@@ -37,35 +52,190 @@ func (m *UserAuthMiddleware) ViewAuth(ctx *gin.Context) {
 	}
 
 	// Add the User's Session to the request context, continue with subsequent middleware/request handling
-	ctx.Set("session", sess)
+	ctx.Set(sessionKey, sess)
 	ctx.Next()
 }
 
 // APIAuth handles the validation of user authentication for API requests,
 // which should defer to the calling process to determine how any authentication
 // failures should be handled.
+//
+// Requests may authenticate with either the Session cookie or a personal APIToken in an
+// "Authorization: Bearer" header. APITokens with the READ Scope may only make read-only (GET/HEAD)
+// requests; routes that allow APITokens to make changes must use ScopedAPIAuth instead.
 func (m *UserAuthMiddleware) APIAuth(ctx *gin.Context) {
-	sess, err := m.userAuth(ctx)
+	m.apiAuth(ctx, "")
+}
+
+// ScopedAPIAuth returns a handler that validates user authentication for API requests in the same way as
+// APIAuth, except that requests authenticated with a personal APIToken must have the specified Scope.
+//
+// scope is the Scope that an APIToken must have to be authorized for the route.
+func (m *UserAuthMiddleware) ScopedAPIAuth(scope scopes.Scope) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		m.apiAuth(ctx, scope)
+	}
+}
+
+func (m *UserAuthMiddleware) apiAuth(ctx *gin.Context, scope scopes.Scope) {
+	// Authenticate with a personal APIToken if one was provided
+	if secret, ok := bearerToken(ctx); ok {
+		m.tokenAuth(ctx, secret, scope)
+		return
+	}
+
+	sess, err := m.userAuth(ctx, false)
+	if err != nil {
+		// User is unauthorized, return status to caller
+		AbortWithError(ctx, &types.AuthenticationRequiredError{})
+		return
+	}
+
+	// Add the User's Session to the request context, continue with subsequent middleware/request handling
+	ctx.Set(sessionKey, sess)
+	ctx.Next()
+}
+
+func (m *UserAuthMiddleware) tokenAuth(ctx *gin.Context, secret string, scope scopes.Scope) {
+	tok, err := m.tokenService.Authenticate(secret)
+	if err != nil {
+		// Token is unknown, revoked or expired, return status to caller
+		if _, ok := err.(*types.APITokenInvalidError); !ok {
+			m.logger.Printf("failed to authenticate api token: %v", err)
+		}
+		AbortWithError(ctx, &types.APITokenInvalidError{})
+		return
+	}
+
+	// APITokens stop authenticating once the User they belong to has been deactivated
+	u, err := m.userRepo.GetByID(tok.User)
+	if err != nil {
+		m.logger.Printf("failed to authenticate api token: %v", err)
+		AbortWithError(ctx, &types.APITokenInvalidError{})
+		return
+	}
+	if u == nil || !u.Active {
+		AbortWithError(ctx, &types.APITokenInvalidError{})
+		return
+	}
+
+	// Read-only requests require the READ Scope, other requests require the Scope declared by the route
+	if len(scope) == 0 {
+		if ctx.Request.Method != http.MethodGet && ctx.Request.Method != http.MethodHead {
+			AbortWithError(ctx, &types.APITokenScopeError{})
+			return
+		}
+		scope = scopes.READ
+	}
+	if !tok.HasScope(scope) {
+		AbortWithError(ctx, &types.APITokenScopeError{Scope: scope})
+		return
+	}
+
+	// Add a Session representing the APIToken to the request context, continue with subsequent middleware/request handling
+	sess := &types.Session{User: tok.User, Created: tok.Created, Expiration: tok.Expiration}
+	ctx.Set(sessionKey, sess)
+	ctx.Set(apiTokenKey, tok)
+	ctx.Next()
+}
+
+// PendingAuth handles the validation of user authentication for the API requests used to complete a login
+// that is awaiting a second authentication factor. Unlike APIAuth, pending Sessions are accepted.
+func (m *UserAuthMiddleware) PendingAuth(ctx *gin.Context) {
+	sess, err := m.userAuth(ctx, true)
 	if err != nil {
 		// User is unauthorized, return status to caller
-		ctx.AbortWithStatus(http.StatusUnauthorized)
+		AbortWithError(ctx, &types.AuthenticationRequiredError{})
 		return
 	}
 
 	// Add the User's Session to the request context, continue with subsequent middleware/request handling
-	ctx.Set("session", sess)
+	ctx.Set(sessionKey, sess)
 	ctx.Next()
 }
 
-func (m *UserAuthMiddleware) userAuth(ctx *gin.Context) (*types.Session, error) {
+// PendingLogin returns the Session of a login that is awaiting a second authentication factor, so that views can
+// prompt for it (or nil if the request does not belong to such a login).
+//
+// ctx is the pointer to the [gin.Context] containing the HTTP request.
+func (m *UserAuthMiddleware) PendingLogin(ctx *gin.Context) *types.Session {
+	sess, err := m.userAuth(ctx, true)
+	if err != nil || !sess.Pending {
+		return nil
+	}
+
+	return sess
+}
+
+// AdminAuth handles the validation of Administrator authorization for requests that have already been
+// authenticated by ViewAuth or APIAuth.
+func (m *UserAuthMiddleware) AdminAuth(ctx *gin.Context) {
+	sess := SessionFromContext(ctx)
+	if sess == nil {
+		AbortWithError(ctx, &types.AuthenticationRequiredError{})
+		return
+	}
+
+	// Look up the User for the Session and verify that they are an active Administrator
+	u, err := m.userRepo.GetByID(sess.User)
+	if err != nil {
+		AbortWithError(ctx, err)
+		return
+	}
+	if u == nil || !u.Active || !u.Administrator {
+		AbortWithError(ctx, &types.AdministratorRequiredError{ID: sess.User})
+		return
+	}
+
+	// Continue with subsequent middleware/request handling
+	ctx.Next()
+}
+
+// SessionFromContext returns the authenticated User's Session that was added to the request context by
+// ViewAuth or APIAuth (or nil if the request has not been authenticated).
+//
+// ctx is the pointer to the [gin.Context] containing the HTTP request.
+func SessionFromContext(ctx *gin.Context) *types.Session {
+	v, exists := ctx.Get(sessionKey)
+	if !exists {
+		return nil
+	}
+
+	sess, _ := v.(*types.Session)
+	return sess
+}
+
+// APITokenFromContext returns the personal APIToken used to authenticate the request
+// (or nil if the request was authenticated with a Session cookie).
+//
+// ctx is the pointer to the [gin.Context] containing the HTTP request.
+func APITokenFromContext(ctx *gin.Context) *types.APIToken {
+	v, exists := ctx.Get(apiTokenKey)
+	if !exists {
+		return nil
+	}
+
+	tok, _ := v.(*types.APIToken)
+	return tok
+}
+
+func bearerToken(ctx *gin.Context) (string, bool) {
+	auth := ctx.GetHeader("Authorization")
+	scheme, token, found := strings.Cut(auth, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || len(strings.TrimSpace(token)) == 0 {
+		return "", false
+	}
+
+	return strings.TrimSpace(token), true
+}
+
+func (m *UserAuthMiddleware) userAuth(ctx *gin.Context, allowPending bool) (*types.Session, error) {
 	// Read the Session ID cookie
 	sessCookie, err := ctx.Cookie("mulhall.sessionID")
 
 	if err != nil {
 		// Couldn't read session cookie, assume user is unauthorized
-		ctx.AbortWithStatus(http.StatusUnauthorized)
-		m.logger.Printf("failed to read session cookie: %v", err)
-		return nil, err
+		return nil, &types.MissingSessionIDError{}
 	}
 
 	// Verify the Session ID is present
@@ -83,9 +253,11 @@ func (m *UserAuthMiddleware) userAuth(ctx *gin.Context) (*types.Session, error)
 
 	// Verify that the Session exists and is active (i.e. - has not expired)
 	if sess == nil {
-		return nil, &types.SessionNotFoundError{ID: sessID}
+		return nil, &types.SessionNotFoundError{Handle: types.SessionHandle(sessID)}
 	} else if sess.Expiration.Before(time.Now().UTC()) {
 		return nil, &types.SessionExpiredError{ID: sessID}
+	} else if sess.Pending && !allowPending {
+		return nil, &types.SessionPendingError{ID: sessID}
 	}
 
 	return sess, nil
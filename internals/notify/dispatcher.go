@@ -0,0 +1,183 @@
+package notify
+
+import (
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mhs294/mulhall/internals/repos"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/notifications"
+)
+
+// Dispatcher routes every Notification sent to a User through the Channels that the User has chosen, honoring their
+// NotificationPreferences. IN_APP Notifications are delivered immediately, while the other Channels are queued on the
+// Notification and delivered by DeliverPending (once the User's quiet hours have ended, if necessary).
+type Dispatcher struct {
+	logger   *log.Logger
+	repo     *repos.NotificationRepository
+	userRepo *repos.UserRepository
+	senders  map[notifications.Channel]Sender
+}
+
+// NewDispatcher creates a new instance of a Dispatcher and returns a pointer to it.
+//
+// l is the pointer to the [log.Logger] used to report Notifications that could not be delivered.
+//
+// r is the NotificationRepository used to store each Notification and the Channels it is pending delivery through.
+//
+// ur is the UserRepository used to load the recipients of each Notification and their preferences.
+//
+// senders are the Senders that deliver Notifications through each Channel other than IN_APP. Channels without a
+// Sender (e.g. - EMAIL, when no SMTP server is configured) are unavailable.
+func NewDispatcher(l *log.Logger, r *repos.NotificationRepository, ur *repos.UserRepository, senders map[notifications.Channel]Sender) *Dispatcher {
+	return &Dispatcher{logger: l, repo: r, userRepo: ur, senders: senders}
+}
+
+// Available indicates whether Notifications can be delivered through the specified Channel.
+//
+// ch is the Channel to check.
+func (d *Dispatcher) Available(ch notifications.Channel) bool {
+	if ch == notifications.IN_APP {
+		return true
+	}
+	_, exists := d.senders[ch]
+
+	return exists
+}
+
+// Dispatch sends the Notification to the User, unless the User has been deactivated, has opted out of its Kind or
+// has already been sent a Notification with the same key. Indicates whether the Notification was sent.
+//
+// userID is the unique identifier of the User to notify.
+//
+// n is the pointer to the Notification to send.
+func (d *Dispatcher) Dispatch(userID types.UserID, n *types.Notification) (bool, error) {
+	// Load the User and their preferences
+	u, err := d.userRepo.GetByID(userID)
+	if err != nil {
+		return false, err
+	}
+	if u == nil || len(u.ID) == 0 || !u.Active {
+		return false, nil
+	}
+	prefs := u.EffectivePreferences()
+	if !prefs.Receives(n.Kind) {
+		return false, nil
+	}
+
+	// Skip Notifications that have already been sent
+	existing, err := d.repo.GetByUserAndKey(u.ID, n.Key)
+	if err != nil {
+		return false, err
+	}
+	if existing != nil && len(existing.ID) > 0 {
+		return false, nil
+	}
+
+	// Deliver the Notification IN_APP, and queue it for each other Channel until any quiet hours have ended
+	now := time.Now().UTC()
+	n.ID = types.NotificationID(uuid.NewString())
+	n.User = u.ID
+	n.Created = now
+	n.Channels = make([]notifications.Channel, 0, 1)
+	n.Pending = nil
+	for _, ch := range prefs.Channels {
+		if ch == notifications.IN_APP {
+			n.Channels = append(n.Channels, ch)
+		} else if d.Available(ch) {
+			n.Pending = append(n.Pending, ch)
+		}
+	}
+	if len(n.Pending) > 0 {
+		n.DeliverAt = now
+		if until, quiet := prefs.QuietUntil(now); quiet {
+			n.DeliverAt = until.UTC()
+		}
+		// Notifications that would expire before the quiet hours end are only delivered IN_APP
+		if n.Expired(n.DeliverAt) {
+			n.Pending = nil
+			n.DeliverAt = time.Time{}
+		}
+	}
+	if err := d.repo.Insert(n); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Email emails the Notification to the provided address, which need not belong to a User (e.g. - to invite someone
+// to the site, or to verify a new email address). Unlike Dispatch, the Notification is sent immediately and is not
+// stored. Indicates whether the Notification was sent, which it is not if EMAIL is unavailable.
+//
+// address is the email address to send the Notification to.
+//
+// n is the pointer to the Notification to send.
+func (d *Dispatcher) Email(address string, n *types.Notification) (bool, error) {
+	sender, ok := d.senders[notifications.EMAIL].(AddressSender)
+	if !ok {
+		return false, nil
+	}
+	if err := sender.SendTo(address, n); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// DeliverPending delivers the Notifications that are pending delivery through Channels other than IN_APP at or
+// before the provided time. Notifications whose User has since entered quiet hours are postponed until they end
+// (unless they would expire first), and Notifications that have expired or whose Channels the User no longer uses
+// are skipped. Failed deliveries are logged and not retried. Returns the number
+// of deliveries made.
+//
+// at is the current time.
+func (d *Dispatcher) DeliverPending(at time.Time) (int, error) {
+	pending, err := d.repo.GetPending(at)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for i := range pending {
+		n := &pending[i]
+		u, err := d.userRepo.GetByID(n.User)
+		if err != nil {
+			return delivered, err
+		}
+
+		if u != nil && len(u.ID) > 0 && u.Active && !n.Expired(at) {
+			prefs := u.EffectivePreferences()
+			until, quiet := prefs.QuietUntil(at)
+			if quiet && !n.Expired(until) {
+				n.DeliverAt = until.UTC()
+				if err := d.repo.Update(n); err != nil {
+					return delivered, err
+				}
+				continue
+			}
+
+			// Notifications that would expire before the quiet hours end are dropped
+			for _, ch := range n.Pending {
+				sender, exists := d.senders[ch]
+				if quiet || !exists || !prefs.Uses(ch) {
+					continue
+				}
+				if err := sender.Send(u, n); err != nil {
+					d.logger.Printf("failed to deliver notification (id=%s, channel=%s): %v", n.ID, ch, err)
+					continue
+				}
+				n.Channels = append(n.Channels, ch)
+				delivered++
+			}
+		}
+
+		n.Pending = nil
+		if err := d.repo.Update(n); err != nil {
+			return delivered, err
+		}
+	}
+
+	return delivered, nil
+}
@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/mhs294/mulhall/internals/types"
+)
+
+// EmailSender delivers Notifications by email through an SMTP server.
+type EmailSender struct {
+	addr    string
+	auth    smtp.Auth
+	from    string
+	baseURL string
+}
+
+// NewEmailSender creates a new instance of an EmailSender and returns a pointer to it.
+//
+// addr is the address of the SMTP server (e.g. - "smtp.example.com:587").
+//
+// username is the username used to authenticate with the SMTP server. If empty, no authentication is attempted.
+//
+// password is the password used to authenticate with the SMTP server.
+//
+// from is the email address that Notifications are sent from.
+//
+// baseURL is the absolute URL of the site, used to link to the page on which each Notification can be acted on.
+// If empty, emails do not include a link.
+func NewEmailSender(addr string, username string, password string, from string, baseURL string) *EmailSender {
+	var auth smtp.Auth
+	if len(username) > 0 {
+		host, _, _ := net.SplitHostPort(addr)
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &EmailSender{addr: addr, auth: auth, from: from, baseURL: baseURL}
+}
+
+// Send emails the Notification to the User's email address.
+//
+// u is the pointer to the User to email.
+//
+// n is the pointer to the Notification to send.
+func (s *EmailSender) Send(u *types.User, n *types.Notification) error {
+	if err := s.SendTo(u.Email, n); err != nil {
+		return fmt.Errorf("failed to send email (user=%s): %v", u.ID, err)
+	}
+
+	return nil
+}
+
+// SendTo emails the Notification to the provided email address.
+//
+// address is the email address to send the Notification to.
+//
+// n is the pointer to the Notification to send.
+func (s *EmailSender) SendTo(address string, n *types.Notification) error {
+	body := n.Message
+	if len(s.baseURL) > 0 && len(n.Link) > 0 {
+		body += "\r\n\r\n" + s.baseURL + n.Link
+	}
+	msg := strings.Join([]string{
+		"From: " + s.from,
+		"To: " + headerValue(address),
+		"Subject: " + headerValue(n.Title),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	if err := smtp.SendMail(s.addr, s.auth, s.from, []string{address}, []byte(msg)); err != nil {
+		return fmt.Errorf("failed to send email to %s: %v", address, err)
+	}
+
+	return nil
+}
+
+// headerValue removes line breaks from a header value, so that it cannot add headers of its own.
+func headerValue(v string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(v)
+}
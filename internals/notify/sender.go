@@ -0,0 +1,17 @@
+package notify
+
+import (
+	"github.com/mhs294/mulhall/internals/types"
+)
+
+// Sender delivers Notifications to Users through a single Channel.
+type Sender interface {
+	// Send delivers the Notification to the User.
+	Send(u *types.User, n *types.Notification) error
+}
+
+// AddressSender delivers Notifications to addresses that do not (yet) belong to a User.
+type AddressSender interface {
+	// SendTo delivers the Notification to the address.
+	SendTo(address string, n *types.Notification) error
+}
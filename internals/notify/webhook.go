@@ -0,0 +1,126 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/mhs294/mulhall/internals/types"
+)
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which is not routable on the public internet.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// WebhookSender delivers Notifications by posting them as JSON to each User's webhook URL. Webhooks may only be
+// delivered to public addresses, so that Users cannot use them to reach the site's internal network.
+type WebhookSender struct {
+	client *http.Client
+}
+
+// NewWebhookSender creates a new instance of a WebhookSender and returns a pointer to it.
+//
+// timeout is the maximum duration of each request to a webhook URL.
+func NewWebhookSender(timeout time.Duration) *WebhookSender {
+	// Verify the address of every connection as it is made, since the webhook's host may resolve differently
+	// than it did when the URL was validated
+	dialer := &net.Dialer{Timeout: timeout, Control: controlPublicDial}
+	transport := &http.Transport{
+		Proxy:               nil,
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: timeout,
+	}
+
+	return &WebhookSender{client: &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+// Send posts the Notification as JSON to the User's webhook URL. Redirects are not followed, and any response status
+// other than 2xx is treated as a failure.
+//
+// u is the pointer to the User whose webhook URL the Notification is posted to.
+//
+// n is the pointer to the Notification to send.
+func (s *WebhookSender) Send(u *types.User, n *types.Notification) error {
+	prefs := u.EffectivePreferences()
+	if len(prefs.WebhookURL) == 0 {
+		return fmt.Errorf("failed to post webhook (user=%s): no webhook url", u.ID)
+	}
+
+	body, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification to json: %v", err)
+	}
+	resp, err := s.client.Post(prefs.WebhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to post webhook (user=%s): %v", u.ID, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to post webhook (user=%s): status %d", u.ID, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// ValidateWebhookURL verifies that the provided URL can be used as a webhook: it must be an https URL whose host
+// resolves only to public addresses.
+//
+// ctx is the [context.Context] used to resolve the URL's host.
+//
+// raw is the URL to validate.
+func ValidateWebhookURL(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || len(u.Hostname()) == 0 || u.User != nil {
+		return fmt.Errorf("must be an https URL")
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("must be an https URL with a resolvable host")
+	}
+	for _, addr := range addrs {
+		if !isPublic(addr) {
+			return fmt.Errorf("must not point to a private, loopback or link-local address")
+		}
+	}
+
+	return nil
+}
+
+// controlPublicDial refuses connections to addresses that are not public.
+func controlPublicDial(network string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("refusing to dial %s: %v", address, err)
+	}
+	if !isPublic(addrPort.Addr()) {
+		return fmt.Errorf("refusing to dial non-public address %s", address)
+	}
+
+	return nil
+}
+
+// isPublic indicates whether the address is routable on the public internet, excluding loopback, private,
+// link-local (including cloud metadata services), multicast and unspecified addresses.
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return addr.IsValid() &&
+		addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!addr.IsLoopback() &&
+		!addr.IsLinkLocalUnicast() &&
+		!sharedAddressSpace.Contains(addr)
+}
@@ -0,0 +1,142 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/mhs294/mulhall/internals/env"
+	"golang.org/x/oauth2"
+)
+
+// Identity contains the verified claims about a User asserted by an identity provider's ID token.
+type Identity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
+
+// Provider performs the OpenID Connect authorization code flow (with PKCE) against a single identity provider.
+// Provider discovery is deferred until the Provider is first used, so that an unreachable identity provider
+// does not prevent the site from starting.
+type Provider struct {
+	cfg      env.OIDCProviderConfig
+	mu       sync.Mutex
+	oauth    *oauth2.Config
+	verifier *gooidc.IDTokenVerifier
+}
+
+// NewProvider creates a new instance of a Provider and returns a pointer to it.
+//
+// cfg is the OIDCProviderConfig containing the client registration details for the identity provider.
+func NewProvider(cfg env.OIDCProviderConfig) *Provider {
+	return &Provider{cfg: cfg}
+}
+
+// Name returns the short name used to identify the Provider in login URLs.
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+// DisplayName returns the name of the Provider shown to Users on the login page.
+func (p *Provider) DisplayName() string {
+	return p.cfg.DisplayName
+}
+
+// AuthCodeURL returns the identity provider URL that the User should be redirected to in order to log in.
+//
+// ctx is the Context used for provider discovery.
+//
+// state is the opaque value that will be returned to the callback to correlate the login attempt.
+//
+// nonce is the value the identity provider must embed in the ID token to bind it to the login attempt.
+//
+// verifier is the PKCE code verifier whose challenge will be sent to the identity provider.
+func (p *Provider) AuthCodeURL(ctx context.Context, state string, nonce string, verifier string) (string, error) {
+	oauth, _, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return oauth.AuthCodeURL(state, gooidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
+}
+
+// Authenticate exchanges an authorization code for tokens and returns the Identity asserted by the verified
+// ID token.
+//
+// ctx is the Context used for requests to the identity provider.
+//
+// code is the authorization code returned to the callback by the identity provider.
+//
+// nonce is the value that must be embedded in the ID token.
+//
+// verifier is the PKCE code verifier that was used to create the authorization request.
+func (p *Provider) Authenticate(ctx context.Context, code string, nonce string, verifier string) (*Identity, error) {
+	oauth, idVerifier, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Exchange the authorization code for tokens
+	tok, err := oauth.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code (provider=%s): %v", p.cfg.Name, err)
+	}
+	rawIDToken, ok := tok.Extra("id_token").(string)
+	if !ok {
+		return nil, fmt.Errorf("token response did not contain an id_token (provider=%s)", p.cfg.Name)
+	}
+
+	// Verify the ID token signature, issuer, audience, expiry and nonce
+	idToken, err := idVerifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify id_token (provider=%s): %v", p.cfg.Name, err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, fmt.Errorf("id_token nonce does not match login attempt (provider=%s)", p.cfg.Name)
+	}
+
+	// Extract the claims describing the User
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+	}
+	if err = idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("failed to parse id_token claims (provider=%s): %v", p.cfg.Name, err)
+	}
+
+	return &Identity{
+		Issuer:        idToken.Issuer,
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+	}, nil
+}
+
+func (p *Provider) discover(ctx context.Context) (*oauth2.Config, *gooidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Only cache successful discovery so that a temporarily unreachable provider is retried
+	if p.oauth != nil {
+		return p.oauth, p.verifier, nil
+	}
+
+	provider, err := gooidc.NewProvider(ctx, p.cfg.Issuer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover oidc provider (provider=%s): %v", p.cfg.Name, err)
+	}
+
+	p.oauth = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       []string{gooidc.ScopeOpenID, "email"},
+	}
+	p.verifier = provider.Verifier(&gooidc.Config{ClientID: p.cfg.ClientID})
+
+	return p.oauth, p.verifier, nil
+}
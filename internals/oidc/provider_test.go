@@ -0,0 +1,224 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/mhs294/mulhall/internals/env"
+)
+
+const (
+	testClientID = "mulhall-test"
+	testVerifier = "test-verifier-test-verifier-test-verifier-0123456789"
+	testCode     = "test-code"
+)
+
+// mockIssuer is a minimal OpenID Connect identity provider that serves discovery, a JWKS and a token endpoint
+// which enforces PKCE and issues ID tokens for a single authorization code.
+type mockIssuer struct {
+	t      *testing.T
+	srv    *httptest.Server
+	key    *rsa.PrivateKey
+	signer *rsa.PrivateKey // Key used to sign ID tokens (differs from key to simulate a bad signature)
+
+	challenge string // PKCE challenge sent with the authorization request
+	nonce     string // Nonce embedded in issued ID tokens
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	m := &mockIssuer{t: t, key: key, signer: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", m.discovery)
+	mux.HandleFunc("/jwks", m.jwks)
+	mux.HandleFunc("/token", m.token)
+	m.srv = httptest.NewServer(mux)
+	t.Cleanup(m.srv.Close)
+
+	return m
+}
+
+func (m *mockIssuer) discovery(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"issuer":                                m.srv.URL,
+		"authorization_endpoint":                m.srv.URL + "/authorize",
+		"token_endpoint":                        m.srv.URL + "/token",
+		"jwks_uri":                              m.srv.URL + "/jwks",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (m *mockIssuer) jwks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &m.key.PublicKey, KeyID: "test", Algorithm: string(jose.RS256), Use: "sig"},
+	}})
+}
+
+func (m *mockIssuer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, `{"error":"invalid_request"}`, http.StatusBadRequest)
+		return
+	}
+
+	// Verify the authorization code and the PKCE code verifier
+	sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+	if r.Form.Get("code") != testCode || base64.RawURLEncoding.EncodeToString(sum[:]) != m.challenge {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"invalid_grant"}`))
+		return
+	}
+
+	// Issue a signed ID token
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: m.signer},
+		(&jose.SignerOptions{}).WithHeader("kid", "test"))
+	if err != nil {
+		m.t.Fatalf("failed to create signer: %v", err)
+	}
+	claims, _ := json.Marshal(map[string]any{
+		"iss":            m.srv.URL,
+		"sub":            "subject-1",
+		"aud":            testClientID,
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"nonce":          m.nonce,
+		"email":          "user@example.com",
+		"email_verified": true,
+	})
+	jws, err := signer.Sign(claims)
+	if err != nil {
+		m.t.Fatalf("failed to sign id_token: %v", err)
+	}
+	idToken, _ := jws.CompactSerialize()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+// begin starts a login against the mock issuer, recording the PKCE challenge and nonce that the issuer will see.
+func (m *mockIssuer) begin(p *Provider, nonce string) *url.URL {
+	m.t.Helper()
+	raw, err := p.AuthCodeURL(context.Background(), "test-state", nonce, testVerifier)
+	if err != nil {
+		m.t.Fatalf("AuthCodeURL failed: %v", err)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		m.t.Fatalf("failed to parse authorization url: %v", err)
+	}
+	m.challenge = u.Query().Get("code_challenge")
+	m.nonce = u.Query().Get("nonce")
+
+	return u
+}
+
+func newTestProvider(m *mockIssuer) *Provider {
+	return NewProvider(env.OIDCProviderConfig{
+		Name:         "mock",
+		DisplayName:  "Mock",
+		Issuer:       m.srv.URL,
+		ClientID:     testClientID,
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost/auth/oidc/mock/callback",
+	})
+}
+
+func TestAuthCodeURLUsesDiscoveredEndpoint(t *testing.T) {
+	m := newMockIssuer(t)
+	u := m.begin(newTestProvider(m), "test-nonce")
+
+	if got := u.Scheme + "://" + u.Host + u.Path; got != m.srv.URL+"/authorize" {
+		t.Errorf("authorization endpoint = %s, want %s/authorize", got, m.srv.URL)
+	}
+	q := u.Query()
+	for param, want := range map[string]string{
+		"client_id":             testClientID,
+		"state":                 "test-state",
+		"nonce":                 "test-nonce",
+		"code_challenge_method": "S256",
+		"response_type":         "code",
+	} {
+		if got := q.Get(param); got != want {
+			t.Errorf("%s = %q, want %q", param, got, want)
+		}
+	}
+	if !strings.Contains(q.Get("scope"), "openid") {
+		t.Errorf("scope = %q, want it to contain openid", q.Get("scope"))
+	}
+	if len(q.Get("code_challenge")) == 0 {
+		t.Error("code_challenge is missing")
+	}
+}
+
+func TestAuthenticateReturnsVerifiedIdentity(t *testing.T) {
+	m := newMockIssuer(t)
+	p := newTestProvider(m)
+	m.begin(p, "test-nonce")
+
+	id, err := p.Authenticate(context.Background(), testCode, "test-nonce", testVerifier)
+	if err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+	if id.Issuer != m.srv.URL || id.Subject != "subject-1" || id.Email != "user@example.com" || !id.EmailVerified {
+		t.Errorf("unexpected identity: %+v", id)
+	}
+}
+
+func TestAuthenticateRejectsNonceMismatch(t *testing.T) {
+	m := newMockIssuer(t)
+	p := newTestProvider(m)
+	m.begin(p, "test-nonce")
+
+	if _, err := p.Authenticate(context.Background(), testCode, "other-nonce", testVerifier); err == nil {
+		t.Fatal("Authenticate succeeded with a mismatched nonce")
+	}
+}
+
+func TestAuthenticateRejectsWrongVerifier(t *testing.T) {
+	m := newMockIssuer(t)
+	p := newTestProvider(m)
+	m.begin(p, "test-nonce")
+
+	wrong := strings.Repeat("x", len(testVerifier))
+	if _, err := p.Authenticate(context.Background(), testCode, "test-nonce", wrong); err == nil {
+		t.Fatal("Authenticate succeeded with the wrong PKCE verifier")
+	}
+}
+
+func TestAuthenticateRejectsBadSignature(t *testing.T) {
+	m := newMockIssuer(t)
+	p := newTestProvider(m)
+	m.begin(p, "test-nonce")
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	m.signer = other
+	if _, err := p.Authenticate(context.Background(), testCode, "test-nonce", testVerifier); err == nil {
+		t.Fatal("Authenticate succeeded with an id_token signed by an unknown key")
+	}
+}
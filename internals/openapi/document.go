@@ -0,0 +1,391 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/scopes"
+)
+
+// Version is the version of the OpenAPI specification that Documents conform to.
+const Version = "3.1.0"
+
+// sessionCookie is the name of the cookie containing a User's SessionID.
+const sessionCookie = "mulhall.sessionID"
+
+// Document is an OpenAPI document describing the HTTP routes of the application
+// (see https://spec.openapis.org/oas/v3.1.0#openapi-object).
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"` // Keyed by path, then by lowercase HTTP method
+	Components Components                       `json:"components"`
+}
+
+// Info provides metadata about the API described by a Document.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Components holds the schemas and security schemes referenced by the Operations of a Document.
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+// SecurityScheme describes a way that callers can authenticate with the API.
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Name        string `json:"name,omitempty"`
+	In          string `json:"in,omitempty"`
+	Scheme      string `json:"scheme,omitempty"`
+}
+
+// Operation describes a single HTTP route.
+type Operation struct {
+	Method      string                `json:"-"`
+	Path        string                `json:"-"`
+	Auth        Auth                  `json:"-"`
+	Tags        []string              `json:"tags"`
+	Summary     string                `json:"summary"`
+	OperationID string                `json:"operationId"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+}
+
+// TokenScope returns the Scope that a personal APIToken must have to make the request described by the Operation,
+// or an empty string if the Operation cannot be requested with an APIToken.
+func (op *Operation) TokenScope() string {
+	for _, req := range op.Security {
+		if sc, exists := req["apiToken"]; exists && len(sc) > 0 {
+			return sc[0]
+		}
+	}
+
+	return ""
+}
+
+// JSONSchema returns the Schema of the JSON content described by the provided MediaTypes, or nil if there is none.
+//
+// content is the content of a request body or response, keyed by content type.
+func JSONSchema(content map[string]*MediaType) *Schema {
+	if mt, exists := content["application/json"]; exists {
+		return mt.Schema
+	}
+
+	return nil
+}
+
+// Parameter describes a path or query parameter of an Operation.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes the body of the requests accepted by an Operation.
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response describes one of the responses returned by an Operation.
+type Response struct {
+	Description string                `json:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// Header describes a header returned with a Response.
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// MediaType describes the body of a request or response of a single content type.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// NewDocument creates a new Document describing no routes and returns a pointer to it.
+//
+// title is the name of the API.
+//
+// version is the version of the API (not of the OpenAPI specification).
+func NewDocument(title string, version string) *Document {
+	d := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       title,
+			Description: "Errors are described by an ErrorResponse. Requests made with htmx (HX-Request: true) receive an HTML error fragment instead.",
+			Version:     version,
+		},
+		Paths: make(map[string]map[string]*Operation),
+		Components: Components{
+			Schemas: make(map[string]*Schema),
+			SecuritySchemes: map[string]*SecurityScheme{
+				"session": {
+					Type:        "apiKey",
+					Description: "The Session cookie set by logging in.",
+					Name:        sessionCookie,
+					In:          "cookie",
+				},
+				"apiToken": {
+					Type:        "http",
+					Description: "A personal API token, which must have the scope listed by each operation.",
+					Scheme:      "bearer",
+				},
+			},
+		},
+	}
+	d.schemaFor(reflect.TypeOf(types.ErrorResponse{}))
+
+	return d
+}
+
+// AddRoutes adds an Operation describing each of the provided Routes to the Document.
+//
+// routes are the Routes to describe.
+func (d *Document) AddRoutes(routes ...Route) {
+	for _, r := range routes {
+		path := toOpenAPIPath(r.Path)
+		if _, exists := d.Paths[path]; !exists {
+			d.Paths[path] = make(map[string]*Operation)
+		}
+		d.Paths[path][strings.ToLower(r.Method)] = d.operation(r, path)
+	}
+}
+
+// Operations returns all of the Operations in the Document, sorted by path and then by HTTP method.
+func (d *Document) Operations() []*Operation {
+	ops := make([]*Operation, 0)
+	for _, item := range d.Paths {
+		for _, op := range item {
+			ops = append(ops, op)
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Path != ops[j].Path {
+			return ops[i].Path < ops[j].Path
+		}
+		return ops[i].Method < ops[j].Method
+	})
+
+	return ops
+}
+
+// Tags returns the distinct tags used to group the Operations in the Document, sorted alphabetically.
+func (d *Document) Tags() []string {
+	seen := make(map[string]struct{})
+	tags := make([]string, 0)
+	for _, op := range d.Operations() {
+		for _, t := range op.Tags {
+			if _, exists := seen[t]; !exists {
+				seen[t] = struct{}{}
+				tags = append(tags, t)
+			}
+		}
+	}
+	sort.Strings(tags)
+
+	return tags
+}
+
+// SchemaNames returns the names of the component schemas in the Document, sorted alphabetically.
+func (d *Document) SchemaNames() []string {
+	names := make([]string, 0, len(d.Components.Schemas))
+	for name := range d.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Verify compares the Document against the routes registered with a gin engine.
+// Returns an error listing any registered routes that the Document does not describe, and any
+// Operations in the Document that do not correspond to a registered route.
+//
+// routes are the routes registered with the gin engine (see [gin.Engine.Routes]).
+//
+// ignore are the path prefixes of registered routes that do not need to be described (e.g. - static content).
+func (d *Document) Verify(routes gin.RoutesInfo, ignore ...string) error {
+	problems := make([]string, 0)
+	registered := make(map[string]struct{}, len(routes))
+	for _, r := range routes {
+		if hasAnyPrefix(r.Path, ignore) {
+			continue
+		}
+
+		path := toOpenAPIPath(r.Path)
+		registered[r.Method+" "+path] = struct{}{}
+		if _, exists := d.Paths[path][strings.ToLower(r.Method)]; !exists {
+			problems = append(problems, fmt.Sprintf("%s %s is not described", r.Method, r.Path))
+		}
+	}
+	for _, op := range d.Operations() {
+		if _, exists := registered[op.Method+" "+op.Path]; !exists {
+			problems = append(problems, fmt.Sprintf("%s %s is described but not registered", op.Method, op.Path))
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("openapi document does not match registered routes:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	return nil
+}
+
+// operation builds the Operation describing the provided Route.
+func (d *Document) operation(r Route, path string) *Operation {
+	op := &Operation{
+		Method:      strings.ToUpper(r.Method),
+		Path:        path,
+		Auth:        r.Auth,
+		Tags:        []string{tag(r)},
+		Summary:     r.Summary,
+		OperationID: operationID(r.Method, path),
+		Responses:   make(map[string]*Response),
+		Security:    security(r),
+		Deprecated:  r.Deprecated,
+	}
+
+	// Path and query parameters
+	for _, name := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		op.Parameters = append(op.Parameters, &Parameter{Name: name[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	for _, p := range r.Query {
+		op.Parameters = append(op.Parameters, &Parameter{Name: p.Name, In: "query", Description: p.Description, Required: p.Required, Schema: &Schema{Type: "string"}})
+	}
+
+	// Request body
+	if r.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{"application/json": {Schema: d.schemaFor(reflect.TypeOf(r.Request))}},
+		}
+	} else if len(r.Upload) > 0 {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{"multipart/form-data": {Schema: &Schema{
+				Type:       "object",
+				Properties: map[string]*Schema{r.Upload: {Type: "string", Format: "binary"}},
+				Required:   []string{r.Upload},
+			}}},
+		}
+	}
+
+	// Successful response
+	status := r.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	resp := &Response{Description: http.StatusText(status)}
+	switch {
+	case len(r.RedirectsTo) > 0:
+		resp.Description = "Redirects to " + r.RedirectsTo
+		resp.Headers = map[string]*Header{"Location": {Schema: &Schema{Type: "string"}}}
+	case r.HTML:
+		resp.Content = map[string]*MediaType{"text/html": {Schema: &Schema{Type: "string"}}}
+	case r.Stream:
+		resp.Content = map[string]*MediaType{"text/event-stream": {Schema: d.schemaFor(reflect.TypeOf(r.Response))}}
+	case r.Response != nil:
+		resp.Content = map[string]*MediaType{"application/json": {Schema: d.schemaFor(reflect.TypeOf(r.Response))}}
+	}
+	op.Responses[fmt.Sprintf("%d", status)] = resp
+
+	// Error responses
+	op.Responses["default"] = &Response{
+		Description: "The request could not be completed",
+		Content: map[string]*MediaType{
+			"application/json": {Schema: &Schema{Ref: schemaRefPrefix + "ErrorResponse"}},
+			"text/html":        {Schema: &Schema{Type: "string", Description: "Error fragment returned to htmx requests."}},
+		},
+	}
+
+	return op
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// toOpenAPIPath converts a gin route path (e.g. - "/pool/:id") to an OpenAPI path template (e.g. - "/pool/{id}").
+func toOpenAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*") {
+			segments[i] = "{" + s[1:] + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// operationID derives a unique identifier for an Operation from its method and path (e.g. - "postPoolIdComplete").
+func operationID(method string, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, word := range regexp.MustCompile(`[A-Za-z0-9]+`).FindAllString(path, -1) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+
+	return b.String()
+}
+
+// tag returns the tag used to group the Route with related Routes, taken from the first segment of its path.
+func tag(r Route) string {
+	if r.HTML {
+		return "views"
+	}
+	first, _, _ := strings.Cut(strings.TrimPrefix(r.Path, "/"), "/")
+	if len(first) == 0 {
+		return "views"
+	}
+
+	return first
+}
+
+// security returns the security requirements of the Route.
+func security(r Route) []map[string][]string {
+	switch r.Auth {
+	case AuthPending, AuthView:
+		return []map[string][]string{{"session": {}}}
+	case AuthAPI, AuthAdmin:
+		scope := r.Scope
+		if len(scope) == 0 {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				scope = scopes.READ
+			} else {
+				// APITokens may only make changes through routes that declare a Scope
+				return []map[string][]string{{"session": {}}}
+			}
+		}
+		return []map[string][]string{{"session": {}}, {"apiToken": {string(scope)}}}
+	default:
+		return []map[string][]string{}
+	}
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+
+	return false
+}
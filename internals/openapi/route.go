@@ -0,0 +1,63 @@
+package openapi
+
+import (
+	"github.com/mhs294/mulhall/internals/types/scopes"
+)
+
+// Auth describes how the caller of a Route must be authenticated.
+type Auth int
+
+const (
+	// AuthNone permits anonymous callers.
+	AuthNone Auth = iota
+	// AuthPending requires a Session cookie, which may still be awaiting a second authentication factor.
+	AuthPending
+	// AuthView requires a Session cookie; anonymous callers are redirected to the login page.
+	AuthView
+	// AuthAPI requires a Session cookie or a personal APIToken sent as a Bearer token.
+	AuthAPI
+	// AuthAdmin requires a Session cookie or personal APIToken belonging to an Administrator.
+	AuthAdmin
+)
+
+// Param describes a query parameter accepted by a Route.
+type Param struct {
+	Name        string
+	Description string
+	Required    bool
+}
+
+// Route describes a single HTTP route registered by a controller, from which the OpenAPI operation
+// for that route is generated.
+type Route struct {
+	Method      string       // HTTP method (e.g. - "GET")
+	Path        string       // gin route path (e.g. - "/pool/:id")
+	Summary     string       // Short description of what the route does
+	Auth        Auth         // How the caller must be authenticated
+	Scope       scopes.Scope // Scope required of APITokens, if other than the default (READ for GET requests)
+	Query       []Param      // Query parameters accepted by the route
+	Request     any          // Zero value of the JSON request body type, or nil if the route has no body
+	Upload      string       // Name of the file field of a multipart/form-data request body, if any
+	Response    any          // Zero value of the JSON response body type, or nil if the route has no body
+	Status      int          // Status code of a successful response (200 if omitted)
+	HTML        bool         // Whether the route responds with an HTML page rather than JSON
+	Stream      bool         // Whether the route responds with Server-Sent Events whose data is the Response type
+	RedirectsTo string       // Location that a successful request is redirected to, if any
+	Deprecated  bool         // Whether the route is only registered for compatibility and will be removed
+}
+
+// String returns a short, human-readable description of the Auth.
+func (a Auth) String() string {
+	switch a {
+	case AuthPending:
+		return "Session (second factor may be pending)"
+	case AuthView:
+		return "Session"
+	case AuthAPI:
+		return "Session or API token"
+	case AuthAdmin:
+		return "Administrator"
+	default:
+		return "None"
+	}
+}
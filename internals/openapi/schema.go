@@ -0,0 +1,242 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mhs294/mulhall/internals/types/events"
+	"github.com/mhs294/mulhall/internals/types/notifications"
+	"github.com/mhs294/mulhall/internals/types/outcomes"
+	"github.com/mhs294/mulhall/internals/types/roles"
+	"github.com/mhs294/mulhall/internals/types/scopes"
+	"github.com/mhs294/mulhall/internals/types/standings"
+	"github.com/mhs294/mulhall/internals/types/status"
+)
+
+// Schema describes the shape of a JSON value (see https://spec.openapis.org/oas/v3.1.0#schema-object).
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	Maximum              *int               `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+}
+
+// TypeName returns a short, human-readable description of the type of value described by the Schema
+// (e.g. - "string (date-time)" or "array of Team").
+func (s *Schema) TypeName() string {
+	switch {
+	case s == nil:
+		return ""
+	case len(s.Ref) > 0:
+		return RefName(s.Ref)
+	case s.Type == "array":
+		return "array of " + s.Items.TypeName()
+	case s.Type == "object" && s.AdditionalProperties != nil:
+		return "map of " + s.AdditionalProperties.TypeName()
+	case len(s.Format) > 0:
+		return fmt.Sprintf("%s (%s)", s.Type, s.Format)
+	default:
+		return s.Type
+	}
+}
+
+// RefName returns the name of the component schema referenced by the provided $ref.
+//
+// ref is the reference to the component schema (e.g. - "#/components/schemas/Team").
+func RefName(ref string) string {
+	return strings.TrimPrefix(ref, schemaRefPrefix)
+}
+
+const schemaRefPrefix = "#/components/schemas/"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// enums lists the values of the enumerated string types exposed by the API.
+var enums = map[reflect.Type][]string{
+	reflect.TypeOf(roles.Role("")):    {string(roles.OWNER), string(roles.MANAGER), string(roles.VIEWER)},
+	reflect.TypeOf(status.Status("")): {string(status.ACTIVE), string(status.ELIMINATED), string(status.DISQUALIFIED)},
+	reflect.TypeOf(scopes.Scope("")):  {string(scopes.READ), string(scopes.PICKS_WRITE), string(scopes.RESULTS_WRITE)},
+	reflect.TypeOf(outcomes.Outcome("")): {
+		string(outcomes.WON), string(outcomes.LOST), string(outcomes.MISSED), string(outcomes.PENDING),
+	},
+	reflect.TypeOf(standings.Sort("")): {string(standings.STATUS), string(standings.SURVIVED), string(standings.NAME)},
+	reflect.TypeOf(events.Event("")): {
+		string(events.PICKS), string(events.PUBLISHED), string(events.RESULT), string(events.ELIMINATED),
+		string(events.REINSTATED),
+	},
+	reflect.TypeOf(notifications.Kind("")): {
+		string(notifications.INVITE), string(notifications.REMINDER), string(notifications.PUBLISHED),
+		string(notifications.ELIMINATION), string(notifications.POOL_RESULT),
+	},
+	reflect.TypeOf(notifications.Channel("")): {
+		string(notifications.IN_APP), string(notifications.EMAIL), string(notifications.WEBHOOK),
+	},
+}
+
+// schemaFor returns the Schema describing the provided type. Named struct types are added to the
+// Document's component schemas and referenced by name.
+func (d *Document) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	if values, exists := enums[t]; exists {
+		return &Schema{Type: "string", Enum: values}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: d.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if len(t.Name()) == 0 || t.NumField() == 0 {
+			return &Schema{Type: "object"}
+		}
+		return d.structRef(t)
+	default:
+		return &Schema{}
+	}
+}
+
+// structRef adds the named struct type to the Document's component schemas (if it has not been added
+// already) and returns a Schema referencing it.
+func (d *Document) structRef(t reflect.Type) *Schema {
+	ref := &Schema{Ref: schemaRefPrefix + t.Name()}
+	if _, exists := d.Components.Schemas[t.Name()]; exists {
+		return ref
+	}
+
+	// Reserve the name before describing the fields, in case the struct refers to itself
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema, t.NumField())}
+	d.Components.Schemas[t.Name()] = s
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		// Fields are named by their JSON names, and fields omitted from JSON are not described
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		} else if len(name) == 0 {
+			name = f.Name
+		}
+
+		prop := d.schemaFor(f.Type)
+		if applyRules(prop, f.Tag.Get("binding")) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+	}
+
+	return ref
+}
+
+// applyRules applies the constraints declared by a field's "binding" struct tag (see the validation package)
+// to the Schema describing that field. Returns whether the field is required.
+func applyRules(s *Schema, binding string) bool {
+	if len(binding) == 0 {
+		return false
+	}
+
+	// Rules following "dive" apply to the elements of a slice or map (and "keys" to the keys of a map)
+	rules, elemRules, _ := strings.Cut(binding, ",dive")
+	required := false
+	for _, rule := range strings.Split(rules, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		n, numErr := strconv.Atoi(param)
+		switch {
+		case name == "required":
+			required = true
+		case name == "email":
+			s.Format = "email"
+		case name == "datetime" && param == time.DateOnly:
+			s.Format = "date"
+		case name == "teamid":
+			s.Description = strings.TrimSpace(s.Description + " The ID of an existing Team.")
+		case name == "nefield":
+			s.Description = strings.TrimSpace(fmt.Sprintf("%s Must differ from %s.", s.Description, strings.ToLower(param[:1])+param[1:]))
+		case (name == "min" || name == "gte") && numErr == nil:
+			setBound(s, n, true)
+		case (name == "max" || name == "lte") && numErr == nil:
+			setBound(s, n, false)
+		}
+	}
+
+	if len(elemRules) > 0 {
+		if _, valueRules, hasKeys := strings.Cut(elemRules, "endkeys"); hasKeys {
+			elemRules = valueRules
+		}
+		elem := s.Items
+		if elem == nil {
+			elem = s.AdditionalProperties
+		}
+		if elem != nil {
+			applyRules(elem, strings.TrimPrefix(elemRules, ","))
+		}
+	}
+
+	return required
+}
+
+// setBound sets the lower (or upper) bound of the value, length or size described by the Schema.
+func setBound(s *Schema, n int, lower bool) {
+	switch s.Type {
+	case "string":
+		if lower {
+			s.MinLength = &n
+		} else {
+			s.MaxLength = &n
+		}
+	case "object":
+		if lower {
+			s.MinProperties = &n
+		} else {
+			s.MaxProperties = &n
+		}
+	case "array":
+		if lower {
+			s.MinItems = &n
+		} else {
+			s.MaxItems = &n
+		}
+	default:
+		if lower {
+			s.Minimum = &n
+		} else {
+			s.Maximum = &n
+		}
+	}
+}
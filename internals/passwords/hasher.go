@@ -0,0 +1,165 @@
+package passwords
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/algorithms"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Hasher produces password hashes using a single configured Algorithm and set of parameters.
+type Hasher interface {
+	// Hash hashes the provided raw password and returns the resulting hash, the salt (if the Algorithm
+	// requires one to be stored separately) and the parameters that were used to produce the hash.
+	Hash(pwd string) (hash string, salt string, params types.HashParams, err error)
+
+	// Params returns the parameters that the Hasher currently uses to produce hashes.
+	Params() types.HashParams
+}
+
+// BcryptHasher is a Hasher that produces bcrypt hashes at a configurable cost.
+type BcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher creates a new BcryptHasher instance and returns a pointer to it.
+//
+// cost is the bcrypt cost (work factor) that will be used to produce hashes.
+func NewBcryptHasher(cost int) (*BcryptHasher, error) {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("bcrypt cost must be between %d and %d (cost=%d)", bcrypt.MinCost, bcrypt.MaxCost, cost)
+	}
+
+	return &BcryptHasher{cost: cost}, nil
+}
+
+// Hash hashes the provided raw password with bcrypt. bcrypt stores its own salt within the hash,
+// so the returned salt is always empty.
+func (h *BcryptHasher) Hash(pwd string) (string, string, types.HashParams, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(pwd), h.cost)
+	if err != nil {
+		return "", "", types.HashParams{}, fmt.Errorf("failed to hash password: %v", err)
+	}
+
+	return string(hash), "", h.Params(), nil
+}
+
+// Params returns the parameters that the BcryptHasher currently uses to produce hashes.
+func (h *BcryptHasher) Params() types.HashParams {
+	return types.HashParams{Algorithm: algorithms.BCRYPT, Cost: h.cost}
+}
+
+// Argon2idHasher is a Hasher that produces argon2id hashes with configurable time/memory/thread costs.
+type Argon2idHasher struct {
+	time      uint32
+	memory    uint32
+	threads   uint8
+	keyLength uint32
+}
+
+// saltLength is the number of random bytes used to salt each argon2id hash.
+const saltLength = 16
+
+// NewArgon2idHasher creates a new Argon2idHasher instance and returns a pointer to it.
+//
+// time is the number of passes argon2id makes over memory.
+//
+// memory is the amount of memory (in KiB) argon2id uses.
+//
+// threads is the degree of parallelism argon2id uses.
+func NewArgon2idHasher(time uint32, memory uint32, threads uint8) (*Argon2idHasher, error) {
+	if time == 0 || memory == 0 || threads == 0 {
+		return nil, fmt.Errorf("argon2id parameters must be positive (time=%d, memory=%d, threads=%d)", time, memory, threads)
+	}
+
+	return &Argon2idHasher{time: time, memory: memory, threads: threads, keyLength: 32}, nil
+}
+
+// Hash hashes the provided raw password with argon2id using a new randomly generated salt.
+// Both the hash and the salt are returned as base64 strings.
+func (h *Argon2idHasher) Hash(pwd string) (string, string, types.HashParams, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", "", types.HashParams{}, fmt.Errorf("failed to generate salt: %v", err)
+	}
+
+	key := argon2.IDKey([]byte(pwd), salt, h.time, h.memory, h.threads, h.keyLength)
+	return base64.RawStdEncoding.EncodeToString(key), base64.RawStdEncoding.EncodeToString(salt), h.Params(), nil
+}
+
+// Params returns the parameters that the Argon2idHasher currently uses to produce hashes.
+func (h *Argon2idHasher) Params() types.HashParams {
+	return types.HashParams{
+		Algorithm: algorithms.ARGON2ID,
+		Time:      h.time,
+		Memory:    h.memory,
+		Threads:   h.threads,
+		KeyLength: h.keyLength,
+	}
+}
+
+// Verify compares the provided raw password against a User's stored password hash, using whichever
+// Algorithm and parameters were used to produce that hash (regardless of the currently configured Hasher).
+// Returns PasswordIncorrectError if the password does not match.
+//
+// u is the User whose password is being verified.
+//
+// pwd is the raw password submitted by the User.
+func Verify(u *types.User, pwd string) error {
+	// Users created through an external identity provider have no password to log in with
+	if len(u.Hash) == 0 {
+		return &types.PasswordIncorrectError{}
+	}
+
+	switch u.HashParams.Algorithm {
+	case algorithms.LEGACY:
+		return compareBcrypt(u.Hash, pwd+u.Salt)
+	case algorithms.BCRYPT:
+		return compareBcrypt(u.Hash, pwd)
+	case algorithms.ARGON2ID:
+		salt, err := base64.RawStdEncoding.DecodeString(u.Salt)
+		if err != nil {
+			return fmt.Errorf("failed to decode password salt: %v", err)
+		}
+		hash, err := base64.RawStdEncoding.DecodeString(u.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to decode password hash: %v", err)
+		}
+
+		p := u.HashParams
+		key := argon2.IDKey([]byte(pwd), salt, p.Time, p.Memory, p.Threads, p.KeyLength)
+		if subtle.ConstantTimeCompare(key, hash) != 1 {
+			return &types.PasswordIncorrectError{}
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unsupported password hash algorithm: %s", u.HashParams.Algorithm)
+	}
+}
+
+// NeedsRehash indicates whether a User's password hash was produced with an Algorithm or parameters
+// other than the ones currently used by the provided Hasher.
+//
+// u is the User whose password hash is being checked.
+//
+// h is the currently configured Hasher.
+func NeedsRehash(u *types.User, h Hasher) bool {
+	return u.HashParams != h.Params()
+}
+
+func compareBcrypt(hash string, pwd string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(pwd))
+	if err == bcrypt.ErrMismatchedHashAndPassword {
+		return &types.PasswordIncorrectError{}
+	} else if err != nil {
+		return fmt.Errorf("failed to verify password: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package passwords
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mhs294/mulhall/internals/types"
+)
+
+// Policy defines the requirements that a new password must satisfy.
+type Policy struct {
+	minLength int
+	maxBytes  int
+	breached  map[string]struct{}
+}
+
+// NewPolicy creates a new Policy instance and returns a pointer to it.
+//
+// minLength is the minimum number of characters a password must contain.
+//
+// maxBytes is the maximum length of a password in bytes (e.g. - 72 for bcrypt, which ignores any further bytes).
+//
+// breachedFile is the path to a local file listing breached passwords that may not be used (or empty to skip
+// this check). Each line contains either a plaintext password or its hex SHA-1 digest, optionally followed
+// by ":count" as in the Have I Been Pwned password lists.
+func NewPolicy(minLength int, maxBytes int, breachedFile string) (*Policy, error) {
+	p := &Policy{minLength: minLength, maxBytes: maxBytes, breached: make(map[string]struct{}, 0)}
+	if len(breachedFile) == 0 {
+		return p, nil
+	}
+
+	// Load the breached password list into memory
+	f, err := os.Open(breachedFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached password list (file=%s): %v", breachedFile, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+
+		// Normalize SHA-1 digests so that they can be matched regardless of case
+		if digest, _, _ := strings.Cut(line, ":"); isSHA1(digest) {
+			line = strings.ToUpper(digest)
+		}
+		p.breached[line] = struct{}{}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read breached password list (file=%s): %v", breachedFile, err)
+	}
+
+	return p, nil
+}
+
+// Check verifies that the provided raw password satisfies the Policy.
+// Returns PasswordPolicyError describing the first requirement that the password does not satisfy.
+//
+// pwd is the raw password to check.
+func (p *Policy) Check(pwd string) error {
+	if len([]rune(pwd)) < p.minLength {
+		return &types.PasswordPolicyError{Reason: fmt.Sprintf("password must be at least %d characters long", p.minLength)}
+	}
+
+	if p.maxBytes > 0 && len(pwd) > p.maxBytes {
+		return &types.PasswordPolicyError{Reason: fmt.Sprintf("password must be at most %d bytes long", p.maxBytes)}
+	}
+
+	digest := sha1.Sum([]byte(pwd))
+	if _, exists := p.breached[pwd]; exists {
+		return &types.PasswordPolicyError{Reason: "password has appeared in a known data breach"}
+	} else if _, exists := p.breached[strings.ToUpper(hex.EncodeToString(digest[:]))]; exists {
+		return &types.PasswordPolicyError{Reason: "password has appeared in a known data breach"}
+	}
+
+	return nil
+}
+
+func isSHA1(s string) bool {
+	if len(s) != sha1.Size*2 {
+		return false
+	}
+
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
@@ -0,0 +1,168 @@
+package repos
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mhs294/mulhall/internals/db"
+	"github.com/mhs294/mulhall/internals/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// APITokenRepository manages APIToken records in the database.
+type APITokenRepository struct {
+	mdb      *db.MongoDB
+	dbName   string
+	collName string
+}
+
+// NewAPITokenRepository creates a new APITokenRepository instance and returns a pointer to it.
+//
+// mdb is the MongoDB instance used by the APITokenRepository.
+func NewAPITokenRepository(mdb *db.MongoDB) *APITokenRepository {
+	return &APITokenRepository{mdb: mdb, dbName: "mulhall", collName: "apiTokens"}
+}
+
+// TestConnection tests the connection to the MongoDB instance and returns any error that occurs.
+func (r *APITokenRepository) TestConnection() error {
+	return r.mdb.TestConnection(r.dbName)
+}
+
+// EnsureIndexes creates the indexes used to look up APIToken records in the database, if they do not already exist.
+func (r *APITokenRepository) EnsureIndexes() error {
+	return r.mdb.CreateIndexes(r.dbName, r.collName, []db.Index{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Unique: true},
+		{Keys: bson.D{{Key: "hash", Value: 1}}, Unique: true},
+		{Keys: bson.D{{Key: "user", Value: 1}}},
+	})
+}
+
+// Insert inserts the provided APIToken into the database.
+//
+// t is the APIToken to insert into the database.
+func (r *APITokenRepository) Insert(t *types.APIToken) error {
+	if err := r.mdb.InsertOne(r.dbName, r.collName, t); err != nil {
+		return fmt.Errorf("failed to insert api token: %v", err)
+	}
+
+	return nil
+}
+
+// GetByID returns the unrevoked APIToken with the provided ID.
+//
+// id is the unique identifier of the APIToken to look up.
+func (r *APITokenRepository) GetByID(id types.APITokenID) (*types.APIToken, error) {
+	// Define the query
+	query := bson.M{
+		"id":      id,
+		"revoked": false,
+	}
+
+	// Load the APIToken from the database
+	var t types.APIToken
+	if err := r.mdb.GetOne(r.dbName, r.collName, query, &t); err != nil {
+		return nil, fmt.Errorf("failed to look up api token (id=%s): %v", id, err)
+	}
+
+	return &t, nil
+}
+
+// GetByHash returns the unrevoked APIToken whose secret has the provided hash.
+//
+// hash is the hash of the APIToken's secret.
+func (r *APITokenRepository) GetByHash(hash string) (*types.APIToken, error) {
+	// Define the query
+	query := bson.M{
+		"hash":    hash,
+		"revoked": false,
+	}
+
+	// Load the APIToken from the database
+	var t types.APIToken
+	if err := r.mdb.GetOne(r.dbName, r.collName, query, &t); err != nil {
+		return nil, fmt.Errorf("failed to look up api token: %v", err)
+	}
+
+	return &t, nil
+}
+
+// GetByUser returns all unrevoked APITokens belonging to the specified User.
+//
+// userID is the unique identifier of the User whose APITokens should be loaded.
+func (r *APITokenRepository) GetByUser(userID types.UserID) ([]types.APIToken, error) {
+	// Define the query
+	query := bson.M{
+		"user":    userID,
+		"revoked": false,
+	}
+
+	// Load the APITokens from the database
+	var tokens []types.APIToken
+	if err := r.mdb.GetAll(r.dbName, r.collName, query, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to look up api tokens (user=%s): %v", userID, err)
+	}
+
+	return tokens, nil
+}
+
+// SetLastUsed records the date/time at which the APIToken with the provided ID was last used.
+//
+// id is the unique identifier of the APIToken that was used.
+//
+// at is the [time.Time] at which the APIToken was used.
+func (r *APITokenRepository) SetLastUsed(id types.APITokenID, at time.Time) error {
+	// Define the filter query and update operation
+	filter := bson.M{"id": id}
+	update := bson.M{
+		"$set": bson.M{
+			"lastused": at,
+		},
+	}
+
+	// Perform the update
+	if err := r.mdb.UpdateOne(r.dbName, r.collName, filter, update); err != nil {
+		return fmt.Errorf("failed to update api token (id=%s): %v", id, err)
+	}
+
+	return nil
+}
+
+// Revoke sets the APIToken with the provided ID to be revoked.
+//
+// id the unique identifier of the APIToken to revoke.
+func (r *APITokenRepository) Revoke(id types.APITokenID) error {
+	// Define the filter query and update operation
+	filter := bson.M{"id": id}
+	update := bson.M{
+		"$set": bson.M{
+			"revoked": true,
+		},
+	}
+
+	// Perform the update
+	if err := r.mdb.UpdateOne(r.dbName, r.collName, filter, update); err != nil {
+		return fmt.Errorf("failed to revoke api token (id=%s): %v", id, err)
+	}
+
+	return nil
+}
+
+// RevokeByUser sets all APITokens belonging to the specified User to be revoked.
+//
+// userID is the unique identifier of the User whose APITokens should be revoked.
+func (r *APITokenRepository) RevokeByUser(userID types.UserID) error {
+	// Define the filter query and update operation
+	filter := bson.M{"user": userID}
+	update := bson.M{
+		"$set": bson.M{
+			"revoked": true,
+		},
+	}
+
+	// Perform the update
+	if err := r.mdb.UpdateMany(r.dbName, r.collName, filter, update); err != nil {
+		return fmt.Errorf("failed to revoke api tokens (user=%s): %v", userID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package repos
+
+import (
+	"fmt"
+
+	"github.com/mhs294/mulhall/internals/db"
+	"github.com/mhs294/mulhall/internals/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AuditRepository manages AuditEvent records in the database.
+type AuditRepository struct {
+	mdb      *db.MongoDB
+	dbName   string
+	collName string
+}
+
+// NewAuditRepository creates a new AuditRepository instance and returns a pointer to it.
+//
+// mdb is the MongoDB instance used by the AuditRepository.
+func NewAuditRepository(mdb *db.MongoDB) *AuditRepository {
+	return &AuditRepository{mdb: mdb, dbName: "mulhall", collName: "auditEvents"}
+}
+
+// TestConnection tests the connection to the MongoDB instance and returns any error that occurs.
+func (r *AuditRepository) TestConnection() error {
+	return r.mdb.TestConnection(r.dbName)
+}
+
+// EnsureIndexes creates the indexes used to look up AuditEvent records in the database, if they do not already exist.
+func (r *AuditRepository) EnsureIndexes() error {
+	return r.mdb.CreateIndexes(r.dbName, r.collName, []db.Index{
+		{Keys: bson.D{{Key: "user", Value: 1}, {Key: "timestamp", Value: -1}}},
+		{Keys: bson.D{{Key: "timestamp", Value: -1}}},
+	})
+}
+
+// Insert inserts the provided AuditEvent into the database.
+//
+// e is the AuditEvent to insert into the database.
+func (r *AuditRepository) Insert(e *types.AuditEvent) error {
+	if err := r.mdb.InsertOne(r.dbName, r.collName, e); err != nil {
+		return fmt.Errorf("failed to insert audit event: %v", err)
+	}
+
+	return nil
+}
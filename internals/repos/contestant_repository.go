@@ -27,6 +27,13 @@ func (r *ContestantRepository) TestConnection() error {
 	return r.mdb.TestConnection(r.dbName)
 }
 
+// EnsureIndexes creates the indexes used to look up Contestant records in the database, if they do not already exist.
+func (r *ContestantRepository) EnsureIndexes() error {
+	return r.mdb.CreateIndexes(r.dbName, r.collName, []db.Index{
+		{Keys: bson.D{{Key: "id", Value: 1}}},
+	})
+}
+
 // Insert inserts the provided Contestant into the database.
 //
 // c is the Contestant to insert into the database.
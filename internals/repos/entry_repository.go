@@ -27,6 +27,25 @@ func (r *EntryRepository) TestConnection() error {
 	return r.mdb.TestConnection(r.dbName)
 }
 
+// EnsureIndexes creates the indexes used to look up Entry records in the database, if they do not already exist.
+// Each Contestant may have only one active Entry per Schedule.
+func (r *EntryRepository) EnsureIndexes() error {
+	// Replace the non-unique index created by earlier versions
+	if err := r.mdb.DropIndex(r.dbName, r.collName, "contestant_1_schedule_1"); err != nil {
+		return err
+	}
+
+	return r.mdb.CreateIndexes(r.dbName, r.collName, []db.Index{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Unique: true},
+		{
+			Keys:    bson.D{{Key: "contestant", Value: 1}, {Key: "schedule", Value: 1}},
+			Unique:  true,
+			Partial: bson.M{"active": true},
+			Name:    "contestant_1_schedule_1_active",
+		},
+	})
+}
+
 // Insert inserts the provided Entry into the database.
 //
 // e is the Entry to insert into the database.
@@ -38,12 +57,72 @@ func (r *EntryRepository) Insert(e *types.Entry) error {
 	return nil
 }
 
-// GetBySchedule gets all Entries for the provided Schedule.
+// Start gets the active Entry for the provided Entry's Contestant and Schedule, inserting the provided Entry if none
+// exists yet. Concurrent calls for the same Contestant and Schedule all return the same Entry.
+//
+// e is the empty Entry to insert if the Contestant has no active Entry for the Schedule.
+func (r *EntryRepository) Start(e *types.Entry) (*types.Entry, error) {
+	// Define the filter query and update operation
+	filter := bson.M{
+		"contestant": e.Contestant,
+		"schedule":   e.Schedule,
+		"active":     true,
+	}
+	update := bson.M{"$setOnInsert": e}
+
+	// Perform the upsert
+	var result types.Entry
+	if err := r.mdb.UpsertOne(r.dbName, r.collName, filter, update, &result); err != nil {
+		return nil, fmt.Errorf("failed to start entry (contestant=%s, schedule=%s): %v", e.Contestant, e.Schedule, err)
+	}
+
+	return &result, nil
+}
+
+// GetByID gets the Entry for the provided ID.
+//
+// id is the unique identifier of the Entry to look up.
+func (r *EntryRepository) GetByID(id types.EntryID) (*types.Entry, error) {
+	// Define the query
+	query := bson.M{"id": id}
+
+	// Load the Entry from the database
+	var e types.Entry
+	if err := r.mdb.GetOne(r.dbName, r.collName, query, &e); err != nil {
+		return nil, fmt.Errorf("failed to look up entry (id=%s): %v", id, err)
+	}
+
+	return &e, nil
+}
+
+// GetByContestantAndSchedule gets the active Entry for the provided Contestant and Schedule.
+//
+// conID is the unique identifier of the Contestant that owns the Entry.
+//
+// schID is the unique identifier of the Schedule that the Entry was made for.
+func (r *EntryRepository) GetByContestantAndSchedule(conID types.ContestantID, schID types.ScheduleID) (*types.Entry, error) {
+	// Define the query
+	query := bson.M{
+		"contestant": conID,
+		"schedule":   schID,
+		"active":     true,
+	}
+
+	// Load the Entry from the database
+	var e types.Entry
+	if err := r.mdb.GetOne(r.dbName, r.collName, query, &e); err != nil {
+		return nil, fmt.Errorf("failed to look up entry (contestant=%s, schedule=%s): %v", conID, schID, err)
+	}
+
+	return &e, nil
+}
+
+// GetBySchedule gets all active Entries for the provided Schedule.
 //
 // id is the unique identifier of the Schedule for which corresponding Entries should be loaded.
 func (r *EntryRepository) GetBySchedule(id types.ScheduleID) ([]types.Entry, error) {
 	// Define the query
-	query := bson.M{"schedule": id}
+	query := bson.M{"schedule": id, "active": true}
 
 	// Load Entries from the database
 	var entries []types.Entry
@@ -54,12 +133,12 @@ func (r *EntryRepository) GetBySchedule(id types.ScheduleID) ([]types.Entry, err
 	return entries, nil
 }
 
-// GetByContestant gets all Entries for the provided Contestant.
+// GetByContestant gets all active Entries for the provided Contestant.
 //
 // id is the unique identifier of the Contestant for which corresponding Entries should be loaded.
 func (r *EntryRepository) GetByContestant(id types.ContestantID) ([]types.Entry, error) {
 	// Define the query
-	query := bson.M{"contestant": id}
+	query := bson.M{"contestant": id, "active": true}
 
 	// Load Entries from the database
 	var entries []types.Entry
@@ -85,3 +164,23 @@ func (r *EntryRepository) Update(e *types.Entry) error {
 
 	return nil
 }
+
+// Deactivate sets the Entry with the provided ID to be inactive.
+//
+// id the unique identifier of the Entry to deactivate.
+func (r *EntryRepository) Deactivate(id types.EntryID) error {
+	// Define the filter query and update operation
+	filter := bson.M{"id": id}
+	update := bson.M{
+		"$set": bson.M{
+			"active": false,
+		},
+	}
+
+	// Perform the update
+	if err := r.mdb.UpdateOne(r.dbName, r.collName, filter, update); err != nil {
+		return fmt.Errorf("failed to deactivate entry (id=%s): %v", id, err)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package repos
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mhs294/mulhall/internals/db"
 	"github.com/mhs294/mulhall/internals/types"
@@ -27,6 +28,14 @@ func (r *InviteRepository) TestConnection() error {
 	return r.mdb.TestConnection(r.dbName)
 }
 
+// EnsureIndexes creates the indexes used to look up Invite records in the database, if they do not already exist.
+func (r *InviteRepository) EnsureIndexes() error {
+	return r.mdb.CreateIndexes(r.dbName, r.collName, []db.Index{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Unique: true},
+		{Keys: bson.D{{Key: "email", Value: 1}}},
+	})
+}
+
 // Insert inserts the provided Invite into the database.
 //
 // inv is the Invite to insert into the database.
@@ -63,6 +72,49 @@ func (r *InviteRepository) Get(email string, token string) (*types.Invite, error
 	return &invs[0], nil
 }
 
+// GetByID returns the Invite for the provided ID (or nil if no such Invite exists).
+//
+// id is the unique identifier of the Invite to look up.
+func (r *InviteRepository) GetByID(id types.InviteID) (*types.Invite, error) {
+	// Define the query
+	query := bson.M{"id": id}
+
+	// Load Invite from the database
+	var inv types.Invite
+	if err := r.mdb.GetOne(r.dbName, r.collName, query, &inv); err != nil {
+		return nil, fmt.Errorf("failed to look up invite (id=%s): %v", id, err)
+	}
+	if len(inv.ID) == 0 {
+		return nil, nil
+	}
+
+	return &inv, nil
+}
+
+// GetPendingByEmail returns the unexpired, unaccepted Invite for the provided email address
+// (or nil if no such Invite exists).
+//
+// email is the email address of the Invite to look up.
+func (r *InviteRepository) GetPendingByEmail(email string) (*types.Invite, error) {
+	// Define the query
+	query := bson.M{
+		"email":      email,
+		"accepted":   false,
+		"expiration": bson.M{"$gt": time.Now().UTC()},
+	}
+
+	// Load Invite from the database
+	var inv types.Invite
+	if err := r.mdb.GetOne(r.dbName, r.collName, query, &inv); err != nil {
+		return nil, fmt.Errorf("failed to look up pending invite (email=%s): %v", email, err)
+	}
+	if len(inv.ID) == 0 {
+		return nil, nil
+	}
+
+	return &inv, nil
+}
+
 // Accept updates the Accepted property of the Invite keyed by the provided ID to be true
 //
 // id is the unique identifier of the Invite being accepted.
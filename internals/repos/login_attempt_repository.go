@@ -0,0 +1,112 @@
+package repos
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mhs294/mulhall/internals/db"
+	"github.com/mhs294/mulhall/internals/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// LoginAttemptRepository manages LoginAttempt records in the database.
+type LoginAttemptRepository struct {
+	mdb      *db.MongoDB
+	dbName   string
+	collName string
+}
+
+// NewLoginAttemptRepository creates a new LoginAttemptRepository instance and returns a pointer to it.
+//
+// mdb is the MongoDB instance used by the LoginAttemptRepository.
+func NewLoginAttemptRepository(mdb *db.MongoDB) *LoginAttemptRepository {
+	return &LoginAttemptRepository{mdb: mdb, dbName: "mulhall", collName: "loginAttempts"}
+}
+
+// TestConnection tests the connection to the MongoDB instance and returns any error that occurs.
+func (r *LoginAttemptRepository) TestConnection() error {
+	return r.mdb.TestConnection(r.dbName)
+}
+
+// EnsureIndexes creates the indexes used to look up LoginAttempt records in the database, if they do not already exist.
+func (r *LoginAttemptRepository) EnsureIndexes() error {
+	return r.mdb.CreateIndexes(r.dbName, r.collName, []db.Index{
+		{Keys: bson.D{{Key: "key", Value: 1}}, Unique: true},
+	})
+}
+
+// GetByKey returns the LoginAttempt for the provided key (or an empty LoginAttempt if no failures are recorded).
+//
+// key is the account or client address key of the LoginAttempt to look up.
+func (r *LoginAttemptRepository) GetByKey(key string) (*types.LoginAttempt, error) {
+	// Define the query
+	query := bson.M{"key": key}
+
+	// Load the LoginAttempt from the database
+	var a types.LoginAttempt
+	if err := r.mdb.GetOne(r.dbName, r.collName, query, &a); err != nil {
+		return nil, fmt.Errorf("failed to look up login attempt (key=%s): %v", key, err)
+	}
+
+	return &a, nil
+}
+
+// IncrementFailures atomically records a failed login attempt for the provided key and returns the
+// updated LoginAttempt.
+//
+// key is the account or client address key of the LoginAttempt to update.
+//
+// at is the [time.Time] at which the failed login attempt occurred.
+func (r *LoginAttemptRepository) IncrementFailures(key string, at time.Time) (*types.LoginAttempt, error) {
+	// Define the filter query and update operation
+	filter := bson.M{"key": key}
+	update := bson.M{
+		"$inc": bson.M{"failures": 1},
+		"$set": bson.M{"lastfailure": at},
+	}
+
+	// Perform the upsert
+	var a types.LoginAttempt
+	if err := r.mdb.UpsertOne(r.dbName, r.collName, filter, update, &a); err != nil {
+		return nil, fmt.Errorf("failed to record login failure (key=%s): %v", key, err)
+	}
+
+	return &a, nil
+}
+
+// SetLockedUntil blocks further login attempts for the provided key until the specified date/time.
+//
+// key is the account or client address key of the LoginAttempt to update.
+//
+// until is the [time.Time] at which login attempts will be allowed again.
+func (r *LoginAttemptRepository) SetLockedUntil(key string, until time.Time) error {
+	// Define the filter query and update operation
+	filter := bson.M{"key": key}
+	update := bson.M{
+		"$set": bson.M{
+			"lockeduntil": until,
+		},
+	}
+
+	// Perform the update
+	if err := r.mdb.UpdateOne(r.dbName, r.collName, filter, update); err != nil {
+		return fmt.Errorf("failed to lock login attempts (key=%s): %v", key, err)
+	}
+
+	return nil
+}
+
+// Reset clears all recorded login failures for the provided key.
+//
+// key is the account or client address key of the LoginAttempt to reset.
+func (r *LoginAttemptRepository) Reset(key string) error {
+	// Define the filter query
+	filter := bson.M{"key": key}
+
+	// Perform the delete
+	if err := r.mdb.DeleteMany(r.dbName, r.collName, filter); err != nil {
+		return fmt.Errorf("failed to reset login attempts (key=%s): %v", key, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package repos
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mhs294/mulhall/internals/db"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/notifications"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// NotificationRepository manages Notification records in the database.
+type NotificationRepository struct {
+	mdb      *db.MongoDB
+	dbName   string
+	collName string
+}
+
+// NewNotificationRepository creates a new NotificationRepository instance and returns a pointer to it.
+//
+// mdb is the MongoDB instance used by the NotificationRepository.
+func NewNotificationRepository(mdb *db.MongoDB) *NotificationRepository {
+	return &NotificationRepository{mdb: mdb, dbName: "mulhall", collName: "notifications"}
+}
+
+// TestConnection tests the connection to the MongoDB instance and returns any error that occurs.
+func (r *NotificationRepository) TestConnection() error {
+	return r.mdb.TestConnection(r.dbName)
+}
+
+// EnsureIndexes creates the indexes used to look up Notification records in the database, if they do not already
+// exist.
+func (r *NotificationRepository) EnsureIndexes() error {
+	return r.mdb.CreateIndexes(r.dbName, r.collName, []db.Index{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Unique: true},
+		{Keys: bson.D{{Key: "user", Value: 1}, {Key: "key", Value: 1}}, Unique: true},
+		{Keys: bson.D{{Key: "deliverat", Value: 1}}},
+	})
+}
+
+// Insert inserts the provided Notification into the database.
+//
+// n is the Notification to insert into the database.
+func (r *NotificationRepository) Insert(n *types.Notification) error {
+	if err := r.mdb.InsertOne(r.dbName, r.collName, n); err != nil {
+		return fmt.Errorf("failed to insert notification: %v", err)
+	}
+
+	return nil
+}
+
+// GetByUserAndKey returns the Notification sent to the specified User about the event with the provided key.
+//
+// userID is the unique identifier of the User that the Notification was sent to.
+//
+// key identifies the event that the Notification was sent about.
+func (r *NotificationRepository) GetByUserAndKey(userID types.UserID, key string) (*types.Notification, error) {
+	// Define the query
+	query := bson.M{
+		"user": userID,
+		"key":  key,
+	}
+
+	// Load the Notification from the database
+	var n types.Notification
+	if err := r.mdb.GetOne(r.dbName, r.collName, query, &n); err != nil {
+		return nil, fmt.Errorf("failed to look up notification (user=%s, key=%s): %v", userID, key, err)
+	}
+
+	return &n, nil
+}
+
+// GetInAppByUser returns all Notifications delivered IN_APP to the specified User.
+//
+// userID is the unique identifier of the User whose Notifications should be loaded.
+func (r *NotificationRepository) GetInAppByUser(userID types.UserID) ([]types.Notification, error) {
+	// Define the query
+	query := bson.M{
+		"user":     userID,
+		"channels": notifications.IN_APP,
+	}
+
+	// Load the Notifications from the database
+	var notifications []types.Notification
+	if err := r.mdb.GetAll(r.dbName, r.collName, query, &notifications); err != nil {
+		return nil, fmt.Errorf("failed to look up notifications (user=%s): %v", userID, err)
+	}
+
+	return notifications, nil
+}
+
+// GetPending returns all Notifications with Channels that are pending delivery at or before the provided time.
+//
+// at is the [time.Time] up to which pending deliveries are due.
+func (r *NotificationRepository) GetPending(at time.Time) ([]types.Notification, error) {
+	// Define the query
+	query := bson.M{
+		"pending.0": bson.M{"$exists": true},
+		"deliverat": bson.M{"$lte": at},
+	}
+
+	// Load the Notifications from the database
+	var notifications []types.Notification
+	if err := r.mdb.GetAll(r.dbName, r.collName, query, &notifications); err != nil {
+		return nil, fmt.Errorf("failed to look up pending notifications: %v", err)
+	}
+
+	return notifications, nil
+}
+
+// Update updates the provided Notification in the database.
+//
+// n is the pointer to the Notification to update.
+func (r *NotificationRepository) Update(n *types.Notification) error {
+	// Define the filter query
+	filter := bson.M{"id": n.ID}
+
+	// Replace the Notification
+	if err := r.mdb.ReplaceOne(r.dbName, r.collName, filter, n); err != nil {
+		return fmt.Errorf("failed to update notification (id=%s): %v", n.ID, err)
+	}
+
+	return nil
+}
+
+// MarkRead marks Notifications sent to the specified User as read.
+//
+// userID is the unique identifier of the User whose Notifications were read.
+//
+// ids are the unique identifiers of the Notifications that were read. If empty, all of the User's Notifications are
+// marked as read.
+func (r *NotificationRepository) MarkRead(userID types.UserID, ids []types.NotificationID) error {
+	// Define the filter query and update operation
+	filter := bson.M{"user": userID}
+	if len(ids) > 0 {
+		filter["id"] = bson.M{"$in": ids}
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"read": true,
+		},
+	}
+
+	// Perform the update
+	if err := r.mdb.UpdateMany(r.dbName, r.collName, filter, update); err != nil {
+		return fmt.Errorf("failed to mark notifications read (user=%s): %v", userID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package repos
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mhs294/mulhall/internals/db"
+	"github.com/mhs294/mulhall/internals/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// OIDCStateRepository manages OIDCState records in the database.
+type OIDCStateRepository struct {
+	mdb      *db.MongoDB
+	dbName   string
+	collName string
+}
+
+// NewOIDCStateRepository creates a new OIDCStateRepository instance and returns a pointer to it.
+//
+// db is the MongoDB instance used by the OIDCStateRepository.
+func NewOIDCStateRepository(mdb *db.MongoDB) *OIDCStateRepository {
+	return &OIDCStateRepository{mdb: mdb, dbName: "mulhall", collName: "oidcStates"}
+}
+
+// TestConnection tests the connection to the MongoDB instance and returns any error that occurs.
+func (r *OIDCStateRepository) TestConnection() error {
+	return r.mdb.TestConnection(r.dbName)
+}
+
+// EnsureIndexes creates the indexes used to look up OIDCState records in the database, if they do not already exist.
+// Expired OIDCState records are deleted automatically by the database.
+func (r *OIDCStateRepository) EnsureIndexes() error {
+	expireAtDate := time.Duration(0)
+	return r.mdb.CreateIndexes(r.dbName, r.collName, []db.Index{
+		{Keys: bson.D{{Key: "state", Value: 1}}, Unique: true},
+		{Keys: bson.D{{Key: "expiration", Value: 1}}, ExpireAfter: &expireAtDate},
+	})
+}
+
+// Insert inserts the provided OIDCState into the database.
+//
+// st is the OIDCState to insert into the database.
+func (r *OIDCStateRepository) Insert(st *types.OIDCState) error {
+	if err := r.mdb.InsertOne(r.dbName, r.collName, st); err != nil {
+		return fmt.Errorf("failed to insert oidc state: %v", err)
+	}
+
+	return nil
+}
+
+// GetByState returns the OIDCState for the provided state value.
+//
+// state is the opaque state value of the OIDCState to look up.
+func (r *OIDCStateRepository) GetByState(state string) (*types.OIDCState, error) {
+	// Define the query
+	query := bson.M{"state": state}
+
+	// Load OIDCState from the database
+	var st types.OIDCState
+	if err := r.mdb.GetOne(r.dbName, r.collName, query, &st); err != nil {
+		return nil, fmt.Errorf("failed to look up oidc state: %v", err)
+	}
+
+	return &st, nil
+}
+
+// Delete deletes the OIDCState for the provided state value, along with any OIDCStates that have expired.
+//
+// state is the opaque state value of the OIDCState to delete.
+func (r *OIDCStateRepository) Delete(state string) error {
+	// Define the filter query
+	filter := bson.M{
+		"$or": []bson.M{
+			{"state": state},
+			{"expiration": bson.M{"$lt": time.Now().UTC()}},
+		},
+	}
+
+	// Perform the delete
+	if err := r.mdb.DeleteMany(r.dbName, r.collName, filter); err != nil {
+		return fmt.Errorf("failed to delete oidc state: %v", err)
+	}
+
+	return nil
+}
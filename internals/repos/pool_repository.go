@@ -19,7 +19,7 @@ type PoolRepository struct {
 //
 // mdb is the MongoDB instance used by the PoolRepository.
 func NewPoolRepository(mdb *db.MongoDB) *PoolRepository {
-	return &PoolRepository{mdb: mdb, dbName: "mulhall", collName: "contestants"}
+	return &PoolRepository{mdb: mdb, dbName: "mulhall", collName: "pools"}
 }
 
 // TestConnection tests the connection to the MongoDB instance and returns any error that occurs.
@@ -27,6 +27,13 @@ func (r *PoolRepository) TestConnection() error {
 	return r.mdb.TestConnection(r.dbName)
 }
 
+// EnsureIndexes creates the indexes used to look up Pool records in the database, if they do not already exist.
+func (r *PoolRepository) EnsureIndexes() error {
+	return r.mdb.CreateIndexes(r.dbName, r.collName, []db.Index{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Unique: true},
+	})
+}
+
 // Insert inserts the provided Pool into the database.
 //
 // p is the Pool to insert into the database.
@@ -40,8 +47,12 @@ func (r *PoolRepository) Insert(p *types.Pool) error {
 
 // GetAll loads all active Pools from the database.
 func (r *PoolRepository) GetAll() ([]types.Pool, error) {
+	// Define the query
+	query := bson.M{"active": true}
+
+	// Load Pools from the database
 	var pools []types.Pool
-	if err := r.mdb.GetAll(r.dbName, r.collName, bson.M{}, &pools); err != nil {
+	if err := r.mdb.GetAll(r.dbName, r.collName, query, &pools); err != nil {
 		return nil, fmt.Errorf("failed to load pools from database: %v", err)
 	}
 
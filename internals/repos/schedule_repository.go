@@ -28,6 +28,14 @@ func (r *ScheduleRepository) TestConnection() error {
 	return r.mdb.TestConnection(r.dbName)
 }
 
+// EnsureIndexes creates the indexes used to look up Schedule records in the database, if they do not already exist.
+func (r *ScheduleRepository) EnsureIndexes() error {
+	return r.mdb.CreateIndexes(r.dbName, r.collName, []db.Index{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Unique: true},
+		{Keys: bson.D{{Key: "year", Value: 1}, {Key: "week", Value: 1}}},
+	})
+}
+
 // Insert inserts the provided Schedule into the database.
 //
 // s is the Schedule to insert into the database.
@@ -95,6 +103,22 @@ func (r *ScheduleRepository) GetByYearAndWeek(year int, week int) (*types.Schedu
 	return &s, nil
 }
 
+// GetByYear gets all active Schedules for the provided season.
+//
+// year is the integer value of the season's starting year (e.g. - 2024 for the 2024-2025 season).
+func (r *ScheduleRepository) GetByYear(year int) ([]types.Schedule, error) {
+	// Define the query
+	query := bson.M{"year": year, "active": true}
+
+	// Load the Schedules from the database
+	var schedules []types.Schedule
+	if err := r.mdb.GetAll(r.dbName, r.collName, query, &schedules); err != nil {
+		return nil, fmt.Errorf("failed to look up schedules (year=%d): %v", year, err)
+	}
+
+	return schedules, nil
+}
+
 // Update updates a Schedule in the database using the information in the provided model.
 //
 // e is the model to use to update the Schedule in the database. The models' ScheduleID is used to
@@ -2,6 +2,7 @@ package repos
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mhs294/mulhall/internals/db"
 	"github.com/mhs294/mulhall/internals/types"
@@ -27,6 +28,17 @@ func (r *SessionRepository) TestConnection() error {
 	return r.mdb.TestConnection(r.dbName)
 }
 
+// EnsureIndexes creates the indexes used to look up Session records in the database, if they do not already exist.
+// Expired Session records are deleted automatically by the database.
+func (r *SessionRepository) EnsureIndexes() error {
+	expireAtDate := time.Duration(0)
+	return r.mdb.CreateIndexes(r.dbName, r.collName, []db.Index{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Unique: true},
+		{Keys: bson.D{{Key: "user", Value: 1}}},
+		{Keys: bson.D{{Key: "expiration", Value: 1}}, ExpireAfter: &expireAtDate},
+	})
+}
+
 // Insert inserts the provided Session into the database.
 //
 // s is the Session to insert into the database.
@@ -53,3 +65,58 @@ func (r *SessionRepository) GetByID(id types.SessionID) (*types.Session, error)
 
 	return &sess, nil
 }
+
+// GetByUser returns all unexpired Sessions belonging to the specified User.
+//
+// userID is the unique identifier of the User whose Sessions should be loaded.
+func (r *SessionRepository) GetByUser(userID types.UserID) ([]types.Session, error) {
+	// Define the query
+	query := bson.M{
+		"user":       userID,
+		"expiration": bson.M{"$gt": time.Now().UTC()},
+	}
+
+	// Load the Sessions from the database
+	var sessions []types.Session
+	if err := r.mdb.GetAll(r.dbName, r.collName, query, &sessions); err != nil {
+		return nil, fmt.Errorf("failed to look up sessions (user=%s): %v", userID, err)
+	}
+
+	return sessions, nil
+}
+
+// Delete deletes the Session with the provided ID, ending that Session immediately.
+//
+// id is the unique identifier of the Session to delete.
+func (r *SessionRepository) Delete(id types.SessionID) error {
+	// Define the filter query
+	filter := bson.M{"id": id}
+
+	// Perform the delete
+	if err := r.mdb.DeleteMany(r.dbName, r.collName, filter); err != nil {
+		return fmt.Errorf("failed to delete session (id=%s): %v", id, err)
+	}
+
+	return nil
+}
+
+// DeleteByUser deletes all Sessions belonging to the specified User, except for the Session
+// with the provided ID (if any).
+//
+// userID is the unique identifier of the User whose Sessions should be deleted.
+//
+// keep is the unique identifier of a Session that should not be deleted (or empty to delete all Sessions).
+func (r *SessionRepository) DeleteByUser(userID types.UserID, keep types.SessionID) error {
+	// Define the filter query
+	filter := bson.M{"user": userID}
+	if len(keep) > 0 {
+		filter["id"] = bson.M{"$ne": keep}
+	}
+
+	// Perform the delete
+	if err := r.mdb.DeleteMany(r.dbName, r.collName, filter); err != nil {
+		return fmt.Errorf("failed to delete sessions (user=%s): %v", userID, err)
+	}
+
+	return nil
+}
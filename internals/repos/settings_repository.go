@@ -0,0 +1,66 @@
+package repos
+
+import (
+	"fmt"
+
+	"github.com/mhs294/mulhall/internals/db"
+	"github.com/mhs294/mulhall/internals/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SettingsRepository manages the site-wide Settings record in the database.
+type SettingsRepository struct {
+	mdb      *db.MongoDB
+	dbName   string
+	collName string
+	id       string
+}
+
+// NewSettingsRepository creates a new SettingsRepository instance and returns a pointer to it.
+//
+// mdb is the MongoDB instance used by the SettingsRepository.
+func NewSettingsRepository(mdb *db.MongoDB) *SettingsRepository {
+	return &SettingsRepository{mdb: mdb, dbName: "mulhall", collName: "settings", id: "site"}
+}
+
+// TestConnection tests the connection to the MongoDB instance and returns any error that occurs.
+func (r *SettingsRepository) TestConnection() error {
+	return r.mdb.TestConnection(r.dbName)
+}
+
+// Get returns the site-wide Settings (or default Settings if none have been saved).
+func (r *SettingsRepository) Get() (*types.Settings, error) {
+	// Define the query
+	query := bson.M{"id": r.id}
+
+	// Load the Settings from the database
+	var s types.Settings
+	if err := r.mdb.GetOne(r.dbName, r.collName, query, &s); err != nil {
+		return nil, fmt.Errorf("failed to look up settings: %v", err)
+	}
+
+	return &s, nil
+}
+
+// Set updates a single site-wide setting, creating the Settings record if it does not exist yet.
+//
+// name is the name of the setting field to update (e.g. - "requireadministratormfa").
+//
+// value is the new value of the setting.
+func (r *SettingsRepository) Set(name string, value any) error {
+	// Define the filter query and update operation
+	filter := bson.M{"id": r.id}
+	update := bson.M{
+		"$set": bson.M{
+			name: value,
+		},
+	}
+
+	// Perform the upsert
+	var s types.Settings
+	if err := r.mdb.UpsertOne(r.dbName, r.collName, filter, update, &s); err != nil {
+		return fmt.Errorf("failed to update setting (name=%s): %v", name, err)
+	}
+
+	return nil
+}
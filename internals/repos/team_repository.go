@@ -30,6 +30,14 @@ func (r *TeamRepository) TestConnection() error {
 	return r.mdb.TestConnection(r.dbName)
 }
 
+// EnsureIndexes creates the indexes used to look up Team records in the database, if they do not already exist.
+func (r *TeamRepository) EnsureIndexes() error {
+	return r.mdb.CreateIndexes(r.dbName, r.collName, []db.Index{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Unique: true},
+		{Keys: bson.D{{Key: "shorthand", Value: 1}}, Unique: true},
+	})
+}
+
 // GetAll returns a slice of all available Teams, sorted by their location shorthand.
 func (r *TeamRepository) GetAll() ([]types.Team, error) {
 	if r.teams == nil {
@@ -64,6 +72,24 @@ func (r *TeamRepository) GetByID(id types.TeamID) (types.Team, error) {
 	return r.teams[id], nil
 }
 
+// Insert inserts the provided Team into the database, unless a Team with the same shorthand already exists.
+// Returns whether the Team was inserted.
+//
+// t is the Team to insert into the database.
+func (r *TeamRepository) Insert(t *types.Team) (bool, error) {
+	// Define the filter query (existing Teams are left unchanged)
+	filter := bson.M{"shorthand": t.Shorthand}
+
+	// Perform the upsert
+	inserted, err := r.mdb.InsertIfAbsent(r.dbName, r.collName, filter, t)
+	if err != nil {
+		return false, fmt.Errorf("failed to insert team (shorthand=%s): %v", t.Shorthand, err)
+	}
+	r.teams = nil
+
+	return inserted, nil
+}
+
 func (r *TeamRepository) loadTeams() error {
 	var teams []types.Team
 	if err := r.mdb.GetAll(r.dbName, r.collName, bson.M{}, &teams); err != nil {
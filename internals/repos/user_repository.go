@@ -2,6 +2,7 @@ package repos
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/mhs294/mulhall/internals/db"
 	"github.com/mhs294/mulhall/internals/types"
@@ -27,6 +28,16 @@ func (r *UserRepository) TestConnection() error {
 	return r.mdb.TestConnection(r.dbName)
 }
 
+// EnsureIndexes creates the indexes used to look up User records in the database, if they do not already exist.
+func (r *UserRepository) EnsureIndexes() error {
+	return r.mdb.CreateIndexes(r.dbName, r.collName, []db.Index{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Unique: true},
+		{Keys: bson.D{{Key: "email", Value: 1}}, Unique: true},
+		{Keys: bson.D{{Key: "pendingemail", Value: 1}}},
+		{Keys: bson.D{{Key: "feedtokenhash", Value: 1}}},
+	})
+}
+
 // Insert inserts the provided User into the database.
 //
 // u is the User to insert into the database.
@@ -69,3 +80,87 @@ func (r *UserRepository) GetByID(id types.UserID) (*types.User, error) {
 
 	return &u, nil
 }
+
+// GetByPendingEmail returns the User with a pending (unverified) change to the provided email address.
+//
+// email is the pending email address of the User to look up.
+func (r *UserRepository) GetByPendingEmail(email string) (*types.User, error) {
+	// Define the query
+	query := bson.M{"pendingemail": email}
+
+	// Load User from the database
+	var u types.User
+	if err := r.mdb.GetOne(r.dbName, r.collName, query, &u); err != nil {
+		return nil, fmt.Errorf("failed to look up user (pending email=%s): %v", email, err)
+	}
+
+	return &u, nil
+}
+
+// GetByFeedTokenHash returns the User whose calendar feed is authenticated by the token with the provided hash.
+//
+// hash is the hash of the User's feed token.
+func (r *UserRepository) GetByFeedTokenHash(hash string) (*types.User, error) {
+	// Define the query
+	query := bson.M{"feedtokenhash": hash}
+
+	// Load User from the database
+	var u types.User
+	if err := r.mdb.GetOne(r.dbName, r.collName, query, &u); err != nil {
+		return nil, fmt.Errorf("failed to look up user by feed token: %v", err)
+	}
+
+	return &u, nil
+}
+
+// GetByIDs returns all Users in the database for the specified IDs.
+//
+// ids is the slice of unique identifiers of the Users to load.
+func (r *UserRepository) GetByIDs(ids []types.UserID) ([]types.User, error) {
+	// Define the query
+	query := bson.M{"id": bson.M{"$in": ids}}
+
+	// Load the Users from the database
+	var users []types.User
+	if err := r.mdb.GetAll(r.dbName, r.collName, query, &users); err != nil {
+		return nil, fmt.Errorf("failed to look up users (ids=%v): %v", ids, err)
+	}
+
+	return users, nil
+}
+
+// Search returns all Users in the database (active or not) whose email address contains the provided text,
+// ignoring case. All Users are returned if the text is empty.
+//
+// text is the text to search User email addresses for.
+func (r *UserRepository) Search(text string) ([]types.User, error) {
+	// Define the query
+	query := bson.M{}
+	if len(text) > 0 {
+		query["email"] = bson.M{"$regex": regexp.QuoteMeta(text), "$options": "i"}
+	}
+
+	// Load the Users from the database
+	var users []types.User
+	if err := r.mdb.GetAll(r.dbName, r.collName, query, &users); err != nil {
+		return nil, fmt.Errorf("failed to search users (text=%s): %v", text, err)
+	}
+
+	return users, nil
+}
+
+// Update updates a User in the database using the information in the provided model.
+//
+// u is the model to use to update the User in the database. The models' UserID is used to
+// determine which document in the database should be replaced with the updated version.
+func (r *UserRepository) Update(u *types.User) error {
+	// Define the filter query
+	filter := bson.M{"id": u.ID}
+
+	// Perform the update
+	if err := r.mdb.ReplaceOne(r.dbName, r.collName, filter, u); err != nil {
+		return fmt.Errorf("failed to update user (id=%v): %v", u.ID, err)
+	}
+
+	return nil
+}
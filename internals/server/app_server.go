@@ -1,16 +1,52 @@
 package server
 
 import (
+	"log"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/mhs294/mulhall/internals/controllers"
 	"github.com/mhs294/mulhall/internals/ioc"
 	"github.com/mhs294/mulhall/internals/middleware"
+	"github.com/mhs294/mulhall/internals/openapi"
+	"github.com/mhs294/mulhall/internals/services"
 )
 
-// Controller is a type that has HTTP method handlers capable of being registered with the app server engine.
+// Controller is a type that has HTTP method handlers capable of being registered with the app server engine,
+// and that describes those handlers' routes for the OpenAPI document.
 type Controller interface {
 	RegisterHandlers(e *gin.Engine)
+	DescribeRoutes() []openapi.Route
+}
+
+// dependencies contains the middleware and services shared by the app server's Controllers.
+type dependencies struct {
+	logger           *log.Logger
+	doc              *openapi.Document
+	userAuth         *middleware.UserAuthMiddleware
+	userService      *services.UserService
+	userAdminService *services.UserAdminService
+	settingsService  *services.SettingsService
+	tokenService     *services.APITokenService
+	oidcService      *services.OIDCService
+	inviteService    *services.InviteService
+	conService       *services.ContestantService
+	poolService      *services.PoolService
+	standingsService *services.StandingsService
+	schService       *services.ScheduleService
+	schImportService *services.ScheduleImportService
+	entryService     *services.EntryService
+	picksService     *services.PicksService
+	calendarService  *services.CalendarService
+	liveService      *services.LiveUpdateService
+	notifService     *services.NotificationService
 }
 
+// deadlineInterval is how often the app server checks the current week's pick deadline, publishing the picks made
+// for deadlines that have passed and reminding Users of deadlines that are near.
+const deadlineInterval = time.Minute
+
 // AppServer represents the backend server responsible for serving views to the end user
 // as well as handling HTTP API requests to facilitate user workflows in the web application.
 type AppServer struct {
@@ -21,37 +57,121 @@ type AppServer struct {
 func NewAppServer() (*AppServer, error) {
 	r := initRouter()
 
-	conts := initControllers()
-	for _, c := range conts {
-		c.RegisterHandlers(r)
-	}
+	deps := initDependencies()
+	registerControllers(r, deps.doc, initControllers(deps))
 
 	return &AppServer{Router: r}, nil
 }
 
 // Start runs the application server.
 func (s *AppServer) Start() {
+	go watchDeadlines()
+
 	// Port must match EXPOSE command in Dockerfile
 	s.Router.Run("0.0.0.0:8080")
 }
 
+// watchDeadlines periodically publishes the picks made for each pick deadline or Matchup that has passed since the
+// previous check, reminds Users who have not made their picks as the pick deadline nears and delivers the
+// Notifications that were held during Users' quiet hours.
+func watchDeadlines() {
+	logger := ioc.Logger()
+	liveServ := ioc.LiveUpdateService()
+	notifServ := ioc.NotificationService()
+	dispatcher := ioc.NotificationDispatcher()
+
+	since := time.Now()
+	ticker := time.NewTicker(deadlineInterval)
+	defer ticker.Stop()
+	for until := range ticker.C {
+		if _, err := notifServ.SendReminders(until); err != nil {
+			logger.Printf("failed to send pick reminders: %v", err)
+		}
+		if _, err := notifServ.SendPublished(since, until); err != nil {
+			logger.Printf("failed to send published picks notifications: %v", err)
+		}
+		if _, err := dispatcher.DeliverPending(until); err != nil {
+			logger.Printf("failed to deliver pending notifications: %v", err)
+		}
+		if err := liveServ.PublishDeadlines(since, until); err != nil {
+			logger.Printf("failed to publish pick deadlines: %v", err)
+			continue
+		}
+		since = until
+	}
+}
+
 func initRouter() *gin.Engine {
 	r := gin.Default()
 	r.SetTrustedProxies(nil)
 
+	// Request body validation (see the "binding" tags in the types package)
+	binding.Validator = ioc.RequestValidator()
+
 	// Static content (CSS/JS)
 	r.Static("/static", "./static")
 
 	// Middleware
+	r.Use(ioc.ErrorMiddleware().HandleErrors)
 	r.Use(middleware.CORS)
+	r.Use(middleware.CSRF)
 	return r
 }
 
-func initControllers() []Controller {
-	conts := make([]Controller, 0)
-	conts = append(conts, ioc.InviteController())
-	conts = append(conts, ioc.UserController())
-	conts = append(conts, ioc.ViewController())
+// registerControllers registers each Controller's handlers with the router and adds its routes to the OpenAPI
+// document.
+func registerControllers(r *gin.Engine, doc *openapi.Document, conts []Controller) {
+	for _, c := range conts {
+		c.RegisterHandlers(r)
+		doc.AddRoutes(c.DescribeRoutes()...)
+	}
+}
+
+// initDependencies returns the dependencies of the app server's Controllers.
+func initDependencies() *dependencies {
+	return &dependencies{
+		logger:           ioc.Logger(),
+		doc:              ioc.OpenAPIDocument(),
+		userAuth:         ioc.UserAuthMiddleware(),
+		userService:      ioc.UserService(),
+		userAdminService: ioc.UserAdminService(),
+		settingsService:  ioc.SettingsService(),
+		tokenService:     ioc.APITokenService(),
+		oidcService:      ioc.OIDCService(),
+		inviteService:    ioc.InviteService(),
+		conService:       ioc.ContestantService(),
+		poolService:      ioc.PoolService(),
+		standingsService: ioc.StandingsService(),
+		schService:       ioc.ScheduleService(),
+		schImportService: ioc.ScheduleImportService(),
+		entryService:     ioc.EntryService(),
+		picksService:     ioc.PicksService(),
+		calendarService:  ioc.CalendarService(),
+		liveService:      ioc.LiveUpdateService(),
+		notifService:     ioc.NotificationService(),
+	}
+}
 
-	return conts
+// initControllers returns every Controller served by the app server, created from the provided dependencies.
+// TestOpenAPIDocumentMatchesRoutes uses the same Controllers to verify that their routes are described by the
+// OpenAPI document.
+func initControllers(d *dependencies) []Controller {
+	return []Controller{
+		controllers.NewInviteController(d.logger, d.userAuth, d.inviteService),
+		controllers.NewUserController(d.logger, d.userAuth, d.userService),
+		controllers.NewViewController(d.userAuth, d.userService, d.oidcService, d.userAdminService, d.picksService,
+			d.inviteService, d.poolService, d.standingsService, d.schService, d.notifService),
+		controllers.NewAdminController(d.logger, d.userAuth, d.settingsService, d.userAdminService),
+		controllers.NewTokenController(d.logger, d.userAuth, d.tokenService),
+		controllers.NewOIDCController(d.logger, d.oidcService),
+		controllers.NewContestantController(d.logger, d.userAuth, d.conService, d.liveService, d.notifService),
+		controllers.NewPoolController(d.logger, d.userAuth, d.poolService, d.conService, d.standingsService,
+			d.liveService, d.notifService),
+		controllers.NewScheduleController(d.logger, d.userAuth, d.schService, d.schImportService, d.entryService,
+			d.liveService, d.notifService),
+		controllers.NewEntryController(d.logger, d.userAuth, d.entryService, d.picksService, d.liveService),
+		controllers.NewCalendarController(d.logger, d.userAuth, d.calendarService),
+		controllers.NewNotificationController(d.logger, d.userAuth, d.notifService),
+		controllers.NewDocsController(d.doc),
+	}
 }
@@ -0,0 +1,25 @@
+package server
+
+import (
+	"log"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/openapi"
+)
+
+// TestOpenAPIDocumentMatchesRoutes verifies that every route registered by the app server's Controllers is described
+// by the OpenAPI document, and that the document describes no routes that are not registered. Registering routes
+// does not use the Controllers' middleware or services, so they are left nil.
+func TestOpenAPIDocumentMatchesRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Static("/static", "./static")
+
+	deps := &dependencies{logger: log.Default(), doc: openapi.NewDocument("Mulhall API", "1.0.0")}
+	registerControllers(r, deps.doc, initControllers(deps))
+
+	if err := deps.doc.Verify(r.Routes(), "/static/"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mhs294/mulhall/internals/repos"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/scopes"
+	"github.com/mhs294/mulhall/internals/utils"
+)
+
+// apiTokenPrefix is prepended to every APIToken secret so that leaked tokens are easy to recognize.
+const apiTokenPrefix = "mlh_"
+
+// APITokenService represents a service for managing Users' personal APITokens.
+type APITokenService struct {
+	repo *repos.APITokenRepository
+}
+
+// NewAPITokenService creates a new instance of an APITokenService and returns a pointer to it.
+//
+// r is the APITokenRepository that will be used to manage APIToken records in the database.
+func NewAPITokenService(r *repos.APITokenRepository) *APITokenService {
+	return &APITokenService{repo: r}
+}
+
+// Create creates a new APIToken for the specified User and returns it along with its secret value.
+// Only a hash of the secret is stored, so the secret cannot be retrieved again later.
+// Returns APITokenRequestInvalidError if the request is missing a name, has unknown scopes or has
+// already expired.
+//
+// userID is the unique identifier of the User the APIToken will authenticate as.
+//
+// req is the CreateAPITokenRequest containing the name, scopes and optional expiration of the APIToken.
+func (s *APITokenService) Create(userID types.UserID, req *types.CreateAPITokenRequest) (*types.CreateAPITokenResponse, error) {
+	// Validate the request
+	now := time.Now().UTC()
+	if len(strings.TrimSpace(req.Name)) == 0 {
+		return nil, &types.APITokenRequestInvalidError{Reason: "name is required"}
+	}
+	if len(req.Scopes) == 0 {
+		return nil, &types.APITokenRequestInvalidError{Reason: "at least one scope is required"}
+	}
+	for _, sc := range req.Scopes {
+		switch sc {
+		case scopes.READ, scopes.PICKS_WRITE, scopes.RESULTS_WRITE:
+		default:
+			return nil, &types.APITokenRequestInvalidError{Reason: fmt.Sprintf("unknown scope %s", sc)}
+		}
+	}
+	var expiration time.Time
+	if req.Expiration != nil {
+		if req.Expiration.Before(now) {
+			return nil, &types.APITokenRequestInvalidError{Reason: "expiration is in the past"}
+		}
+		expiration = req.Expiration.UTC()
+	}
+
+	// Create the APIToken with a randomly generated secret
+	token, err := utils.CreateSecureAlphaNumToken(40)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api token: %v", err)
+	}
+	secret := apiTokenPrefix + token
+	t := &types.APIToken{
+		ID:         types.APITokenID(uuid.NewString()),
+		User:       userID,
+		Name:       req.Name,
+		Prefix:     secret[:len(apiTokenPrefix)+6],
+		Hash:       hashAPIToken(secret),
+		Scopes:     req.Scopes,
+		Created:    now,
+		Expiration: expiration,
+		Revoked:    false,
+	}
+
+	// Insert the APIToken into the database
+	if err = s.repo.Insert(t); err != nil {
+		return nil, fmt.Errorf("failed to create api token: %v", err)
+	}
+
+	return &types.CreateAPITokenResponse{Token: t, Secret: secret}, nil
+}
+
+// GetByUser returns all unrevoked APITokens belonging to the specified User.
+//
+// userID is the unique identifier of the User whose APITokens should be loaded.
+func (s *APITokenService) GetByUser(userID types.UserID) ([]types.APIToken, error) {
+	return s.repo.GetByUser(userID)
+}
+
+// Revoke revokes one of the specified User's APITokens.
+// Returns APITokenNotFoundError if no such APIToken exists for the User.
+//
+// userID is the unique identifier of the User that owns the APIToken.
+//
+// id is the unique identifier of the APIToken to revoke.
+func (s *APITokenService) Revoke(userID types.UserID, id types.APITokenID) error {
+	// Load the APIToken from the database
+	t, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// Verify that the APIToken exists and belongs to the User
+	if t == nil || t.User != userID {
+		return &types.APITokenNotFoundError{ID: id}
+	}
+
+	return s.repo.Revoke(id)
+}
+
+// Authenticate returns the APIToken for the provided secret if it is valid.
+// Returns APITokenInvalidError if the secret is unknown, or the APIToken has been revoked or has expired.
+//
+// secret is the APIToken secret presented by the client.
+func (s *APITokenService) Authenticate(secret string) (*types.APIToken, error) {
+	if !strings.HasPrefix(secret, apiTokenPrefix) {
+		return nil, &types.APITokenInvalidError{}
+	}
+
+	// Look up the APIToken by the hash of its secret
+	t, err := s.repo.GetByHash(hashAPIToken(secret))
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify that the APIToken exists and has not expired
+	now := time.Now().UTC()
+	if t == nil || len(t.ID) == 0 {
+		return nil, &types.APITokenInvalidError{}
+	}
+	if !t.Expiration.IsZero() && t.Expiration.Before(now) {
+		return nil, &types.APITokenInvalidError{}
+	}
+
+	if err = s.repo.SetLastUsed(t.ID, now); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// hashAPIToken hashes an APIToken secret for storage. APIToken secrets are long and uniformly random,
+// so a fast unsalted hash is sufficient (unlike User passwords).
+func hashAPIToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mhs294/mulhall/internals/repos"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/audit"
+)
+
+// AuditService represents a service for recording AuditEvents.
+type AuditService struct {
+	repo *repos.AuditRepository
+}
+
+// NewAuditService creates a new instance of an AuditService and returns a pointer to it.
+//
+// r is the AuditRepository that will be used to manage AuditEvent records in the database.
+func NewAuditService(r *repos.AuditRepository) *AuditService {
+	return &AuditService{repo: r}
+}
+
+// Record records a new AuditEvent.
+//
+// userID is the unique identifier of the User responsible for the event (or empty if unknown).
+//
+// action is the Action that occurred.
+//
+// resource is the type of Resource the Action occurred against.
+//
+// details is the set of additional key/value details describing the event.
+func (s *AuditService) Record(userID types.UserID, action audit.Action, resource audit.Resource, details map[string]string) error {
+	e := &types.AuditEvent{
+		ID:        types.AuditEventID(uuid.NewString()),
+		User:      userID,
+		Action:    action,
+		Resource:  resource,
+		Details:   details,
+		Timestamp: time.Now().UTC(),
+	}
+
+	return s.repo.Insert(e)
+}
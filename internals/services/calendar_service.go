@@ -0,0 +1,189 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mhs294/mulhall/internals/ical"
+	"github.com/mhs294/mulhall/internals/repos"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/utils"
+)
+
+const (
+	// feedTokenLength is the length of the secret token that authenticates a User's calendar feed.
+	feedTokenLength = 40
+
+	// matchupDuration is the approximate length of a Matchup, used as the duration of its calendar event.
+	matchupDuration = 3*time.Hour + 30*time.Minute
+)
+
+// CalendarService represents a service for describing each User's pick deadlines and picked Matchups as a
+// subscribable calendar feed.
+type CalendarService struct {
+	userRepo     *repos.UserRepository
+	teamRepo     *repos.TeamRepository
+	conService   *ContestantService
+	schService   *ScheduleService
+	picksService *PicksService
+}
+
+// NewCalendarService creates a new instance of a CalendarService and returns a pointer to it.
+//
+// ur is the UserRepository used to manage each User's feed token.
+//
+// tr is the TeamRepository used to describe the Teams in each picked Matchup.
+//
+// cs is the ContestantService used to load the Contestants that each User is authorized for.
+//
+// ss is the ScheduleService used to load the season's Schedules and their pick deadlines.
+//
+// pks is the PicksService used to load each Contestant's picks for the season.
+func NewCalendarService(ur *repos.UserRepository, tr *repos.TeamRepository, cs *ContestantService, ss *ScheduleService, pks *PicksService) *CalendarService {
+	return &CalendarService{userRepo: ur, teamRepo: tr, conService: cs, schService: ss, picksService: pks}
+}
+
+// ResetFeedToken creates a new feed token for the User's calendar feed and returns it, which stops the User's
+// previous feed token (if any) from working. Only a hash of the token is stored, so the token cannot be retrieved
+// again later.
+// Returns UserNotFoundError if no such User exists or the User has been deactivated.
+//
+// userID is the unique identifier of the User whose feed token will be reset.
+func (s *CalendarService) ResetFeedToken(userID types.UserID) (string, error) {
+	// Load the User
+	u, err := s.getUser(userID)
+	if err != nil {
+		return "", err
+	}
+
+	// Replace the User's feed token with a randomly generated one
+	token, err := utils.CreateSecureAlphaNumToken(feedTokenLength)
+	if err != nil {
+		return "", fmt.Errorf("failed to create feed token: %v", err)
+	}
+	u.FeedTokenHash = hashAPIToken(token)
+	if err := s.userRepo.Update(u); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// RevokeFeedToken disables the User's calendar feed.
+// Returns UserNotFoundError if no such User exists or the User has been deactivated.
+//
+// userID is the unique identifier of the User whose feed token will be revoked.
+func (s *CalendarService) RevokeFeedToken(userID types.UserID) error {
+	// Load the User
+	u, err := s.getUser(userID)
+	if err != nil {
+		return err
+	}
+
+	// Remove the User's feed token
+	u.FeedTokenHash = ""
+
+	return s.userRepo.Update(u)
+}
+
+// GetFeed describes the current season's pick deadlines and the Matchups picked by each of the User's Contestants
+// as a calendar, for the User authenticated by the provided feed token.
+// Returns FeedTokenInvalidError if the feed token does not belong to an active User.
+//
+// token is the secret feed token of the User whose calendar will be described.
+func (s *CalendarService) GetFeed(token string) (*ical.Calendar, error) {
+	// Look up the User that the feed token belongs to
+	if len(token) == 0 {
+		return nil, &types.FeedTokenInvalidError{}
+	}
+	u, err := s.userRepo.GetByFeedTokenHash(hashAPIToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if u == nil || len(u.ID) == 0 || !u.Active {
+		return nil, &types.FeedTokenInvalidError{}
+	}
+
+	// Load the current season's Schedules
+	year, err := s.picksService.GetCurrentSeason()
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := s.schService.GetByYear(year)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[types.ScheduleID]types.Schedule, len(schedules))
+	cal := &ical.Calendar{Name: "Mulhall", Events: make([]ical.Event, 0, len(schedules))}
+
+	// Add an event for each week's pick deadline
+	for _, sch := range schedules {
+		byID[sch.ID] = sch
+		cal.Events = append(cal.Events, ical.Event{
+			UID:         fmt.Sprintf("closes-%s@mulhall", sch.ID),
+			Summary:     fmt.Sprintf("Week %d picks close", sch.Week),
+			Description: fmt.Sprintf("Picks for week %d of the %d season must be made by this time.", sch.Week, sch.Year),
+			Categories:  []string{"Deadline"},
+			Start:       sch.Closes,
+			End:         sch.Closes,
+		})
+	}
+
+	// Add an event for the kickoff of each Matchup picked by the User's Contestants
+	cons, err := s.conService.GetByAuthorizedUser(u.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cons {
+		weeks, _, err := s.picksService.GetSeasonHistory(c.ID, year)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range weeks {
+			if w.Pick == nil {
+				continue
+			}
+			m, exists := byID[w.Schedule].Matchups[w.Pick.Matchup]
+			if !exists {
+				continue
+			}
+			away, err := s.teamRepo.GetByID(m.AwayTeam)
+			if err != nil {
+				return nil, err
+			}
+			home, err := s.teamRepo.GetByID(m.HomeTeam)
+			if err != nil {
+				return nil, err
+			}
+			cal.Events = append(cal.Events, ical.Event{
+				UID:         fmt.Sprintf("pick-%s-%s@mulhall", c.ID, w.Schedule),
+				Summary:     fmt.Sprintf("%s @ %s (%s picked %s)", away.Shorthand, home.Shorthand, c.Name, w.Pick.Team.Shorthand),
+				Description: fmt.Sprintf("Week %d: %s picked the %s %s.", w.Week, c.Name, w.Pick.Team.Location, w.Pick.Team.Name),
+				Categories:  []string{"Pick"},
+				Start:       m.DateTime,
+				End:         m.DateTime.Add(matchupDuration),
+			})
+		}
+	}
+
+	// Sort the events chronologically
+	sort.SliceStable(cal.Events, func(i, j int) bool {
+		return cal.Events[i].Start.Before(cal.Events[j].Start)
+	})
+
+	return cal, nil
+}
+
+// getUser returns the active User with the provided ID.
+func (s *CalendarService) getUser(userID types.UserID) (*types.User, error) {
+	u, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil || !u.Active {
+		return nil, &types.UserNotFoundError{ID: userID}
+	}
+
+	return u, nil
+}
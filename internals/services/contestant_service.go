@@ -11,6 +11,7 @@ import (
 // ContestantService represents a service for managing Contestants and their authorized Users.
 type ContestantService struct {
 	repo        *repos.ContestantRepository
+	userRepo    *repos.UserRepository
 	poolService *PoolService
 }
 
@@ -18,9 +19,11 @@ type ContestantService struct {
 //
 // r is the ContestantRepository that will be used to manage Contestant records in the database.
 //
+// ur is the UserRepository used to look up Users when authorizing them for a Pool.
+//
 // ps is the PoolService that will be used to load Contestant information for specific Pools.
-func NewContestantService(r *repos.ContestantRepository, ps *PoolService) *ContestantService {
-	return &ContestantService{repo: r, poolService: ps}
+func NewContestantService(r *repos.ContestantRepository, ur *repos.UserRepository, ps *PoolService) *ContestantService {
+	return &ContestantService{repo: r, userRepo: ur, poolService: ps}
 }
 
 // GetByPool returns all active Contestants for the specified Pool.
@@ -33,6 +36,28 @@ func (s *ContestantService) GetByPool(poolID types.PoolID) ([]types.Contestant,
 		return nil, err
 	}
 
+	return s.getByPool(p)
+}
+
+// GetByPoolAsMember returns all active Contestants for the specified Pool on behalf of a User who is either an
+// Administrator or authorized for one of the Pool's Contestants.
+// Returns PoolNotFoundError if no such Pool exists or that Pool has been deactivated.
+// Returns PoolForbiddenError if the User is not a member of the Pool.
+//
+// userID is the unique identifier of the User requesting the Contestants.
+//
+// poolID is the unique identifier of the Pool to load Contestants for.
+func (s *ContestantService) GetByPoolAsMember(userID types.UserID, poolID types.PoolID) ([]types.Contestant, error) {
+	p, err := s.GetPoolAsMember(userID, poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getByPool(p)
+}
+
+// getByPool loads the active Contestants in the provided Pool.
+func (s *ContestantService) getByPool(p *types.Pool) ([]types.Contestant, error) {
 	// Load Contestants from database using Contestant IDs from Pool
 	i := 0
 	conIDs := make([]types.ContestantID, len(p.Contestants))
@@ -48,6 +73,120 @@ func (s *ContestantService) GetByPool(poolID types.PoolID) ([]types.Contestant,
 	return cons, nil
 }
 
+// GetByID gets the Contestant for the provided ID.
+// Returns ContestantNotFoundError if no such Contestant exists or that Contestant has been deactivated.
+//
+// id is the unique identifier of the Contestant to look up.
+func (s *ContestantService) GetByID(id types.ContestantID) (*types.Contestant, error) {
+	// Load the Contestant from the database
+	c, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify that the Contestant exists and is active
+	if c == nil || !c.Active {
+		return nil, &types.ContestantNotFoundError{ID: id}
+	}
+
+	return c, nil
+}
+
+// GetAsMember gets the Contestant for the provided ID on behalf of one of its authorized Users.
+// Returns ContestantNotFoundError if no such Contestant exists.
+// Returns ContestantForbiddenError if the User is not authorized for the Contestant.
+//
+// userID is the unique identifier of the User requesting the Contestant.
+//
+// conID is the unique identifier of the Contestant to look up.
+func (s *ContestantService) GetAsMember(userID types.UserID, conID types.ContestantID) (*types.Contestant, error) {
+	c, err := s.GetByID(conID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify that the User is authorized for the Contestant
+	if _, ok := c.AuthorizedUsers[userID]; !ok {
+		return nil, &types.ContestantForbiddenError{ContestantID: conID, UserID: userID}
+	}
+
+	return c, nil
+}
+
+// GetPoolAsMember gets the Pool for the provided ID on behalf of a User who is either an Administrator or
+// authorized for one of the Pool's Contestants.
+// Returns PoolNotFoundError if no such Pool exists or that Pool has been deactivated.
+// Returns PoolForbiddenError if the User is not a member of the Pool.
+//
+// userID is the unique identifier of the User requesting the Pool.
+//
+// poolID is the unique identifier of the Pool to look up.
+func (s *ContestantService) GetPoolAsMember(userID types.UserID, poolID types.PoolID) (*types.Pool, error) {
+	p, err := s.poolService.GetByID(poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Administrators are members of every Pool
+	admin, err := s.isAdministrator(userID)
+	if err != nil {
+		return nil, err
+	}
+	if admin {
+		return p, nil
+	}
+
+	// Verify that the User is authorized for a Contestant in the Pool
+	cons, err := s.repo.GetByAuthorizedUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cons {
+		if _, ok := p.Contestants[c.ID]; ok {
+			return p, nil
+		}
+	}
+
+	return nil, &types.PoolForbiddenError{PoolID: poolID, UserID: userID}
+}
+
+// GetPoolsAsMember returns all active Pools that the User is a member of (i.e. - that contain a Contestant the User
+// is authorized for), or all active Pools if the User is an Administrator.
+//
+// userID is the unique identifier of the User requesting the Pools.
+func (s *ContestantService) GetPoolsAsMember(userID types.UserID) ([]types.Pool, error) {
+	pools, err := s.poolService.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Administrators are members of every Pool
+	admin, err := s.isAdministrator(userID)
+	if err != nil {
+		return nil, err
+	}
+	if admin {
+		return pools, nil
+	}
+
+	// Keep only the Pools containing a Contestant the User is authorized for
+	cons, err := s.repo.GetByAuthorizedUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	member := make([]types.Pool, 0, len(pools))
+	for _, p := range pools {
+		for _, c := range cons {
+			if _, ok := p.Contestants[c.ID]; ok {
+				member = append(member, p)
+				break
+			}
+		}
+	}
+
+	return member, nil
+}
+
 // GetByAuthorizedUser returns all active Contestants for which the specified User is authorized.
 //
 // userID is the unique identifier of the authorized User to load Contestants for.
@@ -57,9 +196,25 @@ func (s *ContestantService) GetByAuthorizedUser(userID types.UserID) ([]types.Co
 
 // Create creates a new Contestant from the provided information.
 // Returns an updated version of the Contestant model containing its ID after creation.
+// Returns RoleInvalidError if any authorized User is assigned an unknown Role.
+// Returns ContestantOwnerRequiredError if no active authorized User is an OWNER.
 //
 // req is the CreateContestantRequest containing the information required to create the Contestant.
 func (s *ContestantService) Create(req *types.CreateContestantRequest) (*types.Contestant, error) {
+	// Validate the request
+	for _, role := range req.AuthorizedUsers {
+		if err := validateRole(role); err != nil {
+			return nil, err
+		}
+	}
+	owners, err := s.countActiveOwners(req.AuthorizedUsers, "")
+	if err != nil {
+		return nil, err
+	}
+	if owners == 0 {
+		return nil, &types.ContestantOwnerRequiredError{}
+	}
+
 	c := &types.Contestant{
 		ID:              types.ContestantID(uuid.NewString()),
 		Name:            req.Name,
@@ -77,6 +232,8 @@ func (s *ContestantService) Create(req *types.CreateContestantRequest) (*types.C
 
 // SetAuthorizedUser sets the specified User to be authorized for the Contestant with the specified Role.
 // Returns ContestantNotFoundError if no such Contestant exists.
+// Returns RoleInvalidError if the Role is unknown.
+// Returns ContestantOwnerRequiredError if the change would demote the Contestant's last active OWNER.
 //
 // conID is the unique identifier of the Contestant to update.
 //
@@ -95,6 +252,16 @@ func (s *ContestantService) SetAuthorizedUser(conID types.ContestantID, userID t
 		return &types.ContestantNotFoundError{ID: conID}
 	}
 
+	// Validate the change
+	if err = validateRole(role); err != nil {
+		return err
+	}
+	if c.AuthorizedUsers[userID] == roles.OWNER && role != roles.OWNER {
+		if err = s.verifyOtherOwner(c, userID); err != nil {
+			return err
+		}
+	}
+
 	// Update the authorized User's Role for the Contestant
 	c.AuthorizedUsers[userID] = role
 	if err = s.repo.Update(c); err != nil {
@@ -106,6 +273,7 @@ func (s *ContestantService) SetAuthorizedUser(conID types.ContestantID, userID t
 
 // RemoveAuthorizedUser removed the specified User from the list of authorized Users for the Contestant.
 // Returns ContestantNotFoundError if no such Contestant exists.
+// Returns ContestantOwnerRequiredError if the User is the Contestant's last active OWNER.
 //
 // conID is the unique identifier of the Contestant to update.
 //
@@ -122,6 +290,13 @@ func (s *ContestantService) RemoveAuthorizedUser(conID types.ContestantID, userI
 		return &types.ContestantNotFoundError{ID: conID}
 	}
 
+	// Verify that the Contestant will still have an active OWNER
+	if c.AuthorizedUsers[userID] == roles.OWNER {
+		if err = s.verifyOtherOwner(c, userID); err != nil {
+			return err
+		}
+	}
+
 	// Remove the authorized User from the Contestant
 	delete(c.AuthorizedUsers, userID)
 	if err = s.repo.Update(c); err != nil {
@@ -131,31 +306,181 @@ func (s *ContestantService) RemoveAuthorizedUser(conID types.ContestantID, userI
 	return nil
 }
 
-// SetStatus updates the Status of the specified Contestant.
+// ChangeMemberRole promotes or demotes a MANAGER/VIEWER of the Contestant on behalf of one of its OWNERs.
+// Ownership can only be given away with TransferOwnership.
 // Returns ContestantNotFoundError if no such Contestant exists.
+// Returns ContestantForbiddenError if the acting User is not an OWNER of the Contestant.
+// Returns ContestantMemberNotFoundError if the target User is not a MANAGER or VIEWER of the Contestant.
+// Returns RoleInvalidError if the new Role is not MANAGER or VIEWER.
 //
-// id is the unique identifier of the Contestant to update.
+// actorID is the unique identifier of the OWNER making the change.
 //
-// status is the new Status that will be applied to the Contestant.
-func (s *ContestantService) SetStatus(id types.ContestantID, status status.Status) error {
+// conID is the unique identifier of the Contestant to update.
+//
+// userID is the unique identifier of the member whose Role is being changed.
+//
+// role is the member's new Role.
+func (s *ContestantService) ChangeMemberRole(actorID types.UserID, conID types.ContestantID, userID types.UserID, role roles.Role) error {
+	c, err := s.getAsOwner(actorID, conID)
+	if err != nil {
+		return err
+	}
+
+	// Validate the change
+	switch c.AuthorizedUsers[userID] {
+	case roles.MANAGER, roles.VIEWER:
+	default:
+		return &types.ContestantMemberNotFoundError{ContestantID: conID, UserID: userID}
+	}
+	if role != roles.MANAGER && role != roles.VIEWER {
+		return &types.RoleInvalidError{Role: role}
+	}
+
+	// Update the member's Role
+	c.AuthorizedUsers[userID] = role
+	return s.repo.Update(c)
+}
+
+// TransferOwnership makes another member of the Contestant an OWNER in place of the acting OWNER, who
+// becomes a MANAGER.
+// Returns ContestantNotFoundError if no such Contestant exists.
+// Returns ContestantForbiddenError if the acting User is not an OWNER of the Contestant.
+// Returns ContestantMemberNotFoundError if the target User is not a member of the Contestant.
+// Returns ContestantOwnerRequiredError if the Contestant would be left without an active OWNER.
+//
+// actorID is the unique identifier of the OWNER giving away ownership.
+//
+// conID is the unique identifier of the Contestant to update.
+//
+// userID is the unique identifier of the member receiving ownership.
+func (s *ContestantService) TransferOwnership(actorID types.UserID, conID types.ContestantID, userID types.UserID) error {
+	c, err := s.getAsOwner(actorID, conID)
+	if err != nil {
+		return err
+	}
+
+	// Validate the change
+	if _, ok := c.AuthorizedUsers[userID]; !ok || userID == actorID {
+		return &types.ContestantMemberNotFoundError{ContestantID: conID, UserID: userID}
+	}
+
+	// Swap the Roles of the two members, so long as an active OWNER remains
+	c.AuthorizedUsers[userID] = roles.OWNER
+	if err = s.verifyOtherOwner(c, actorID); err != nil {
+		return err
+	}
+	c.AuthorizedUsers[actorID] = roles.MANAGER
+	return s.repo.Update(c)
+}
+
+// Leave removes the acting User from the Contestant's authorized Users.
+// Returns ContestantNotFoundError if no such Contestant exists.
+// Returns ContestantMemberNotFoundError if the User is not a member of the Contestant.
+// Returns ContestantOwnerRequiredError if the User is the Contestant's last active OWNER.
+//
+// userID is the unique identifier of the User leaving the Contestant.
+//
+// conID is the unique identifier of the Contestant to leave.
+func (s *ContestantService) Leave(userID types.UserID, conID types.ContestantID) error {
 	// Load the Contestant from the database
-	c, err := s.repo.GetByID(id)
+	c, err := s.repo.GetByID(conID)
 	if err != nil {
 		return err
 	}
 
-	// Verify that the Contestant exists and is active
+	// Verify that the Contestant exists and the User is a member
 	if c == nil || !c.Active {
-		return &types.ContestantNotFoundError{ID: id}
+		return &types.ContestantNotFoundError{ID: conID}
+	}
+	if _, ok := c.AuthorizedUsers[userID]; !ok {
+		return &types.ContestantMemberNotFoundError{ContestantID: conID, UserID: userID}
+	}
+
+	return s.RemoveAuthorizedUser(conID, userID)
+}
+
+// SetStatus updates the Status of the specified Contestant on behalf of an Administrator, which takes precedence
+// over any Elimination by a Matchup result. Indicates whether the Status changed.
+// Returns ContestantNotFoundError if no such Contestant exists.
+// Returns StatusInvalidError if the Status is unknown.
+//
+// id is the unique identifier of the Contestant to update.
+//
+// status is the new Status that will be applied to the Contestant.
+func (s *ContestantService) SetStatus(id types.ContestantID, status status.Status) (bool, error) {
+	// Load the Contestant from the database
+	c, err := s.GetByID(id)
+	if err != nil {
+		return false, err
+	}
+
+	// Validate the change
+	if err = validateStatus(status); err != nil {
+		return false, err
 	}
 
 	// Update the Contestant's Status
+	changed := c.Status != status
 	c.Status = status
+	c.Elimination = nil
 	if err = s.repo.Update(c); err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	return changed, nil
+}
+
+// Eliminate marks an ACTIVE Contestant as ELIMINATED by the result of a Matchup. Contestants that are not ACTIVE are
+// left unchanged. Indicates whether the Contestant was eliminated.
+// Returns ContestantNotFoundError if no such Contestant exists.
+//
+// id is the unique identifier of the Contestant to eliminate.
+//
+// el is the pointer to the Elimination describing the Matchup whose result eliminated the Contestant.
+func (s *ContestantService) Eliminate(id types.ContestantID, el *types.Elimination) (bool, error) {
+	c, err := s.GetByID(id)
+	if err != nil {
+		return false, err
+	}
+	if c.Status != status.ACTIVE {
+		return false, nil
+	}
+
+	c.Status = status.ELIMINATED
+	c.Elimination = el
+	if err = s.repo.Update(c); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Reinstate reverses the Elimination of a Contestant by the result of a Matchup (e.g. - after the result is
+// corrected), making it ACTIVE again. Contestants that were not ELIMINATED by that Matchup are left unchanged.
+// Indicates whether the Contestant was reinstated.
+// Returns ContestantNotFoundError if no such Contestant exists.
+//
+// id is the unique identifier of the Contestant to reinstate.
+//
+// sch is the unique identifier of the Schedule containing the Matchup.
+//
+// mID is the unique identifier of the Matchup whose result no longer eliminates the Contestant.
+func (s *ContestantService) Reinstate(id types.ContestantID, sch types.ScheduleID, mID types.MatchupID) (bool, error) {
+	c, err := s.GetByID(id)
+	if err != nil {
+		return false, err
+	}
+	if c.Status != status.ELIMINATED || c.Elimination == nil || c.Elimination.Schedule != sch || c.Elimination.Matchup != mID {
+		return false, nil
+	}
+
+	c.Status = status.ACTIVE
+	c.Elimination = nil
+	if err = s.repo.Update(c); err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
 // Deactivate deactivates the specified Contestant (soft-delete).
@@ -164,3 +489,94 @@ func (s *ContestantService) SetStatus(id types.ContestantID, status status.Statu
 func (s *ContestantService) Deactivate(id types.ContestantID) error {
 	return s.repo.Deactivate(id)
 }
+
+// getAsOwner loads the specified Contestant, verifying that the acting User is one of its OWNERs.
+// isAdministrator returns whether the User is an active Administrator.
+func (s *ContestantService) isAdministrator(userID types.UserID) (bool, error) {
+	u, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	return u != nil && u.Active && u.Administrator, nil
+}
+
+func (s *ContestantService) getAsOwner(actorID types.UserID, conID types.ContestantID) (*types.Contestant, error) {
+	// Load the Contestant from the database
+	c, err := s.repo.GetByID(conID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify that the Contestant exists and is active
+	if c == nil || !c.Active {
+		return nil, &types.ContestantNotFoundError{ID: conID}
+	}
+
+	// Verify that the acting User is an OWNER
+	if c.AuthorizedUsers[actorID] != roles.OWNER {
+		return nil, &types.ContestantForbiddenError{ContestantID: conID, UserID: actorID}
+	}
+
+	return c, nil
+}
+
+// verifyOtherOwner returns ContestantOwnerRequiredError if the Contestant has no active OWNER other than the
+// specified User.
+func (s *ContestantService) verifyOtherOwner(c *types.Contestant, userID types.UserID) error {
+	owners, err := s.countActiveOwners(c.AuthorizedUsers, userID)
+	if err != nil {
+		return err
+	}
+	if owners == 0 {
+		return &types.ContestantOwnerRequiredError{ContestantID: c.ID, UserID: userID}
+	}
+
+	return nil
+}
+
+// countActiveOwners returns the number of active authorized Users with the OWNER Role, excluding the specified User.
+// Deactivated Users cannot manage a Contestant, so they do not count as its OWNERs.
+func (s *ContestantService) countActiveOwners(authorized map[types.UserID]roles.Role, exclude types.UserID) (int, error) {
+	ids := make([]types.UserID, 0)
+	for id, role := range authorized {
+		if id != exclude && role == roles.OWNER {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	// Look up the OWNERs and count those that are active
+	users, err := s.userRepo.GetByIDs(ids)
+	if err != nil {
+		return 0, err
+	}
+	owners := 0
+	for _, u := range users {
+		if u.Active {
+			owners++
+		}
+	}
+
+	return owners, nil
+}
+
+func validateRole(role roles.Role) error {
+	switch role {
+	case roles.OWNER, roles.MANAGER, roles.VIEWER:
+		return nil
+	default:
+		return &types.RoleInvalidError{Role: role}
+	}
+}
+
+func validateStatus(st status.Status) error {
+	switch st {
+	case status.ACTIVE, status.ELIMINATED, status.DISQUALIFIED:
+		return nil
+	default:
+		return &types.StatusInvalidError{Status: st}
+	}
+}
@@ -1,29 +1,559 @@
 package services
 
-import "github.com/mhs294/mulhall/internals/repos"
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mhs294/mulhall/internals/repos"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/outcomes"
+	"github.com/mhs294/mulhall/internals/types/roles"
+	"github.com/mhs294/mulhall/internals/types/status"
+)
 
 // EntryService represents a service for managing Contestants' Entries in a Pool.
 type EntryService struct {
-	repo *repos.EntryRepository
+	repo       *repos.EntryRepository
+	conService *ContestantService
+	schService *ScheduleService
 }
 
 // NewEntryService creates a new instance of a EntryService and returns a pointer to it.
 //
 // r is the EntryRepository used to manage Entry records in the database.
-func NewEntryService(r *repos.EntryRepository) *EntryService {
-	return &EntryService{repo: r}
+//
+// cs is the ContestantService used to verify that Users are authorized to manage a Contestant's Entries.
+//
+// ss is the ScheduleService used to verify that picks are valid and made while the Schedule is open.
+func NewEntryService(r *repos.EntryRepository, cs *ContestantService, ss *ScheduleService) *EntryService {
+	return &EntryService{repo: r, conService: cs, schService: ss}
+}
+
+// Create creates a new Entry for a Contestant from the provided information.
+// Returns an updated version of the Entry model containing its ID after creation.
+// Returns ContestantNotFoundError or ScheduleNotFoundError if the Contestant or Schedule does not exist.
+// Returns ContestantForbiddenError if the acting User is not an OWNER or MANAGER of the Contestant.
+// Returns EntryConflictError if the Contestant already has an Entry for the Schedule.
+//
+// actorID is the unique identifier of the User creating the Entry.
+//
+// req is the CreateEntryRequest containing the information required to create the Entry.
+func (s *EntryService) Create(actorID types.UserID, req *types.CreateEntryRequest) (*types.Entry, error) {
+	// Verify that the acting User can manage the Contestant's picks
+	if _, err := s.getContestant(actorID, req.ContestantID, true); err != nil {
+		return nil, err
+	}
+
+	// Verify that the Schedule exists
+	if _, err := s.schService.GetByID(req.ScheduleID); err != nil {
+		return nil, err
+	}
+
+	// Determine if an Entry already exists for the Contestant and Schedule
+	existing, err := s.repo.GetByContestantAndSchedule(req.ContestantID, req.ScheduleID)
+	if err != nil {
+		return nil, err
+	} else if len(existing.ID) > 0 {
+		return nil, &types.EntryConflictError{ContestantID: req.ContestantID, ScheduleID: req.ScheduleID, ID: existing.ID}
+	}
+
+	// Create the Entry
+	e := newEntry(req.ContestantID, req.ScheduleID)
+	e.ID = types.EntryID(uuid.NewString())
+	if err = s.repo.Insert(e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// GetBySchedule gets the Contestant's Entry for the provided Schedule on behalf of one of its authorized Users.
+// If the Contestant has no Entry for the Schedule and the Schedule is open for picks, an empty Entry (without an ID)
+// is returned so that the Contestant's picks can be displayed; it is only stored once a pick is suggested or
+// Selected. Otherwise, nil is returned if no Entry exists.
+// Returns ContestantNotFoundError if no such Contestant exists.
+// Returns ContestantForbiddenError if the acting User is not authorized for the Contestant.
+//
+// actorID is the unique identifier of the User requesting the Entry.
+//
+// conID is the unique identifier of the Contestant whose Entry will be loaded.
+//
+// sch is the Schedule that the Entry was made for.
+func (s *EntryService) GetBySchedule(actorID types.UserID, conID types.ContestantID, sch *types.Schedule) (*types.Entry, error) {
+	if _, err := s.getContestant(actorID, conID, false); err != nil {
+		return nil, err
+	}
+
+	// Load the existing Entry, if there is one
+	e, err := s.repo.GetByContestantAndSchedule(conID, sch.ID)
+	if err != nil {
+		return nil, err
+	} else if len(e.ID) > 0 {
+		return e, nil
+	}
+
+	// Entries can only be started while the Schedule is open for picks
+	if !sch.IsOpen(time.Now()) {
+		return nil, nil
+	}
+
+	return newEntry(conID, sch.ID), nil
+}
+
+// GetUsedTeams gets the Teams that the Contestant has Selected in its Entries for the other Schedules of the season,
+// keyed by Team and mapped to the Schedule for which each Team was Selected.
+//
+// conID is the unique identifier of the Contestant whose Entries will be checked.
+//
+// sch is the Schedule whose season will be checked. Picks made for this Schedule are excluded.
+func (s *EntryService) GetUsedTeams(conID types.ContestantID, sch *types.Schedule) (map[types.TeamID]types.ScheduleID, error) {
+	entries, err := s.repo.GetByContestant(conID)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[types.TeamID]types.ScheduleID)
+	for _, e := range entries {
+		if e.Schedule == sch.ID || len(e.SelectedPick) == 0 {
+			continue
+		}
+
+		// Only picks made within the same season are counted
+		other, err := s.schService.GetByID(e.Schedule)
+		if err != nil {
+			if _, ok := err.(*types.ScheduleNotFoundError); ok {
+				continue
+			}
+			return nil, err
+		}
+		if other.Year != sch.Year {
+			continue
+		}
+
+		for _, teamID := range e.SelectedPick {
+			used[teamID] = e.Schedule
+		}
+	}
+
+	return used, nil
+}
+
+// GetByID gets the Entry for the provided ID on behalf of one of its Contestant's authorized Users.
+// Returns EntryNotFoundError if no such Entry exists or that Entry has been deactivated.
+// Returns ContestantForbiddenError if the acting User is not authorized for the Entry's Contestant.
+//
+// actorID is the unique identifier of the User requesting the Entry.
+//
+// id is the unique identifier of the Entry to look up.
+func (s *EntryService) GetByID(actorID types.UserID, id types.EntryID) (*types.Entry, error) {
+	e, err := s.getEntry(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify that the acting User is authorized for the Contestant
+	if _, err = s.getContestant(actorID, e.Contestant, false); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// GetByContestant gets all active Entries for the provided Contestant on behalf of one of its authorized Users.
+// Returns ContestantNotFoundError if no such Contestant exists.
+// Returns ContestantForbiddenError if the acting User is not authorized for the Contestant.
+//
+// actorID is the unique identifier of the User requesting the Entries.
+//
+// conID is the unique identifier of the Contestant whose Entries will be loaded.
+func (s *EntryService) GetByContestant(actorID types.UserID, conID types.ContestantID) ([]types.Entry, error) {
+	if _, err := s.getContestant(actorID, conID, false); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByContestant(conID)
+}
+
+// SetSelectedPick sets the Contestant's pick for the Entry's Schedule, replacing any previously Selected pick.
+// Returns EntryNotFoundError if no such Entry exists.
+// Returns ContestantNotFoundError or ScheduleNotFoundError if no Entry is specified and the Contestant or Schedule
+// does not exist.
+// Returns ContestantForbiddenError if the acting User is not an OWNER or MANAGER of the Entry's Contestant.
+// Returns ContestantNotActiveError if the Entry's Contestant is no longer ACTIVE.
+// Returns ScheduleClosedError if the Entry's Schedule is not open for picks.
+// Returns MatchupNotFoundError or PickInvalidError if the Team is not part of a Matchup on the Schedule.
+// Returns MatchupLockedError if the Matchup (or that of the previously Selected pick) has already started.
+// Returns TeamAlreadyPickedError if the Contestant has already Selected the Team for another week of the season.
+//
+// actorID is the unique identifier of the User selecting the pick.
+//
+// req is the SavePickRequest containing the Matchup and Team being picked.
+func (s *EntryService) SetSelectedPick(actorID types.UserID, req *types.SavePickRequest) (*types.Entry, error) {
+	e, err := s.getPickEntry(actorID, req, true)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.validatePick(e, req.MatchupID, req.TeamID); err != nil {
+		return nil, err
+	}
+	if err = s.verifySelectionUnlocked(e); err != nil {
+		return nil, err
+	}
+	if e, err = s.startEntry(e); err != nil {
+		return nil, err
+	}
+
+	// Replace the Selected pick
+	e.SelectedPick = map[types.MatchupID]types.TeamID{req.MatchupID: req.TeamID}
+	if err = s.repo.Update(e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// ClearSelectedPick clears the Contestant's pick for the Entry's Schedule.
+// Returns EntryNotFoundError if no such Entry exists.
+// Returns ContestantForbiddenError if the acting User is not an OWNER or MANAGER of the Entry's Contestant.
+// Returns ContestantNotActiveError if the Entry's Contestant is no longer ACTIVE.
+// Returns ScheduleClosedError if the Entry's Schedule is not open for picks.
+// Returns MatchupLockedError if the Matchup of the Selected pick has already started.
+//
+// actorID is the unique identifier of the User clearing the pick.
+//
+// id is the unique identifier of the Entry to update.
+func (s *EntryService) ClearSelectedPick(actorID types.UserID, id types.EntryID) (*types.Entry, error) {
+	e, err := s.getOpenEntry(actorID, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// If no pick has been Selected, do nothing and return
+	if len(e.SelectedPick) == 0 {
+		return e, nil
+	}
+	if err = s.verifySelectionUnlocked(e); err != nil {
+		return nil, err
+	}
+
+	// Clear the Selected pick
+	e.SelectedPick = make(map[types.MatchupID]types.TeamID, 0)
+	if err = s.repo.Update(e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// AddSuggestedPick suggests a pick for the Entry's Schedule, replacing any existing suggestion for the same Matchup.
+// Any authorized User of the Entry's Contestant may suggest a pick.
+// Returns EntryNotFoundError if no such Entry exists.
+// Returns ContestantNotFoundError or ScheduleNotFoundError if no Entry is specified and the Contestant or Schedule
+// does not exist.
+// Returns ContestantForbiddenError if the acting User is not authorized for the Entry's Contestant.
+// Returns ContestantNotActiveError if the Entry's Contestant is no longer ACTIVE.
+// Returns ScheduleClosedError if the Entry's Schedule is not open for picks.
+// Returns MatchupNotFoundError or PickInvalidError if the Team is not part of a Matchup on the Schedule.
+// Returns MatchupLockedError if the Matchup has already started.
+// Returns TeamAlreadyPickedError if the Contestant has already Selected the Team for another week of the season.
+//
+// actorID is the unique identifier of the User suggesting the pick.
+//
+// req is the SavePickRequest containing the Matchup and Team being suggested.
+func (s *EntryService) AddSuggestedPick(actorID types.UserID, req *types.SavePickRequest) (*types.Entry, error) {
+	e, err := s.getPickEntry(actorID, req, false)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.validatePick(e, req.MatchupID, req.TeamID); err != nil {
+		return nil, err
+	}
+	if e, err = s.startEntry(e); err != nil {
+		return nil, err
+	}
+
+	// Add the Suggested pick, recording who suggested it
+	if e.SuggestedBy == nil {
+		e.SuggestedBy = make(map[types.MatchupID]types.UserID, 1)
+	}
+	e.SuggestedPicks[req.MatchupID] = req.TeamID
+	e.SuggestedBy[req.MatchupID] = actorID
+	if err = s.repo.Update(e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
 }
 
-// TODO - Create
+// RemoveSuggestedPick removes the suggested pick for a Matchup from the Entry. Any authorized User of the Entry's
+// Contestant may remove their own suggestion, while OWNERs and MANAGERs may remove any suggestion.
+// Returns EntryNotFoundError if no such Entry exists.
+// Returns ContestantForbiddenError if the acting User is not authorized to remove the suggestion.
+// Returns ContestantNotActiveError if the Entry's Contestant is no longer ACTIVE.
+// Returns ScheduleClosedError if the Entry's Schedule is not open for picks.
+//
+// actorID is the unique identifier of the User removing the suggestion.
+//
+// id is the unique identifier of the Entry to update.
+//
+// mID is the unique identifier of the Matchup whose suggestion will be removed.
+func (s *EntryService) RemoveSuggestedPick(actorID types.UserID, id types.EntryID, mID types.MatchupID) (*types.Entry, error) {
+	e, err := s.getOpenEntry(actorID, id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the Matchup has no suggestion, do nothing and return
+	if _, exists := e.SuggestedPicks[mID]; !exists {
+		return e, nil
+	}
+
+	// Only OWNERs and MANAGERs can remove suggestions made by other Users
+	if e.SuggestedBy[mID] != actorID {
+		if _, err = s.getContestant(actorID, e.Contestant, true); err != nil {
+			return nil, err
+		}
+	}
 
-// TODO - GetByID
+	// Remove the Suggested pick
+	delete(e.SuggestedPicks, mID)
+	delete(e.SuggestedBy, mID)
+	if err = s.repo.Update(e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// ApplyResult updates the Status of each Contestant whose Selected pick is in a Matchup with a recorded result: ACTIVE
+// Contestants whose pick LOST are ELIMINATED, and Contestants that a previous result for the Matchup ELIMINATED are
+// reinstated if their pick no longer LOST (e.g. - after the result is corrected). Returns the unique identifiers of
+// the Contestants whose Status changed.
+//
+// sch is the pointer to the Schedule containing the Matchup.
+//
+// mID is the unique identifier of the Matchup whose result was recorded.
+func (s *EntryService) ApplyResult(sch *types.Schedule, mID types.MatchupID) ([]types.ContestantID, []types.ContestantID, error) {
+	entries, err := s.repo.GetBySchedule(sch.ID)
+	if err != nil {
+		return nil, nil, err
+	}
 
-// TODO - SetSelectedPick
+	eliminated := make([]types.ContestantID, 0)
+	reinstated := make([]types.ContestantID, 0)
+	for _, e := range entries {
+		teamID, exists := e.SelectedPick[mID]
+		if !e.Active || !exists {
+			continue
+		}
 
-// TODO - ClearSelectedPick
+		var changed bool
+		if outcome(sch.Matchups[mID], teamID) == outcomes.LOST {
+			changed, err = s.conService.Eliminate(e.Contestant, &types.Elimination{Schedule: sch.ID, Matchup: mID, Week: sch.Week})
+			if changed {
+				eliminated = append(eliminated, e.Contestant)
+			}
+		} else {
+			changed, err = s.conService.Reinstate(e.Contestant, sch.ID, mID)
+			if changed {
+				reinstated = append(reinstated, e.Contestant)
+			}
+		}
+		if err != nil {
+			if _, ok := err.(*types.ContestantNotFoundError); ok {
+				continue
+			}
+			return eliminated, reinstated, err
+		}
+	}
 
-// TODO - AddSuggestedPick
+	return eliminated, reinstated, nil
+}
+
+// Deactivate deactivates the specified Entry (soft-delete).
+//
+// id is the unique identifier of the Entry to deactivate.
+func (s *EntryService) Deactivate(id types.EntryID) error {
+	return s.repo.Deactivate(id)
+}
+
+// getEntry loads the specified Entry, verifying that it exists and is active.
+func (s *EntryService) getEntry(id types.EntryID) (*types.Entry, error) {
+	// Load the Entry from the database
+	e, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify that the Entry exists and is active
+	if e == nil || !e.Active {
+		return nil, &types.EntryNotFoundError{ID: id}
+	}
+
+	return e, nil
+}
+
+// getOpenEntry loads the specified Entry on behalf of the acting User, verifying that the User is authorized
+// to change its picks, that its Contestant is ACTIVE and that its Schedule is currently open for picks.
+func (s *EntryService) getOpenEntry(actorID types.UserID, id types.EntryID, manage bool) (*types.Entry, error) {
+	e, err := s.getEntry(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify that the acting User is authorized for the Contestant
+	if _, err = s.getActiveContestant(actorID, e.Contestant, manage); err != nil {
+		return nil, err
+	}
+
+	// Verify that the Schedule is open for picks
+	sch, err := s.schService.GetByID(e.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	if !sch.IsOpen(time.Now()) {
+		return nil, &types.ScheduleClosedError{ID: sch.ID, Opens: sch.Opens, Closes: sch.Closes}
+	}
+
+	return e, nil
+}
 
-// TODO - RemoveSuggestedPick
+// getPickEntry loads the Entry that a pick will be saved to on behalf of the acting User, verifying that the User is
+// authorized to change its picks, that its Contestant is ACTIVE and that its Schedule is currently open for picks. If the request does not identify
+// an Entry, the Contestant's Entry for the Schedule is loaded, or an empty Entry (without an ID) is returned to be
+// started by startEntry once the pick has been validated.
+func (s *EntryService) getPickEntry(actorID types.UserID, req *types.SavePickRequest, manage bool) (*types.Entry, error) {
+	if len(req.EntryID) > 0 {
+		return s.getOpenEntry(actorID, req.EntryID, manage)
+	}
 
-// TODO - Deactivate
+	// Verify that the acting User is authorized for the Contestant
+	if _, err := s.getActiveContestant(actorID, req.ContestantID, manage); err != nil {
+		return nil, err
+	}
+
+	// Verify that the Schedule is open for picks
+	sch, err := s.schService.GetByID(req.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if !sch.IsOpen(time.Now()) {
+		return nil, &types.ScheduleClosedError{ID: sch.ID, Opens: sch.Opens, Closes: sch.Closes}
+	}
+
+	// Load the existing Entry, if there is one
+	e, err := s.repo.GetByContestantAndSchedule(req.ContestantID, req.ScheduleID)
+	if err != nil {
+		return nil, err
+	} else if len(e.ID) > 0 {
+		return e, nil
+	}
+
+	return newEntry(req.ContestantID, req.ScheduleID), nil
+}
+
+// startEntry stores the provided Entry if it has not been stored yet, returning the stored Entry (which may have been
+// started concurrently by another User of the Contestant).
+func (s *EntryService) startEntry(e *types.Entry) (*types.Entry, error) {
+	if len(e.ID) > 0 {
+		return e, nil
+	}
+	e.ID = types.EntryID(uuid.NewString())
+
+	return s.repo.Start(e)
+}
+
+// getContestant loads the specified Contestant on behalf of the acting User. If manage is true, the User must be
+// an OWNER or MANAGER of the Contestant; otherwise any authorized User is permitted.
+func (s *EntryService) getContestant(actorID types.UserID, conID types.ContestantID, manage bool) (*types.Contestant, error) {
+	c, err := s.conService.GetAsMember(actorID, conID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify that the acting User can manage the Contestant's picks
+	if manage && c.AuthorizedUsers[actorID] != roles.OWNER && c.AuthorizedUsers[actorID] != roles.MANAGER {
+		return nil, &types.ContestantForbiddenError{ContestantID: conID, UserID: actorID}
+	}
+
+	return c, nil
+}
+
+// getActiveContestant loads the specified Contestant on behalf of the acting User (see getContestant), verifying
+// that the Contestant is ACTIVE and so can still change its picks.
+func (s *EntryService) getActiveContestant(actorID types.UserID, conID types.ContestantID, manage bool) (*types.Contestant, error) {
+	c, err := s.getContestant(actorID, conID, manage)
+	if err != nil {
+		return nil, err
+	}
+	if c.Status != status.ACTIVE {
+		return nil, &types.ContestantNotActiveError{ContestantID: conID, Status: c.Status}
+	}
+
+	return c, nil
+}
+
+// validatePick verifies that the Team is part of the specified Matchup on the Entry's Schedule, that the Matchup
+// has not started and that the Contestant has not already Selected the Team for another week of the season.
+func (s *EntryService) validatePick(e *types.Entry, mID types.MatchupID, teamID types.TeamID) error {
+	// Load the Schedule from the database
+	sch, err := s.schService.GetByID(e.Schedule)
+	if err != nil {
+		return err
+	}
+
+	// Verify that the Matchup exists and features the Team
+	m, exists := sch.Matchups[mID]
+	if !exists {
+		return &types.MatchupNotFoundError{ScheduleID: sch.ID, MatchupID: mID}
+	}
+	if m.HomeTeam != teamID && m.AwayTeam != teamID {
+		return &types.PickInvalidError{EntryID: e.ID, MatchupID: mID, TeamID: teamID}
+	}
+
+	// Verify that the Matchup has not started
+	if m.IsLocked(time.Now()) {
+		return &types.MatchupLockedError{ScheduleID: sch.ID, MatchupID: mID, DateTime: m.DateTime}
+	}
+
+	// Verify that the Team has not been used in another week of the season
+	used, err := s.GetUsedTeams(e.Contestant, sch)
+	if err != nil {
+		return err
+	}
+	if schID, exists := used[teamID]; exists {
+		return &types.TeamAlreadyPickedError{ContestantID: e.Contestant, TeamID: teamID, ScheduleID: schID}
+	}
+
+	return nil
+}
+
+// verifySelectionUnlocked verifies that the Matchup of the Entry's Selected pick (if any) has not started, since
+// a Selected pick cannot be changed once its game is underway.
+func (s *EntryService) verifySelectionUnlocked(e *types.Entry) error {
+	// Load the Schedule from the database
+	sch, err := s.schService.GetByID(e.Schedule)
+	if err != nil {
+		return err
+	}
+
+	for mID := range e.SelectedPick {
+		if m, exists := sch.Matchups[mID]; exists && m.IsLocked(time.Now()) {
+			return &types.MatchupLockedError{ScheduleID: sch.ID, MatchupID: mID, DateTime: m.DateTime}
+		}
+	}
+
+	return nil
+}
+
+// newEntry returns an empty, active Entry (without an ID) for the Contestant and Schedule.
+func newEntry(conID types.ContestantID, schID types.ScheduleID) *types.Entry {
+	return &types.Entry{
+		Contestant:     conID,
+		Schedule:       schID,
+		SelectedPick:   make(map[types.MatchupID]types.TeamID, 0),
+		SuggestedPicks: make(map[types.MatchupID]types.TeamID, 0),
+		SuggestedBy:    make(map[types.MatchupID]types.UserID, 0),
+		Active:         true,
+	}
+}
@@ -2,51 +2,157 @@ package services
 
 import (
 	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/mhs294/mulhall/internals/env"
 	"github.com/mhs294/mulhall/internals/repos"
 	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/roles"
 	"github.com/mhs294/mulhall/internals/utils"
 )
 
 // InviteService represents a service for interacting with User Invites for the site.
 type InviteService struct {
-	invRepo *repos.InviteRepository
+	invRepo      *repos.InviteRepository
+	userRepo     *repos.UserRepository
+	conService   *ContestantService
+	notifService *NotificationService
 }
 
 // NewInviteService creates a new instance of an InviteService and returns a pointer to it.
 //
 // r is the InviteRepository that will be used to manage Invite records in the database.
-func NewInviteService(r *repos.InviteRepository) *InviteService {
-	return &InviteService{invRepo: r}
+//
+// ur is the UserRepository used to verify that invited email addresses do not already belong to a User.
+//
+// cs is the ContestantService used to load the Contestants that the inviting User manages.
+//
+// ns is the NotificationService used to email each Invite and to let the inviting User know that it was accepted.
+func NewInviteService(r *repos.InviteRepository, ur *repos.UserRepository, cs *ContestantService, ns *NotificationService) *InviteService {
+	return &InviteService{invRepo: r, userRepo: ur, conService: cs, notifService: ns}
+}
+
+// Check checks a partially completed Invite on behalf of the acting User, describing the Contestants that the
+// User can invite new Users to, the Roles available for each and any problems with the values provided so far.
+// If no Contestant or Role has been chosen yet, the first one available is chosen.
+//
+// Users can invite new Users to the Contestants that they manage (as an OWNER or MANAGER). An invited User can
+// be made the OWNER of a Contestant that has no OWNER, and otherwise a MANAGER or VIEWER. Since only Administrators
+// can create Contestants, only Administrators can invite a new User to be the OWNER of a new Contestant.
+//
+// actorID is the unique identifier of the User sending the Invite.
+//
+// req is the CheckInviteRequest containing the values provided so far.
+func (s *InviteService) Check(actorID types.UserID, req *types.CheckInviteRequest) (*types.InviteFormResponse, error) {
+	form, err := s.getOptions(actorID)
+	if err != nil {
+		return nil, err
+	}
+	form.Email = strings.TrimSpace(req.Email)
+	form.ContestantID = req.ContestantID
+	form.ContestantName = strings.TrimSpace(req.ContestantName)
+	form.Role = req.Role
+	form.Fields = make(map[string]string)
+
+	// Verify that the Contestant is one that the User manages (choosing the first one if none was provided), or
+	// that the User can invite the OWNER of a new Contestant
+	newContestant := req.NewContestant || len(form.ContestantName) > 0
+	if len(form.ContestantID) == 0 && !newContestant {
+		if len(form.Options) > 0 {
+			form.ContestantID = form.Options[0].Contestant
+		} else {
+			newContestant = form.NewContestant != nil
+		}
+	}
+	if newContestant && len(form.ContestantID) == 0 && form.NewContestant == nil {
+		form.Fields["contestantName"] = "only administrators can invite the owner of a new contestant"
+	}
+	opt := form.Selected()
+	if opt == nil && len(form.ContestantID) > 0 {
+		form.Fields["contestantId"] = "must be a contestant that you manage"
+	}
+
+	// Verify that the Role is available for the Contestant (choosing the first one if none was provided)
+	if opt != nil {
+		if len(form.Role) == 0 {
+			form.Role = opt.Roles[0]
+		} else if !slices.Contains(opt.Roles, form.Role) {
+			form.Fields["role"] = fmt.Sprintf("must be %s for this contestant", describeRoles(opt.Roles))
+		}
+	}
+
+	// Verify that the email address does not already belong to a User or have a pending Invite
+	if len(form.Email) > 0 {
+		if addr, err := mail.ParseAddress(form.Email); err != nil || addr.Address != form.Email {
+			form.Fields["email"] = "must be a valid email address"
+		} else if u, err := s.userRepo.GetByEmail(form.Email); err != nil {
+			return nil, err
+		} else if u != nil && len(u.ID) > 0 {
+			form.Fields["email"] = "already belongs to an account"
+		} else if inv, err := s.invRepo.GetPendingByEmail(form.Email); err != nil {
+			return nil, err
+		} else if inv != nil {
+			form.Fields["email"] = "already has a pending invite"
+		}
+	}
+
+	return form, nil
 }
 
-// Create creates a new Invite from the provided information and returns a pointer to it.
+// Create creates a new Invite from the provided information on behalf of the acting User and returns a pointer to it.
+// Returns RequestValidationError if the Contestant, Role or email address is not valid for the Invite (see Check).
+//
+// actorID is the unique identifier of the User sending the Invite.
 //
 // req is the CreateInviteRequest containing the necessary information to create the new Invite.
-func (s *InviteService) Create(req *types.CreateInviteRequest) (*types.Invite, error) {
+func (s *InviteService) Create(actorID types.UserID, req *types.CreateInviteRequest) (*types.Invite, error) {
+	// Verify that the User can send the Invite
+	form, err := s.Check(actorID, &types.CheckInviteRequest{
+		Email:          req.Email,
+		ContestantID:   req.ContestantID,
+		ContestantName: req.ContestantName,
+		Role:           req.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(form.ContestantID) == 0 && len(form.ContestantName) == 0 {
+		form.Fields["contestantName"] = "is required for a new contestant"
+	}
+	if len(form.Fields) > 0 {
+		return nil, &types.RequestValidationError{Fields: form.Fields}
+	}
+
 	// Create the Invite with a randomly generated validation token
 	token := utils.CreateAlphaNumToken(64)
 	inv := &types.Invite{
 		ID:           types.InviteID(uuid.NewString()),
-		Email:        req.Email,
-		Contestant:   req.ContestantID,
-		Role:         req.Role,
-		InvitingUser: req.InvitingUserID,
+		Email:        form.Email,
+		Contestant:   form.ContestantID,
+		Role:         form.Role,
+		InvitingUser: actorID,
 		Token:        token,
 		Expiration:   time.Now().UTC().Add(env.InviteExpiration),
 		Accepted:     false,
 	}
+	if len(inv.Contestant) == 0 {
+		inv.ContestantName = form.ContestantName
+	}
+
+	// Email the invitation link before storing the Invite, so that it can be sent again if delivery fails
+	if _, err := s.notifService.SendInvite(inv); err != nil {
+		return nil, fmt.Errorf("failed to send invite: %v", err)
+	}
 
 	// Insert the Invite into the database
 	if err := s.invRepo.Insert(inv); err != nil {
 		return nil, fmt.Errorf("failed to create invite: %v", err)
 	}
 
-	// TODO - send out email with invitation link
-
 	return inv, nil
 }
 
@@ -79,9 +185,109 @@ func (s *InviteService) Validate(email string, token string) (types.InviteID, er
 	return inv.ID, nil
 }
 
-// Accept marks the Invite with the provided ID as accepted.
+// GetPending returns the active Invite for the provided email address, for Users whose email address has
+// already been verified by other means (e.g. - an external identity provider) and so do not need the token.
+// Returns InviteNotFoundError if no unexpired, unaccepted Invite exists for the email address.
+//
+// email is the email address to look up the Invite for.
+func (s *InviteService) GetPending(email string) (*types.Invite, error) {
+	inv, err := s.invRepo.GetPendingByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	if inv == nil {
+		return nil, &types.InviteNotFoundError{Email: email}
+	}
+
+	return inv, nil
+}
+
+// Accept marks the Invite with the provided ID as accepted, authorizes the User who accepted it for the Invite's
+// Contestant with the Invite's Role (creating the Contestant first, for an Invite to a new Contestant) and notifies
+// the User who sent it.
 //
 // id is the unique identifier of the Invite being accepted.
-func (s *InviteService) Accept(id types.InviteID) error {
-	return s.invRepo.Accept(id)
+//
+// userID is the unique identifier of the User who accepted the Invite.
+func (s *InviteService) Accept(id types.InviteID, userID types.UserID) error {
+	if err := s.invRepo.Accept(id); err != nil {
+		return err
+	}
+	inv, err := s.invRepo.GetByID(id)
+	if err != nil || inv == nil {
+		return err
+	}
+
+	// Add the User to the Contestant, unless it has since been deactivated
+	if len(inv.Contestant) == 0 {
+		c, err := s.conService.Create(&types.CreateContestantRequest{
+			Name:            inv.ContestantName,
+			AuthorizedUsers: map[types.UserID]roles.Role{userID: roles.OWNER},
+		})
+		if err != nil {
+			return err
+		}
+		inv.Contestant = c.ID
+	} else if err := s.conService.SetAuthorizedUser(inv.Contestant, userID, inv.Role); err != nil {
+		if _, ok := err.(*types.ContestantNotFoundError); ok {
+			return nil
+		}
+		return err
+	}
+
+	// Let the inviting User know
+	if _, err := s.notifService.SendInviteAccepted(inv); err != nil {
+		if _, ok := err.(*types.ContestantNotFoundError); !ok {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getOptions describes the Contestants that the User manages, and the Roles that invited Users can be given in each
+// (along with the new Contestant, for Administrators).
+func (s *InviteService) getOptions(actorID types.UserID) (*types.InviteFormResponse, error) {
+	cons, err := s.conService.GetByAuthorizedUser(actorID)
+	if err != nil {
+		return nil, err
+	}
+	actor, err := s.userRepo.GetByID(actorID)
+	if err != nil {
+		return nil, err
+	}
+	admin := actor != nil && actor.Administrator
+
+	form := &types.InviteFormResponse{Options: make([]types.InviteOptionResponse, 0, len(cons))}
+	for _, c := range cons {
+		if role := c.AuthorizedUsers[actorID]; role != roles.OWNER && role != roles.MANAGER {
+			continue
+		}
+
+		opt := types.InviteOptionResponse{Contestant: c.ID, Name: c.Name, Roles: []roles.Role{roles.MANAGER, roles.VIEWER}}
+		owners, err := s.conService.countActiveOwners(c.AuthorizedUsers, "")
+		if err != nil {
+			return nil, err
+		}
+		if owners == 0 {
+			opt.Roles = append([]roles.Role{roles.OWNER}, opt.Roles...)
+		}
+		form.Options = append(form.Options, opt)
+	}
+	if admin {
+		form.NewContestant = &types.InviteOptionResponse{Name: "New contestant", Roles: []roles.Role{roles.OWNER}}
+	}
+
+	return form, nil
+}
+
+// describeRoles lists the Roles as a human-readable phrase (e.g. - "Manager or Viewer").
+func describeRoles(rs []roles.Role) string {
+	names := make([]string, 0, len(rs))
+	for _, r := range rs {
+		names = append(names, string(r))
+	}
+
+	return strings.Join(names, " or ")
 }
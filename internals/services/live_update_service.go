@@ -0,0 +1,159 @@
+package services
+
+import (
+	"time"
+
+	"github.com/mhs294/mulhall/internals/live"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/events"
+	"github.com/mhs294/mulhall/internals/types/status"
+)
+
+// LiveUpdateService represents a service for publishing live updates about picks, results and eliminations to the
+// subscribers of each Pool and Contestant.
+type LiveUpdateService struct {
+	hub         *live.Hub
+	poolService *PoolService
+	conService  *ContestantService
+	schService  *ScheduleService
+}
+
+// NewLiveUpdateService creates a new instance of a LiveUpdateService and returns a pointer to it.
+//
+// h is the Hub through which live updates are delivered to subscribers.
+//
+// ps is the PoolService used to verify Pools and find the Pools that each Contestant belongs to.
+//
+// cs is the ContestantService used to verify that subscribers are authorized for a Contestant.
+//
+// ss is the ScheduleService used to find the pick deadlines and Matchups of the current week.
+func NewLiveUpdateService(h *live.Hub, ps *PoolService, cs *ContestantService, ss *ScheduleService) *LiveUpdateService {
+	return &LiveUpdateService{hub: h, poolService: ps, conService: cs, schService: ss}
+}
+
+// SubscribePool subscribes to the live updates that affect the standings of a Pool: published picks, results and
+// eliminations.
+// Returns PoolNotFoundError if no such Pool exists or that Pool has been deactivated.
+// Returns PoolForbiddenError if the acting User is not a member of the Pool.
+//
+// actorID is the unique identifier of the User subscribing.
+//
+// poolID is the unique identifier of the Pool to subscribe to.
+func (s *LiveUpdateService) SubscribePool(actorID types.UserID, poolID types.PoolID) (*live.Subscription, error) {
+	if _, err := s.conService.GetPoolAsMember(actorID, poolID); err != nil {
+		return nil, err
+	}
+
+	return s.hub.Subscribe(live.PoolTopic(poolID)), nil
+}
+
+// SubscribeContestant subscribes to the live updates that affect a Contestant's picks, including changes to its
+// Selected and Suggested picks, which are only visible to the Contestant's authorized Users.
+// Returns ContestantNotFoundError if no such Contestant exists.
+// Returns ContestantForbiddenError if the acting User is not authorized for the Contestant.
+//
+// actorID is the unique identifier of the User subscribing.
+//
+// conID is the unique identifier of the Contestant to subscribe to.
+func (s *LiveUpdateService) SubscribeContestant(actorID types.UserID, conID types.ContestantID) (*live.Subscription, error) {
+	if _, err := s.conService.GetAsMember(actorID, conID); err != nil {
+		return nil, err
+	}
+
+	return s.hub.Subscribe(live.ContestantTopic(conID)), nil
+}
+
+// PicksChanged notifies the Contestant's authorized Users that its Selected or Suggested picks have changed.
+//
+// e is the pointer to the Entry whose picks changed.
+func (s *LiveUpdateService) PicksChanged(e *types.Entry) {
+	s.hub.Publish(types.LiveUpdateResponse{Event: events.PICKS, Contestant: e.Contestant, Schedule: e.Schedule},
+		live.ContestantTopic(e.Contestant))
+}
+
+// ResultRecorded notifies all subscribers that the result of a Matchup has been recorded, and notifies the Pools of
+// the Contestants that the result eliminated or reinstated.
+//
+// sch is the pointer to the Schedule containing the Matchup.
+//
+// mID is the unique identifier of the Matchup whose result was recorded.
+//
+// eliminated are the unique identifiers of the Contestants that the result changed from ACTIVE to ELIMINATED.
+//
+// reinstated are the unique identifiers of the Contestants that the result changed from ELIMINATED to ACTIVE.
+func (s *LiveUpdateService) ResultRecorded(sch *types.Schedule, mID types.MatchupID, eliminated []types.ContestantID, reinstated []types.ContestantID) error {
+	s.hub.Broadcast(types.LiveUpdateResponse{Event: events.RESULT, Schedule: sch.ID, Matchup: mID, Week: sch.Week})
+
+	if err := s.publishStatus(eliminated, events.ELIMINATED, sch.Week); err != nil {
+		return err
+	}
+	return s.publishStatus(reinstated, events.REINSTATED, sch.Week)
+}
+
+// StatusChanged notifies the Pools of a Contestant that it has been eliminated, disqualified or reinstated.
+//
+// conID is the unique identifier of the Contestant whose Status changed.
+//
+// st is the new Status of the Contestant.
+func (s *LiveUpdateService) StatusChanged(conID types.ContestantID, st status.Status) error {
+	ev := events.ELIMINATED
+	if st == status.ACTIVE {
+		ev = events.REINSTATED
+	}
+
+	return s.publishStatus([]types.ContestantID{conID}, ev, 0)
+}
+
+// PublishDeadlines notifies all subscribers of the picks that were published between two times, because the current
+// week's picks closed or one of its Matchups started.
+//
+// since is the time after which picks were published (usually the end of the previous call).
+//
+// until is the time up to which picks were published (usually the current time).
+func (s *LiveUpdateService) PublishDeadlines(since time.Time, until time.Time) error {
+	sch, err := s.schService.GetByDateTime(until)
+	if err != nil {
+		if _, ok := err.(*types.ScheduleNotFoundError); ok {
+			return nil
+		}
+		return err
+	}
+
+	passed := func(t time.Time) bool {
+		return t.After(since) && !t.After(until)
+	}
+	if passed(sch.Closes) {
+		s.hub.Broadcast(types.LiveUpdateResponse{Event: events.PUBLISHED, Schedule: sch.ID, Week: sch.Week})
+	}
+	for mID, m := range sch.Matchups {
+		if passed(m.DateTime) && m.DateTime.Before(sch.Closes) {
+			s.hub.Broadcast(types.LiveUpdateResponse{Event: events.PUBLISHED, Schedule: sch.ID, Matchup: mID, Week: sch.Week})
+		}
+	}
+
+	return nil
+}
+
+// publishStatus notifies each Contestant and the Pools it belongs to that it has been eliminated or reinstated.
+func (s *LiveUpdateService) publishStatus(conIDs []types.ContestantID, ev events.Event, week int) error {
+	if len(conIDs) == 0 {
+		return nil
+	}
+
+	pools, err := s.poolService.GetAll()
+	if err != nil {
+		return err
+	}
+	for _, conID := range conIDs {
+		u := types.LiveUpdateResponse{Event: ev, Contestant: conID, Week: week}
+		s.hub.Publish(u, live.ContestantTopic(conID))
+		for _, p := range pools {
+			if _, exists := p.Contestants[conID]; exists {
+				u.Pool = p.ID
+				s.hub.Publish(u, live.PoolTopic(p.ID))
+			}
+		}
+	}
+
+	return nil
+}
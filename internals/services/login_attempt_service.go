@@ -0,0 +1,130 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mhs294/mulhall/internals/env"
+	"github.com/mhs294/mulhall/internals/repos"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/audit"
+)
+
+// LoginAttemptService represents a service for tracking failed login attempts per account and per client
+// address, applying exponential backoff and temporary lockouts to slow down password guessing.
+type LoginAttemptService struct {
+	repo         *repos.LoginAttemptRepository
+	auditService *AuditService
+}
+
+// NewLoginAttemptService creates a new instance of a LoginAttemptService and returns a pointer to it.
+//
+// r is the LoginAttemptRepository that will be used to manage LoginAttempt records in the database.
+//
+// as is the AuditService that will be used to record lockouts.
+func NewLoginAttemptService(r *repos.LoginAttemptRepository, as *AuditService) *LoginAttemptService {
+	return &LoginAttemptService{repo: r, auditService: as}
+}
+
+// Check verifies that a login attempt is currently allowed for the provided email address and client address.
+// Returns LoginThrottledError if either the account or the client address must wait before trying again.
+//
+// email is the email address being logged in to.
+//
+// ip is the address of the client attempting to log in.
+func (s *LoginAttemptService) Check(email string, ip string) error {
+	now := time.Now().UTC()
+	for _, key := range []string{accountKey(email), addressKey(ip)} {
+		// Load the recorded failures for the key
+		a, err := s.repo.GetByKey(key)
+		if err != nil {
+			return err
+		}
+
+		// Forget failures that are older than the attempt window
+		if a.Failures > 0 && a.LastFailure.Add(env.LoginAttemptWindow).Before(now) {
+			if err = s.repo.Reset(key); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if a.LockedUntil.After(now) {
+			return &types.LoginThrottledError{RetryAfter: a.LockedUntil.Sub(now).Round(time.Second)}
+		}
+	}
+
+	return nil
+}
+
+// RecordFailure records a failed login attempt for the provided email address and client address, and
+// blocks further attempts for an exponentially increasing period once the free attempts are exhausted.
+// Reaching the lockout threshold is recorded as an AuditEvent.
+//
+// email is the email address that failed to log in.
+//
+// ip is the address of the client that failed to log in.
+func (s *LoginAttemptService) RecordFailure(email string, ip string) error {
+	if err := s.recordFailure(accountKey(email), env.LoginLockoutThreshold, audit.ACCOUNT, email); err != nil {
+		return err
+	}
+
+	return s.recordFailure(addressKey(ip), env.LoginAddressLockoutThreshold, audit.ADDRESS, ip)
+}
+
+// RecordSuccess clears the recorded login failures for the provided email address.
+// Failures for the client address are retained, so that a client cannot reset its own backoff by
+// logging in to an account it controls.
+//
+// email is the email address that successfully logged in.
+func (s *LoginAttemptService) RecordSuccess(email string) error {
+	return s.repo.Reset(accountKey(email))
+}
+
+func (s *LoginAttemptService) recordFailure(key string, threshold int, resource audit.Resource, subject string) error {
+	now := time.Now().UTC()
+	a, err := s.repo.IncrementFailures(key, now)
+	if err != nil {
+		return err
+	}
+
+	// Allow a few free attempts before applying any backoff
+	if a.Failures <= env.LoginFreeAttempts {
+		return nil
+	}
+
+	// Lock out the key for the full lockout duration once the threshold is reached,
+	// otherwise back off exponentially (1s, 2s, 4s, ...) up to the lockout duration
+	var wait time.Duration
+	if a.Failures >= threshold {
+		wait = env.LoginLockoutDuration
+	} else {
+		wait = time.Second << min(a.Failures-env.LoginFreeAttempts-1, 30)
+		wait = min(wait, env.LoginLockoutDuration)
+	}
+	if err = s.repo.SetLockedUntil(key, now.Add(wait)); err != nil {
+		return err
+	}
+
+	if a.Failures == threshold {
+		details := map[string]string{
+			"subject":  subject,
+			"failures": fmt.Sprintf("%d", a.Failures),
+			"until":    now.Add(wait).Format(time.RFC3339),
+		}
+		if err = s.auditService.Record("", audit.LOCKOUT, resource, details); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func addressKey(ip string) string {
+	return "address:" + ip
+}
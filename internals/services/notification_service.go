@@ -0,0 +1,573 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mhs294/mulhall/internals/env"
+	"github.com/mhs294/mulhall/internals/notify"
+	"github.com/mhs294/mulhall/internals/repos"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/notifications"
+	"github.com/mhs294/mulhall/internals/types/roles"
+	"github.com/mhs294/mulhall/internals/types/status"
+)
+
+// NotificationService represents a service for sending Notifications to Users and managing the Notifications they
+// have received.
+type NotificationService struct {
+	dispatcher  *notify.Dispatcher
+	repo        *repos.NotificationRepository
+	userRepo    *repos.UserRepository
+	entryRepo   *repos.EntryRepository
+	poolService *PoolService
+	conService  *ContestantService
+	schService  *ScheduleService
+	offsets     []time.Duration
+}
+
+// NewNotificationService creates a new instance of a NotificationService and returns a pointer to it.
+//
+// d is the Dispatcher through which every Notification is sent, honoring each User's preferences.
+//
+// r is the NotificationRepository that will be used to manage Notification records in the database.
+//
+// ur is the UserRepository used to manage each User's notification preferences.
+//
+// er is the EntryRepository used to find the Contestants that have not Selected a pick for the current week.
+//
+// ps is the PoolService used to load the Pools whose Contestants are notified.
+//
+// cs is the ContestantService used to load the Contestants in each Pool and the Users authorized for them.
+//
+// ss is the ScheduleService used to load the current week's Schedule and its pick deadline.
+//
+// offsets are the durations before each week's pick deadline at which reminders are sent (e.g. - 24h and 1h).
+func NewNotificationService(d *notify.Dispatcher, r *repos.NotificationRepository, ur *repos.UserRepository, er *repos.EntryRepository, ps *PoolService, cs *ContestantService, ss *ScheduleService, offsets []time.Duration) *NotificationService {
+	return &NotificationService{
+		dispatcher:  d,
+		repo:        r,
+		userRepo:    ur,
+		entryRepo:   er,
+		poolService: ps,
+		conService:  cs,
+		schService:  ss,
+		offsets:     offsets,
+	}
+}
+
+// SendReminders reminds the OWNERs and MANAGERs of each ACTIVE Contestant that has not Selected a pick for the current
+// week that its picks close soon, once the pick deadline is within one of the configured offsets. Each User receives a
+// single reminder per offset (covering all of their Contestants that are missing a pick), and Users that have opted out
+// of reminders are skipped. Reminders are not delivered outside of the app once picks have closed (e.g. - when the
+// User's quiet hours last beyond the pick deadline). When several offsets have been reached (e.g. - after downtime),
+// only the reminder for the nearest one is sent. Returns the number of reminders sent.
+//
+// at is the current time.
+func (s *NotificationService) SendReminders(at time.Time) (int, error) {
+	// Load the current week's Schedule, if it is open for picks
+	sch, err := s.schService.GetByDateTime(at)
+	if err != nil {
+		if _, ok := err.(*types.ScheduleNotFoundError); ok {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if !sch.IsOpen(at) {
+		return 0, nil
+	}
+
+	// Find the nearest offset before the pick deadline that has been reached
+	remaining := sch.Closes.Sub(at)
+	offset := time.Duration(-1)
+	for _, o := range s.offsets {
+		if remaining <= o && (offset < 0 || o < offset) {
+			offset = o
+		}
+	}
+	if offset < 0 {
+		return 0, nil
+	}
+
+	// Find the Users who manage an ACTIVE Contestant that has not Selected a pick
+	missing, err := s.findMissingPicks(sch)
+	if err != nil {
+		return 0, err
+	}
+
+	// Remind each User once, listing all of their Contestants that are missing a pick
+	sent := 0
+	for userID, names := range missing {
+		sort.Strings(names)
+		ok, err := s.dispatcher.Dispatch(userID, &types.Notification{
+			Kind:  notifications.REMINDER,
+			Key:   fmt.Sprintf("reminder:%s:%s", sch.ID, offset),
+			Title: fmt.Sprintf("Week %d picks close in %s", sch.Week, describeOffset(offset)),
+			Message: fmt.Sprintf("No pick has been selected for %s. Picks close at %s.",
+				joinNames(names), sch.Closes.Local().Format(time.DateTime)),
+			Link:    "/",
+			Expires: sch.Closes,
+		})
+		if err != nil {
+			return sent, err
+		}
+		if ok {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// SendPublished notifies the members of each incomplete Pool that the current week's picks have been published, once
+// its pick deadline has passed between two times. Returns the number of Notifications sent.
+//
+// since is the time after which the pick deadline must have passed (usually the end of the previous call).
+//
+// until is the time up to which the pick deadline may have passed (usually the current time).
+func (s *NotificationService) SendPublished(since time.Time, until time.Time) (int, error) {
+	// Load the current week's Schedule, if its pick deadline has just passed
+	sch, err := s.schService.GetByDateTime(until)
+	if err != nil {
+		if _, ok := err.(*types.ScheduleNotFoundError); ok {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if !sch.Closes.After(since) || sch.Closes.After(until) {
+		return 0, nil
+	}
+
+	// Notify the members of every incomplete Pool
+	pools, err := s.poolService.GetAll()
+	if err != nil {
+		return 0, err
+	}
+	members := make(map[types.UserID]struct{})
+	for _, p := range pools {
+		if p.Complete {
+			continue
+		}
+		cons, err := s.conService.GetByPool(p.ID)
+		if err != nil {
+			return 0, err
+		}
+		for _, c := range cons {
+			for userID := range c.AuthorizedUsers {
+				members[userID] = struct{}{}
+			}
+		}
+	}
+
+	return s.dispatchAll(members, &types.Notification{
+		Kind:    notifications.PUBLISHED,
+		Key:     fmt.Sprintf("published:%s", sch.ID),
+		Title:   fmt.Sprintf("Week %d picks are published", sch.Week),
+		Message: fmt.Sprintf("Picks for week %d have closed, and every Contestant's pick can now be seen.", sch.Week),
+		Link:    "/picks",
+	})
+}
+
+// SendElimination notifies the Users authorized for a Contestant that it has been eliminated or disqualified. Changes
+// to any other Status are not notified. Returns the number of Notifications sent.
+//
+// conID is the unique identifier of the Contestant that was eliminated.
+//
+// st is the Status of the Contestant.
+//
+// week is the week in which the Contestant's Selected pick lost, or 0 if an Administrator changed its Status.
+func (s *NotificationService) SendElimination(conID types.ContestantID, st status.Status, week int) (int, error) {
+	if st != status.ELIMINATED && st != status.DISQUALIFIED {
+		return 0, nil
+	}
+	c, err := s.conService.GetByID(conID)
+	if err != nil {
+		return 0, err
+	}
+
+	n := &types.Notification{
+		Kind:    notifications.ELIMINATION,
+		Key:     fmt.Sprintf("elimination:%s:%s:%d", conID, st, week),
+		Title:   fmt.Sprintf("%s was %s", c.Name, strings.ToLower(string(st))),
+		Message: fmt.Sprintf("An administrator marked %s as %s.", c.Name, strings.ToLower(string(st))),
+		Link:    "/",
+	}
+	if week > 0 {
+		n.Title = fmt.Sprintf("%s was eliminated in week %d", c.Name, week)
+		n.Message = fmt.Sprintf("The team that %s picked for week %d lost.", c.Name, week)
+	}
+
+	return s.dispatchAll(authorizedUsers(c), n)
+}
+
+// SendPoolResult notifies the members of a completed Pool of its result: the Contestants that remain ACTIVE win the
+// Pool. Returns the number of Notifications sent.
+//
+// poolID is the unique identifier of the Pool that was completed.
+func (s *NotificationService) SendPoolResult(poolID types.PoolID) (int, error) {
+	p, err := s.poolService.GetByID(poolID)
+	if err != nil {
+		return 0, err
+	}
+	cons, err := s.conService.GetByPool(poolID)
+	if err != nil {
+		return 0, err
+	}
+
+	members := make(map[types.UserID]struct{})
+	winners := make([]string, 0)
+	for _, c := range cons {
+		for userID := range c.AuthorizedUsers {
+			members[userID] = struct{}{}
+		}
+		if c.Status == status.ACTIVE {
+			winners = append(winners, c.Name)
+		}
+	}
+	sort.Strings(winners)
+	message := fmt.Sprintf("%s is complete, with no Contestants remaining.", p.Name)
+	if len(winners) > 0 {
+		message = fmt.Sprintf("%s is complete. Congratulations to %s!", p.Name, joinNames(winners))
+	}
+
+	return s.dispatchAll(members, &types.Notification{
+		Kind:    notifications.POOL_RESULT,
+		Key:     fmt.Sprintf("pool-result:%s", poolID),
+		Title:   fmt.Sprintf("%s is complete", p.Name),
+		Message: message,
+		Link:    fmt.Sprintf("/standings/%s", poolID),
+	})
+}
+
+// SendInviteAccepted notifies the User who sent an Invite that it has been accepted. Indicates whether the
+// Notification was sent.
+//
+// inv is the pointer to the Invite that was accepted.
+func (s *NotificationService) SendInviteAccepted(inv *types.Invite) (bool, error) {
+	c, err := s.conService.GetByID(inv.Contestant)
+	if err != nil {
+		return false, err
+	}
+
+	return s.dispatcher.Dispatch(inv.InvitingUser, &types.Notification{
+		Kind:    notifications.INVITE,
+		Key:     fmt.Sprintf("invite:%s", inv.ID),
+		Title:   "Your invite was accepted",
+		Message: fmt.Sprintf("%s accepted your invite to join %s as a %s.", inv.Email, c.Name, inv.Role),
+		Link:    "/",
+	})
+}
+
+// SendInvite emails an Invite to the invited email address, linking to the page on which it can be accepted.
+// Indicates whether the email was sent, which it is not if email is unavailable on the site.
+//
+// inv is the pointer to the Invite to send.
+func (s *NotificationService) SendInvite(inv *types.Invite) (bool, error) {
+	name := inv.ContestantName
+	if len(inv.Contestant) > 0 {
+		c, err := s.conService.GetByID(inv.Contestant)
+		if err != nil {
+			return false, err
+		}
+		name = c.Name
+	}
+
+	return s.dispatcher.Email(inv.Email, &types.Notification{
+		Kind:    notifications.INVITE,
+		Title:   "You have been invited to Mulhall",
+		Message: fmt.Sprintf("You have been invited to join %s as a %s. This invite expires at %s.", name, inv.Role, inv.Expiration.Local().Format(time.DateTime)),
+		Link:    fmt.Sprintf("/api/v1/invite/accept?email=%s&token=%s", url.QueryEscape(inv.Email), url.QueryEscape(inv.Token)),
+	})
+}
+
+// SendEmailVerification emails a link to the User's pending email address with which the address can be verified.
+// Indicates whether the email was sent, which it is not if email is unavailable on the site.
+//
+// u is the pointer to the User whose pending email address will be verified.
+func (s *NotificationService) SendEmailVerification(u *types.User) (bool, error) {
+	return s.dispatcher.Email(u.PendingEmail, &types.Notification{
+		Title:   "Verify your email address",
+		Message: fmt.Sprintf("Follow the link below to start using this email address with Mulhall. This link expires at %s.", u.PendingEmailExpiration.Local().Format(time.DateTime)),
+		Link:    fmt.Sprintf("/api/v1/user/email/verify?email=%s&token=%s", url.QueryEscape(u.PendingEmail), url.QueryEscape(u.PendingEmailToken)),
+	})
+}
+
+// GetByUser returns all Notifications delivered IN_APP to the User, newest first.
+//
+// userID is the unique identifier of the User whose Notifications will be loaded.
+func (s *NotificationService) GetByUser(userID types.UserID) ([]types.Notification, error) {
+	ns, err := s.repo.GetInAppByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(ns, func(i, j int) bool {
+		return ns[i].Created.After(ns[j].Created)
+	})
+
+	return ns, nil
+}
+
+// MarkRead marks Notifications sent to the User as read.
+//
+// userID is the unique identifier of the User who read the Notifications.
+//
+// ids are the unique identifiers of the Notifications that were read. If empty, all of the User's Notifications
+// are marked as read.
+func (s *NotificationService) MarkRead(userID types.UserID, ids []types.NotificationID) error {
+	return s.repo.MarkRead(userID, ids)
+}
+
+// GetPreferences describes how and when the User is notified.
+// Returns UserNotFoundError if no such User exists or the User has been deactivated.
+//
+// userID is the unique identifier of the User whose preferences will be described.
+func (s *NotificationService) GetPreferences(userID types.UserID) (*types.NotificationPreferencesResponse, error) {
+	u, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.NotificationPreferencesResponse{
+		Preferences:    u.EffectivePreferences(),
+		EmailAvailable: s.dispatcher.Available(notifications.EMAIL),
+	}, nil
+}
+
+// UpdatePreferences changes how and when the User is notified.
+// Returns UserNotFoundError if no such User exists or the User has been deactivated.
+// Returns RequestValidationError if any of the preferences are not valid.
+//
+// userID is the unique identifier of the User whose preferences will be updated.
+//
+// req is the UpdateNotificationPreferencesRequest containing the User's new preferences.
+func (s *NotificationService) UpdatePreferences(userID types.UserID, req *types.UpdateNotificationPreferencesRequest) (*types.NotificationPreferencesResponse, error) {
+	// Validate the request
+	prefs := types.NotificationPreferences{
+		Channels:   make([]notifications.Channel, 0, len(req.Channels)),
+		Kinds:      make([]notifications.Kind, 0, len(req.Kinds)),
+		WebhookURL: strings.TrimSpace(req.WebhookURL),
+		TimeZone:   strings.TrimSpace(req.TimeZone),
+		QuietStart: strings.TrimSpace(req.QuietStart),
+		QuietEnd:   strings.TrimSpace(req.QuietEnd),
+	}
+	fields := make(map[string]string)
+	for _, ch := range notifications.Channels {
+		if slices.Contains(req.Channels, ch) {
+			prefs.Channels = append(prefs.Channels, ch)
+		}
+	}
+	for _, k := range notifications.Kinds {
+		if slices.Contains(req.Kinds, k) {
+			prefs.Kinds = append(prefs.Kinds, k)
+		}
+	}
+	for _, ch := range req.Channels {
+		if !slices.Contains(notifications.Channels, ch) {
+			fields["channels"] = fmt.Sprintf("unknown channel %s", ch)
+		}
+	}
+	if prefs.Uses(notifications.EMAIL) && !s.dispatcher.Available(notifications.EMAIL) {
+		fields["channels"] = "email notifications are not available on this site"
+	}
+	for _, k := range req.Kinds {
+		if !slices.Contains(notifications.Kinds, k) {
+			fields["kinds"] = fmt.Sprintf("unknown notification kind %s", k)
+		}
+	}
+	if prefs.Uses(notifications.WEBHOOK) || len(prefs.WebhookURL) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), env.Timeout)
+		if err := notify.ValidateWebhookURL(ctx, prefs.WebhookURL); err != nil {
+			fields["webhookUrl"] = err.Error()
+		}
+		cancel()
+	}
+	if _, err := time.LoadLocation(prefs.TimeZone); err != nil || len(prefs.TimeZone) == 0 {
+		fields["timeZone"] = "must be a known time zone (e.g. - America/New_York)"
+	}
+	if len(prefs.QuietStart) > 0 || len(prefs.QuietEnd) > 0 {
+		if _, err := time.Parse(types.QuietHoursLayout, prefs.QuietStart); err != nil {
+			fields["quietStart"] = "must be a time of day (e.g. - 22:00)"
+		}
+		if _, err := time.Parse(types.QuietHoursLayout, prefs.QuietEnd); err != nil {
+			fields["quietEnd"] = "must be a time of day (e.g. - 07:00)"
+		} else if prefs.QuietStart == prefs.QuietEnd {
+			fields["quietEnd"] = "must be different from the start of quiet hours"
+		}
+	}
+	if len(fields) > 0 {
+		return nil, &types.RequestValidationError{Fields: fields}
+	}
+
+	// Load the User and update their preferences
+	u, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	u.Preferences = &prefs
+	u.MutedNotifications = nil
+	if err := s.userRepo.Update(u); err != nil {
+		return nil, err
+	}
+
+	return &types.NotificationPreferencesResponse{
+		Preferences:    prefs,
+		EmailAvailable: s.dispatcher.Available(notifications.EMAIL),
+		Saved:          true,
+	}, nil
+}
+
+// SetMuted opts the User out of (or back in to) Notifications of the specified Kind, leaving the rest of their
+// preferences unchanged. Superseded by UpdatePreferences.
+// Returns UserNotFoundError if no such User exists or the User has been deactivated.
+// Returns RequestValidationError if the Kind is not valid.
+//
+// userID is the unique identifier of the User whose preferences will be updated.
+//
+// req is the MuteNotificationsRequest containing the Kind of Notification and whether it is muted.
+func (s *NotificationService) SetMuted(userID types.UserID, req *types.MuteNotificationsRequest) (*types.User, error) {
+	// Validate the Kind
+	if !slices.Contains(notifications.Kinds, req.Kind) {
+		return nil, &types.RequestValidationError{Fields: map[string]string{
+			"kind": fmt.Sprintf("unknown notification kind %s", req.Kind),
+		}}
+	}
+
+	// Load the User
+	u, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the preference is unchanged, do nothing and return
+	prefs := u.EffectivePreferences()
+	if prefs.Receives(req.Kind) != req.Muted {
+		return u, nil
+	}
+
+	// Update the Kinds of Notification that the User receives
+	kinds := make([]notifications.Kind, 0, len(notifications.Kinds))
+	for _, k := range notifications.Kinds {
+		if k == req.Kind && !req.Muted || k != req.Kind && prefs.Receives(k) {
+			kinds = append(kinds, k)
+		}
+	}
+	prefs.Kinds = kinds
+	u.Preferences = &prefs
+	u.MutedNotifications = nil
+	if err := s.userRepo.Update(u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// dispatchAll sends a copy of the Notification to each of the Users. Returns the number of Notifications sent.
+func (s *NotificationService) dispatchAll(userIDs map[types.UserID]struct{}, n *types.Notification) (int, error) {
+	sent := 0
+	for userID := range userIDs {
+		c := *n
+		ok, err := s.dispatcher.Dispatch(userID, &c)
+		if err != nil {
+			return sent, err
+		}
+		if ok {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// getUser returns the active User with the provided ID.
+func (s *NotificationService) getUser(userID types.UserID) (*types.User, error) {
+	u, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil || !u.Active {
+		return nil, &types.UserNotFoundError{ID: userID}
+	}
+
+	return u, nil
+}
+
+// findMissingPicks describes the ACTIVE Contestants that have not Selected a pick for the Schedule, keyed by the
+// OWNERs and MANAGERs who can select one.
+func (s *NotificationService) findMissingPicks(sch *types.Schedule) (map[types.UserID][]string, error) {
+	entries, err := s.entryRepo.GetBySchedule(sch.ID)
+	if err != nil {
+		return nil, err
+	}
+	picked := make(map[types.ContestantID]bool, len(entries))
+	for _, e := range entries {
+		if e.Active && len(e.SelectedPick) > 0 {
+			picked[e.Contestant] = true
+		}
+	}
+
+	pools, err := s.poolService.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	missing := make(map[types.UserID][]string)
+	seen := make(map[types.ContestantID]bool)
+	for _, p := range pools {
+		if p.Complete {
+			continue
+		}
+		cons, err := s.conService.GetByPool(p.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range cons {
+			if seen[c.ID] || !c.Active || c.Status != status.ACTIVE || picked[c.ID] {
+				continue
+			}
+			seen[c.ID] = true
+			for userID, role := range c.AuthorizedUsers {
+				if role == roles.OWNER || role == roles.MANAGER {
+					missing[userID] = append(missing[userID], c.Name)
+				}
+			}
+		}
+	}
+
+	return missing, nil
+}
+
+// describeOffset describes the duration before a pick deadline in whole hours or minutes (e.g. - "24 hours").
+func describeOffset(d time.Duration) string {
+	n, unit := int(d/time.Minute), "minute"
+	if d >= time.Hour {
+		n, unit = int(d/time.Hour), "hour"
+	}
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// joinNames joins names into a list for display (e.g. - "A, B and C").
+func joinNames(names []string) string {
+	if len(names) <= 1 {
+		return strings.Join(names, "")
+	}
+
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// authorizedUsers returns the set of Users authorized for the Contestant.
+func authorizedUsers(c *types.Contestant) map[types.UserID]struct{} {
+	users := make(map[types.UserID]struct{}, len(c.AuthorizedUsers))
+	for userID := range c.AuthorizedUsers {
+		users[userID] = struct{}{}
+	}
+
+	return users
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mhs294/mulhall/internals/env"
+	"github.com/mhs294/mulhall/internals/oidc"
+	"github.com/mhs294/mulhall/internals/repos"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/utils"
+	"golang.org/x/oauth2"
+)
+
+// OIDCService represents a service for logging Users in through external OpenID Connect identity providers.
+type OIDCService struct {
+	providers   []*oidc.Provider
+	stateRepo   *repos.OIDCStateRepository
+	userService *UserService
+}
+
+// NewOIDCService creates a new instance of an OIDCService and returns a pointer to it.
+//
+// p is the list of configured identity Providers that Users can log in with.
+//
+// r is the OIDCStateRepository that will be used to manage in-progress logins in the database.
+//
+// us is the UserService that will be used to issue Sessions for authenticated Users.
+func NewOIDCService(p []*oidc.Provider, r *repos.OIDCStateRepository, us *UserService) *OIDCService {
+	return &OIDCService{providers: p, stateRepo: r, userService: us}
+}
+
+// GetProviders returns the identity providers that Users can log in with.
+func (s *OIDCService) GetProviders() []types.OIDCProviderResponse {
+	res := make([]types.OIDCProviderResponse, 0, len(s.providers))
+	for _, p := range s.providers {
+		res = append(res, types.OIDCProviderResponse{
+			Name:        p.Name(),
+			DisplayName: p.DisplayName(),
+			LoginURL:    fmt.Sprintf("/auth/oidc/%s/login", p.Name()),
+		})
+	}
+
+	return res
+}
+
+// Begin starts a new login with the specified identity provider, returning the URL the User should be
+// redirected to along with the opaque state value that the identity provider will return to the callback.
+// Returns OIDCProviderNotFoundError if no such identity provider is configured.
+//
+// name is the short name of the identity provider to log in with.
+func (s *OIDCService) Begin(name string) (string, *types.OIDCState, error) {
+	p, err := s.getProvider(name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Generate and store the secrets used to correlate the callback with this login attempt
+	state, err := utils.CreateSecureAlphaNumToken(32)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate oidc state: %v", err)
+	}
+	nonce, err := utils.CreateSecureAlphaNumToken(32)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate oidc nonce: %v", err)
+	}
+	st := &types.OIDCState{
+		State:      state,
+		Provider:   p.Name(),
+		Nonce:      nonce,
+		Verifier:   oauth2.GenerateVerifier(),
+		Expiration: time.Now().UTC().Add(env.OIDCStateExpiration),
+	}
+	if err = s.stateRepo.Insert(st); err != nil {
+		return "", nil, err
+	}
+
+	// Build the identity provider's authorization URL
+	ctx, cancel := context.WithTimeout(context.Background(), env.Timeout)
+	defer cancel()
+	url, err := p.AuthCodeURL(ctx, st.State, st.Nonce, st.Verifier)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return url, st, nil
+}
+
+// Complete finishes a login with the specified identity provider by exchanging the authorization code,
+// verifying the returned identity and issuing a Session for the corresponding User (see UserService.LoginExternal).
+// Returns OIDCProviderNotFoundError if no such identity provider is configured.
+// Returns OIDCStateInvalidError if the state is unknown, expired, already used or belongs to another provider.
+// Returns OIDCEmailNotVerifiedError if the identity provider has not verified the User's email address.
+// Returns OIDCAccountNotFoundError if no active User or Invite exists for the email address.
+//
+// name is the short name of the identity provider that the login was started with.
+//
+// state is the opaque state value returned to the callback by the identity provider.
+//
+// code is the authorization code returned to the callback by the identity provider.
+func (s *OIDCService) Complete(name string, state string, code string) (*types.Session, error) {
+	p, err := s.getProvider(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load and consume the login attempt so that the state cannot be replayed
+	st, err := s.stateRepo.GetByState(state)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.stateRepo.Delete(state); err != nil {
+		return nil, err
+	}
+	if len(st.State) == 0 || st.Provider != p.Name() || st.Expiration.Before(time.Now().UTC()) {
+		return nil, &types.OIDCStateInvalidError{State: state}
+	}
+
+	// Exchange the authorization code and verify the returned identity
+	ctx, cancel := context.WithTimeout(context.Background(), env.Timeout)
+	defer cancel()
+	id, err := p.Authenticate(ctx, code, st.Nonce, st.Verifier)
+	if err != nil {
+		return nil, err
+	}
+	if len(id.Email) == 0 || !id.EmailVerified {
+		return nil, &types.OIDCEmailNotVerifiedError{Email: id.Email}
+	}
+
+	sess, err := s.userService.LoginExternal(id.Email)
+	if _, ok := err.(*types.UserNotFoundError); ok {
+		return nil, &types.OIDCAccountNotFoundError{Email: id.Email}
+	}
+
+	return sess, err
+}
+
+func (s *OIDCService) getProvider(name string) (*oidc.Provider, error) {
+	for _, p := range s.providers {
+		if p.Name() == name {
+			return p, nil
+		}
+	}
+
+	return nil, &types.OIDCProviderNotFoundError{Name: name}
+}
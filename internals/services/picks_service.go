@@ -0,0 +1,372 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mhs294/mulhall/internals/repos"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/outcomes"
+	"github.com/mhs294/mulhall/internals/types/status"
+)
+
+// PicksService represents a service for describing the picks that Contestants can make for each week's Schedule.
+type PicksService struct {
+	conService   *ContestantService
+	schService   *ScheduleService
+	entryService *EntryService
+	entryRepo    *repos.EntryRepository
+	teamRepo     *repos.TeamRepository
+	userRepo     *repos.UserRepository
+}
+
+// NewPicksService creates a new instance of a PicksService and returns a pointer to it.
+//
+// cs is the ContestantService used to load the Contestants that Users are authorized for.
+//
+// ss is the ScheduleService used to load the Schedule for each week.
+//
+// es is the EntryService used to load Contestants' Entries and the Teams they have already picked.
+//
+// er is the EntryRepository used to load the Entries of each Contestant's season.
+//
+// tr is the TeamRepository used to load the details of the Teams in each Matchup.
+//
+// ur is the UserRepository used to load the Users who suggested each pick.
+func NewPicksService(cs *ContestantService, ss *ScheduleService, es *EntryService, er *repos.EntryRepository, tr *repos.TeamRepository, ur *repos.UserRepository) *PicksService {
+	return &PicksService{
+		conService:   cs,
+		schService:   ss,
+		entryService: es,
+		entryRepo:    er,
+		teamRepo:     tr,
+		userRepo:     ur,
+	}
+}
+
+// GetWeeklyPicks describes the picks that a Contestant can make for the Schedule of the week containing the
+// provided date/time, on behalf of one of its authorized Users. The Schedule of the response is nil if there is
+// no Schedule for the week, and the Contestant is nil if the User is not a member of any Contestant.
+// Returns ContestantNotFoundError if no such Contestant exists.
+// Returns ContestantForbiddenError if the acting User is not authorized for the Contestant.
+//
+// actorID is the unique identifier of the User requesting the picks.
+//
+// conID is the unique identifier of the Contestant making the picks. If empty, the first Contestant that the
+// User is authorized for is used.
+//
+// at is the [time.Time] that falls within the week of the Schedule to describe.
+func (s *PicksService) GetWeeklyPicks(actorID types.UserID, conID types.ContestantID, at time.Time) (*types.WeeklyPicksResponse, error) {
+	// Load the acting User's Contestant memberships
+	memberships, err := s.getMemberships(actorID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &types.WeeklyPicksResponse{Memberships: memberships}
+	if len(conID) == 0 {
+		if len(resp.Memberships) == 0 {
+			return resp, nil
+		}
+		conID = resp.Memberships[0].Contestant
+	}
+
+	// Load the Contestant on behalf of the acting User
+	c, err := s.conService.GetAsMember(actorID, conID)
+	if err != nil {
+		return nil, err
+	}
+	resp.Contestant = c
+	resp.Role = c.AuthorizedUsers[actorID]
+
+	// Load the week's Schedule, if there is one
+	sch, err := s.schService.GetByDateTime(at)
+	if err != nil {
+		if _, ok := err.(*types.ScheduleNotFoundError); ok {
+			return resp, nil
+		}
+		return nil, err
+	}
+
+	// Load (or start) the Contestant's Entry for the Schedule
+	e, err := s.entryService.GetBySchedule(actorID, conID, sch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.describePicks(actorID, resp, sch, e); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetEntryPicks describes the picks that a Contestant can make for the Schedule of the provided Entry, on behalf
+// of one of its authorized Users.
+// Returns ContestantNotFoundError or ScheduleNotFoundError if the Entry's Contestant or Schedule does not exist.
+// Returns ContestantForbiddenError if the acting User is not authorized for the Entry's Contestant.
+//
+// actorID is the unique identifier of the User requesting the picks.
+//
+// e is the Entry containing the Contestant's picks.
+func (s *PicksService) GetEntryPicks(actorID types.UserID, e *types.Entry) (*types.WeeklyPicksResponse, error) {
+	// Load the acting User's Contestant memberships
+	memberships, err := s.getMemberships(actorID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &types.WeeklyPicksResponse{Memberships: memberships}
+
+	// Load the Contestant on behalf of the acting User
+	c, err := s.conService.GetAsMember(actorID, e.Contestant)
+	if err != nil {
+		return nil, err
+	}
+	resp.Contestant = c
+	resp.Role = c.AuthorizedUsers[actorID]
+
+	// Load the Entry's Schedule
+	sch, err := s.schService.GetByID(e.Schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.describePicks(actorID, resp, sch, e); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetSeasonPicks describes a Contestant's Selected pick and its Outcome for each week of a season, on behalf of
+// one of its authorized Users. The Contestant of the response is nil if the User is not a member of any Contestant.
+// Returns ContestantNotFoundError if no such Contestant exists.
+// Returns ContestantForbiddenError if the acting User is not authorized for the Contestant.
+//
+// actorID is the unique identifier of the User requesting the picks.
+//
+// conID is the unique identifier of the Contestant whose picks will be described. If empty, the first Contestant
+// that the User is authorized for is used.
+//
+// year is the integer value of the season's starting year (e.g. - 2024 for the 2024-2025 season). If zero, the
+// current season is used.
+func (s *PicksService) GetSeasonPicks(actorID types.UserID, conID types.ContestantID, year int) (*types.SeasonPicksResponse, error) {
+	// Load the acting User's Contestant memberships
+	memberships, err := s.getMemberships(actorID)
+	if err != nil {
+		return nil, err
+	}
+	if year == 0 {
+		if year, err = s.GetCurrentSeason(); err != nil {
+			return nil, err
+		}
+	}
+	resp := &types.SeasonPicksResponse{Memberships: memberships, Year: year}
+	if len(conID) == 0 {
+		if len(memberships) == 0 {
+			return resp, nil
+		}
+		conID = memberships[0].Contestant
+	}
+
+	// Load the Contestant on behalf of the acting User
+	c, err := s.conService.GetAsMember(actorID, conID)
+	if err != nil {
+		return nil, err
+	}
+	resp.Contestant = c
+
+	// Describe each week of the season
+	resp.Weeks, resp.EliminatedWeek, err = s.GetSeasonHistory(conID, year)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetSeasonHistory describes the Contestant's Selected pick and its Outcome for each week of a season, sorted by
+// week. Also returns the week in which the Contestant was eliminated (by losing or missing a pick), or 0 if the
+// Contestant has not been eliminated.
+//
+// conID is the unique identifier of the Contestant whose picks will be described.
+//
+// year is the integer value of the season's starting year (e.g. - 2024 for the 2024-2025 season).
+func (s *PicksService) GetSeasonHistory(conID types.ContestantID, year int) ([]types.WeekPickResponse, int, error) {
+	// Load the season's Schedules and the Contestant's Entries
+	schedules, err := s.schService.GetByYear(year)
+	if err != nil {
+		return nil, 0, err
+	}
+	entries, err := s.entryRepo.GetByContestant(conID)
+	if err != nil {
+		return nil, 0, err
+	}
+	bySchedule := make(map[types.ScheduleID]types.Entry, len(entries))
+	for _, e := range entries {
+		bySchedule[e.Schedule] = e
+	}
+
+	// Describe the Outcome of each week, noting the first week that eliminated the Contestant
+	now := time.Now()
+	eliminated := 0
+	weeks := make([]types.WeekPickResponse, 0, len(schedules))
+	for _, sch := range schedules {
+		w := types.WeekPickResponse{Week: sch.Week, Schedule: sch.ID, Outcome: outcomes.PENDING}
+		for mID, teamID := range bySchedule[sch.ID].SelectedPick {
+			t, err := s.teamRepo.GetByID(teamID)
+			if err != nil {
+				return nil, 0, err
+			}
+			w.Pick = &types.PickResponse{Matchup: mID, Team: t}
+			w.Outcome = outcome(sch.Matchups[mID], teamID)
+		}
+		if w.Pick == nil && now.After(sch.Closes) {
+			w.Outcome = outcomes.MISSED
+		}
+
+		if eliminated == 0 && (w.Outcome == outcomes.LOST || w.Outcome == outcomes.MISSED) {
+			eliminated = sch.Week
+		}
+		w.Eliminated = eliminated > 0
+		weeks = append(weeks, w)
+	}
+
+	return weeks, eliminated, nil
+}
+
+// GetCurrentSeason returns the starting year of the current season: the season of the current week's Schedule if
+// there is one, or otherwise the season that the current date falls within (seasons end by March).
+func (s *PicksService) GetCurrentSeason() (int, error) {
+	now := time.Now()
+	sch, err := s.schService.GetByDateTime(now)
+	if err == nil {
+		return sch.Year, nil
+	} else if _, ok := err.(*types.ScheduleNotFoundError); !ok {
+		return 0, err
+	}
+
+	if now.Month() < time.March {
+		return now.Year() - 1, nil
+	}
+	return now.Year(), nil
+}
+
+// getMemberships describes the acting User's Contestant memberships.
+func (s *PicksService) getMemberships(actorID types.UserID) ([]types.MembershipResponse, error) {
+	cons, err := s.conService.GetByAuthorizedUser(actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships := make([]types.MembershipResponse, 0, len(cons))
+	for _, c := range cons {
+		memberships = append(memberships, types.MembershipResponse{
+			Contestant: c.ID,
+			Name:       c.Name,
+			Role:       c.AuthorizedUsers[actorID],
+		})
+	}
+
+	return memberships, nil
+}
+
+// describePicks adds the Schedule's Matchups and the Entry's picks to the WeeklyPicksResponse, as seen by the acting
+// User.
+func (s *PicksService) describePicks(actorID types.UserID, resp *types.WeeklyPicksResponse, sch *types.Schedule, e *types.Entry) error {
+	now := time.Now()
+	resp.Schedule = sch
+	resp.Entry = e
+	resp.Open = sch.IsOpen(now) && resp.Contestant.Status == status.ACTIVE
+
+	// Load the Teams that the Contestant has already picked this season
+	used, err := s.entryService.GetUsedTeams(resp.Contestant.ID, sch)
+	if err != nil {
+		return err
+	}
+	resp.UsedTeams = used
+
+	// Describe each Matchup in order of kickoff
+	resp.Matchups = make([]types.MatchupPicksResponse, 0, len(sch.Matchups))
+	for id, m := range sch.Matchups {
+		away, err := s.teamRepo.GetByID(m.AwayTeam)
+		if err != nil {
+			return err
+		}
+		home, err := s.teamRepo.GetByID(m.HomeTeam)
+		if err != nil {
+			return err
+		}
+		resp.Matchups = append(resp.Matchups, types.MatchupPicksResponse{
+			ID:       id,
+			AwayTeam: away,
+			HomeTeam: home,
+			DateTime: m.DateTime,
+			Locked:   m.IsLocked(now),
+		})
+	}
+	sort.Slice(resp.Matchups, func(i, j int) bool {
+		if !resp.Matchups[i].DateTime.Equal(resp.Matchups[j].DateTime) {
+			return resp.Matchups[i].DateTime.Before(resp.Matchups[j].DateTime)
+		}
+		return resp.Matchups[i].ID < resp.Matchups[j].ID
+	})
+
+	// If no Entry exists, there are no picks to describe
+	resp.SuggestedPicks = make([]types.PickResponse, 0)
+	if e == nil {
+		return nil
+	}
+
+	// Describe the Selected pick
+	for mID, teamID := range e.SelectedPick {
+		t, err := s.teamRepo.GetByID(teamID)
+		if err != nil {
+			return err
+		}
+		resp.SelectedPick = &types.PickResponse{Matchup: mID, Team: t}
+	}
+
+	// Describe the Suggested picks along with the Users who suggested them
+	userIDs := make([]types.UserID, 0, len(e.SuggestedBy))
+	for _, userID := range e.SuggestedBy {
+		userIDs = append(userIDs, userID)
+	}
+	users, err := s.userRepo.GetByIDs(userIDs)
+	if err != nil {
+		return err
+	}
+	emails := make(map[types.UserID]string, len(users))
+	for _, u := range users {
+		emails[u.ID] = u.Email
+	}
+	for _, m := range resp.Matchups {
+		teamID, exists := e.SuggestedPicks[m.ID]
+		if !exists {
+			continue
+		}
+		t, err := s.teamRepo.GetByID(teamID)
+		if err != nil {
+			return err
+		}
+		resp.SuggestedPicks = append(resp.SuggestedPicks, types.PickResponse{
+			Matchup:     m.ID,
+			Team:        t,
+			SuggestedBy: emails[e.SuggestedBy[m.ID]],
+			Removable:   resp.CanManage() || e.SuggestedBy[m.ID] == actorID,
+		})
+	}
+
+	return nil
+}
+
+// outcome determines the Outcome of picking the Team in the Matchup. Ties are counted as losses.
+func outcome(m types.Matchup, teamID types.TeamID) outcomes.Outcome {
+	switch {
+	case m.Result == nil:
+		return outcomes.PENDING
+	case m.Winner() == teamID:
+		return outcomes.WON
+	default:
+		return outcomes.LOST
+	}
+}
@@ -45,6 +45,7 @@ func (s *PoolService) GetAll() ([]types.Pool, error) {
 }
 
 // GetByID gets the Pool for the provided ID.
+// Returns PoolNotFoundError if no such Pool exists or that Pool has been deactivated.
 //
 // id is the unique identifier of the Pool to look up.
 func (s *PoolService) GetByID(id types.PoolID) (*types.Pool, error) {
@@ -63,13 +64,14 @@ func (s *PoolService) GetByID(id types.PoolID) (*types.Pool, error) {
 }
 
 // AddContestant adds the specified Contestant to the specified Pool.
+// Returns PoolNotFoundError if no such Pool exists.
 //
 // poolID is the unique identifier of the Pool to update.
 //
 // conID is the unique identifier of the Contestant to add to the Pool.
 func (s *PoolService) AddContestant(poolID types.PoolID, conID types.ContestantID) error {
 	// Load the Pool from the database
-	p, err := s.repo.GetByID(poolID)
+	p, err := s.GetByID(poolID)
 	if err != nil {
 		return err
 	}
@@ -89,13 +91,14 @@ func (s *PoolService) AddContestant(poolID types.PoolID, conID types.ContestantI
 }
 
 // RemoveContestant removes the specified Contestant from the specified Pool.
+// Returns PoolNotFoundError if no such Pool exists.
 //
 // poolID is the unique identifier of the Pool to update.
 //
 // conID is the unique identifier of the Contestant to remove from the Pool.
 func (s *PoolService) RemoveContestant(poolID types.PoolID, conID types.ContestantID) error {
 	// Load the Pool from the database
-	p, err := s.repo.GetByID(poolID)
+	p, err := s.GetByID(poolID)
 	if err != nil {
 		return err
 	}
@@ -115,11 +118,12 @@ func (s *PoolService) RemoveContestant(poolID types.PoolID, conID types.Contesta
 }
 
 // Complete marks the specified Pool as complete (i.e. - its contest has concluded)
+// Returns PoolNotFoundError if no such Pool exists.
 //
 // id is the unique identifier of the Pool to mark as complete.
 func (s *PoolService) Complete(id types.PoolID) error {
 	// Load the Pool from the database
-	p, err := s.repo.GetByID(id)
+	p, err := s.GetByID(id)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,565 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mhs294/mulhall/internals/ical"
+	"github.com/mhs294/mulhall/internals/repos"
+	"github.com/mhs294/mulhall/internals/types"
+)
+
+// importKickoffLayouts are the layouts accepted for kickoff date/times without a UTC offset, which are read in
+// Eastern US time.
+var importKickoffLayouts = []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02T15:04:05"}
+
+// importColumns are the columns of a schedule CSV file, which must be named in its header row.
+var importColumns = []string{"year", "week", "kickoff", "away", "home"}
+
+// ScheduleImportService represents a service for importing a season's Schedules and Matchups from a file.
+type ScheduleImportService struct {
+	schService *ScheduleService
+	teamRepo   *repos.TeamRepository
+}
+
+// NewScheduleImportService creates a new instance of a ScheduleImportService and returns a pointer to it.
+//
+// ss is the ScheduleService used to create the imported Schedules and add or update their Matchups.
+//
+// tr is the TeamRepository used to resolve the Team shorthands in the imported file.
+func NewScheduleImportService(ss *ScheduleService, tr *repos.TeamRepository) *ScheduleImportService {
+	return &ScheduleImportService{schService: ss, teamRepo: tr}
+}
+
+// Parse reads the games of a season from a CSV, JSON or iCalendar file, determining the format from the file's
+// extension. Teams may be named by their shorthand (e.g. - "BUF"), name (e.g. - "Bills") or both their location and
+// name (e.g. - "Buffalo Bills").
+// Returns ScheduleImportInvalidError if the file type is not supported or the file cannot be parsed.
+//
+// CSV files must have a header row naming the year, week, kickoff, away and home columns. JSON files must contain
+// an array of objects with the same fields. Kickoffs are RFC 3339 date/times, or date/times without a UTC offset
+// (e.g. - "2024-09-08 13:00") in Eastern US time.
+//
+// iCalendar files contain a VEVENT per game, starting at its kickoff and summarized as "Away at Home" (or
+// "Away @ Home", or "Home vs Away"). The week is read from a "Week N" label in the event's summary, description or
+// categories. Games without a label are numbered by calendar week within their season, so calendars without
+// labels must contain the whole season.
+//
+// name is the name of the file, whose extension (".csv", ".json" or ".ics") determines its format.
+//
+// r is the [io.Reader] from which the contents of the file are read.
+func (s *ScheduleImportService) Parse(name string, r io.Reader) ([]types.ImportedGame, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return parseGamesCSV(r)
+	case ".json":
+		return parseGamesJSON(r)
+	case ".ics":
+		return parseGamesICS(r)
+	default:
+		return nil, &types.ScheduleImportInvalidError{Reason: fmt.Sprintf("unsupported file type %q (expected .csv, .json or .ics)", filepath.Ext(name))}
+	}
+}
+
+// Import creates a Schedule for each week of the provided games that does not have one yet, and adds (or updates
+// the kickoff of) each game's Matchup. Games are matched to existing Matchups by their away and home Teams, so
+// importing the same games again changes nothing. Existing Matchups that are not among the games are left as they
+// are. All of the games are validated before any changes are made.
+// Returns ScheduleImportInvalidError if any of the games are invalid or would conflict with an existing Matchup.
+// Returns ScheduleConflictError if a new week's Schedule would overlap with the Schedule of a different week.
+//
+// New Schedules span the calendar week (from 03:00 EST/EDT on Tuesday) containing the week's first kickoff, and
+// close at the week's last kickoff.
+//
+// games is the slice of ImportedGames describing the season.
+//
+// dryRun indicates whether the changes should only be described, without being made.
+func (s *ScheduleImportService) Import(games []types.ImportedGame, dryRun bool) (*types.ScheduleImportResponse, error) {
+	tz, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return nil, fmt.Errorf("failed to import schedule: %v", err)
+	}
+
+	// Resolve the Team shorthands and group the games by week
+	teams, err := s.teamRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]types.Team, len(teams)*3)
+	byID := make(map[types.TeamID]types.Team, len(teams))
+	for _, t := range teams {
+		byName[strings.ToUpper(t.Shorthand)] = t
+		byName[strings.ToUpper(t.Name)] = t
+		byName[strings.ToUpper(t.Location+" "+t.Name)] = t
+		byID[t.ID] = t
+	}
+	weeks := make(map[[2]int][]types.ImportedGame)
+	for _, g := range games {
+		if err := validateGame(g, byName); err != nil {
+			return nil, err
+		}
+		key := [2]int{g.Year, g.Week}
+		weeks[key] = append(weeks[key], g)
+	}
+	if len(weeks) == 0 {
+		return nil, &types.ScheduleImportInvalidError{Reason: "the file contains no games"}
+	}
+	keys := make([][2]int, 0, len(weeks))
+	for key := range weeks {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+
+	// Describe the changes to each week before making any of them
+	resp := &types.ScheduleImportResponse{DryRun: dryRun, Weeks: make([]types.WeekImportResponse, 0, len(keys))}
+	schedules := make([]*types.Schedule, 0, len(keys))
+	created := make(map[time.Time]int, len(keys))
+	for _, key := range keys {
+		sch, w, err := s.diffWeek(weeks[key], byName, byID, tz)
+		if err != nil {
+			return nil, err
+		}
+
+		// New Schedules must not overlap with each other either
+		if w.Created {
+			start := weekStart(w.Added[0].Kickoff, tz)
+			if week, exists := created[start]; exists {
+				return nil, &types.ScheduleImportInvalidError{
+					Line:   weeks[key][0].Line,
+					Reason: fmt.Sprintf("week %d falls within the same calendar week as week %d", w.Week, week),
+				}
+			}
+			created[start] = w.Week
+		}
+		schedules = append(schedules, sch)
+		resp.Weeks = append(resp.Weeks, *w)
+	}
+	if dryRun {
+		return resp, nil
+	}
+
+	// Create the missing Schedules and add or update their Matchups
+	for i := range resp.Weeks {
+		if err := s.applyWeek(schedules[i], &resp.Weeks[i], byName, tz); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// diffWeek describes the changes that importing a week's games would make to its Schedule, which is returned if
+// it already exists (or nil otherwise).
+func (s *ScheduleImportService) diffWeek(games []types.ImportedGame, byName map[string]types.Team, byID map[types.TeamID]types.Team, tz *time.Location) (*types.Schedule, *types.WeekImportResponse, error) {
+	sort.SliceStable(games, func(i, j int) bool {
+		return games[i].Kickoff.Before(games[j].Kickoff)
+	})
+	first, last := games[0], games[len(games)-1]
+	w := &types.WeekImportResponse{
+		Year:     first.Year,
+		Week:     first.Week,
+		Closes:   last.Kickoff,
+		Added:    make([]types.MatchupImportResponse, 0),
+		Updated:  make([]types.MatchupImportResponse, 0),
+		Unlisted: make([]types.MatchupImportResponse, 0),
+	}
+
+	// Load the week's Schedule, verifying that a new Schedule would not overlap with another week's
+	sch, err := s.schService.GetByYearAndWeek(w.Year, w.Week)
+	if err != nil {
+		if _, ok := err.(*types.ScheduleNotFoundError); !ok {
+			return nil, nil, err
+		}
+		sch = nil
+	}
+	start := weekStart(first.Kickoff, tz)
+	end := start.Add(7 * 24 * time.Hour).Add(-1 * time.Millisecond)
+	if sch == nil {
+		other, err := s.schService.GetByDateTime(first.Kickoff)
+		if err == nil {
+			return nil, nil, &types.ScheduleConflictError{Year: w.Year, Week: w.Week, ID: other.ID}
+		} else if _, ok := err.(*types.ScheduleNotFoundError); !ok {
+			return nil, nil, err
+		}
+		w.Created = true
+	} else {
+		w.Schedule = sch.ID
+		w.Closes = sch.Closes
+		start, end = sch.Start, sch.End
+	}
+
+	// Verify that every game falls within the week and that no Team plays twice
+	playing := make(map[types.TeamID]int, len(games)*2)
+	for _, g := range games {
+		if g.Kickoff.Before(start) || g.Kickoff.After(end) {
+			return nil, nil, &types.ScheduleImportInvalidError{
+				Line:   g.Line,
+				Reason: fmt.Sprintf("kickoff falls outside of week %d (%s to %s)", g.Week, start.Format(time.RFC3339), end.Format(time.RFC3339)),
+			}
+		}
+		for _, shorthand := range []string{g.AwayTeam, g.HomeTeam} {
+			t := byName[strings.ToUpper(shorthand)]
+			if line, exists := playing[t.ID]; exists {
+				return nil, nil, &types.ScheduleImportInvalidError{
+					Line:   g.Line,
+					Reason: fmt.Sprintf("%s already plays in week %d (line %d)", t.Shorthand, g.Week, line),
+				}
+			}
+			playing[t.ID] = g.Line
+		}
+	}
+
+	// Match the games to the existing Matchups by their Teams
+	listed := make(map[types.MatchupID]struct{}, len(games))
+	for _, g := range games {
+		away, home := byName[strings.ToUpper(g.AwayTeam)], byName[strings.ToUpper(g.HomeTeam)]
+		change := types.MatchupImportResponse{AwayTeam: away.Shorthand, HomeTeam: home.Shorthand, Kickoff: g.Kickoff}
+		mID, m, exists := findMatchup(sch, away.ID, home.ID)
+		switch {
+		case !exists:
+			w.Added = append(w.Added, change)
+		case !m.DateTime.Equal(g.Kickoff):
+			previous := m.DateTime
+			change.Matchup, change.PreviousKickoff = mID, &previous
+			w.Updated = append(w.Updated, change)
+		default:
+			w.Unchanged++
+		}
+		if exists {
+			listed[mID] = struct{}{}
+		}
+	}
+	if sch == nil {
+		return nil, w, nil
+	}
+
+	// Describe the existing Matchups that are not in the file, which must not feature any of the Teams being added
+	for id, m := range sch.Matchups {
+		if _, exists := listed[id]; exists {
+			continue
+		}
+		for _, teamID := range []types.TeamID{m.AwayTeam, m.HomeTeam} {
+			if line, conflict := playing[teamID]; conflict {
+				return nil, nil, &types.ScheduleImportInvalidError{
+					Line:   line,
+					Reason: fmt.Sprintf("%s already plays in another matchup of week %d", byID[teamID].Shorthand, w.Week),
+				}
+			}
+		}
+		w.Unlisted = append(w.Unlisted, types.MatchupImportResponse{
+			Matchup:  id,
+			AwayTeam: byID[m.AwayTeam].Shorthand,
+			HomeTeam: byID[m.HomeTeam].Shorthand,
+			Kickoff:  m.DateTime,
+		})
+	}
+	sort.Slice(w.Unlisted, func(i, j int) bool {
+		return w.Unlisted[i].Kickoff.Before(w.Unlisted[j].Kickoff)
+	})
+
+	return sch, w, nil
+}
+
+// applyWeek makes the changes described by the WeekImportResponse, creating the week's Schedule if it is nil.
+func (s *ScheduleImportService) applyWeek(sch *types.Schedule, w *types.WeekImportResponse, byName map[string]types.Team, tz *time.Location) error {
+	// Create the Schedule if it does not exist yet
+	if sch == nil {
+		var err error
+		sch, err = s.schService.CreateSchedule(&types.CreateScheduleRequest{
+			Year:   w.Year,
+			Week:   w.Week,
+			Date:   weekStart(w.Added[0].Kickoff, tz).Format(time.DateOnly),
+			Closes: w.Closes,
+		})
+		if err != nil {
+			return err
+		}
+		w.Schedule = sch.ID
+	}
+
+	// Add the new Matchups and update the kickoff of the existing ones
+	for i, change := range w.Added {
+		away, home := byName[strings.ToUpper(change.AwayTeam)], byName[strings.ToUpper(change.HomeTeam)]
+		updated, err := s.schService.AddMatchup(&types.CreateMatchupRequest{
+			ScheduleID: sch.ID,
+			Matchup:    &types.Matchup{AwayTeam: away.ID, HomeTeam: home.ID, DateTime: change.Kickoff},
+		})
+		if err != nil {
+			return err
+		}
+		w.Added[i].Matchup, _, _ = findMatchup(updated, away.ID, home.ID)
+	}
+	for _, change := range w.Updated {
+		away, home := byName[strings.ToUpper(change.AwayTeam)], byName[strings.ToUpper(change.HomeTeam)]
+		_, err := s.schService.UpdateMatchup(&types.UpdateMatchupRequest{
+			ScheduleID: sch.ID,
+			MatchupID:  change.Matchup,
+			Matchup:    &types.Matchup{AwayTeam: away.ID, HomeTeam: home.ID, DateTime: change.Kickoff},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findMatchup finds the Matchup between the away and home Teams within the Schedule (which may be nil).
+func findMatchup(sch *types.Schedule, away types.TeamID, home types.TeamID) (types.MatchupID, types.Matchup, bool) {
+	if sch == nil {
+		return "", types.Matchup{}, false
+	}
+	for id, m := range sch.Matchups {
+		if m.AwayTeam == away && m.HomeTeam == home {
+			return id, m, true
+		}
+	}
+
+	return "", types.Matchup{}, false
+}
+
+// weekStart returns the start (03:00 EST/EDT on Tuesday) of the calendar week containing the provided date/time.
+func weekStart(t time.Time, tz *time.Location) time.Time {
+	local := t.In(tz).Add(-3 * time.Hour)
+	days := (int(local.Weekday()) - int(time.Tuesday) + 7) % 7
+
+	return time.Date(local.Year(), local.Month(), local.Day()-days, 3, 0, 0, 0, tz)
+}
+
+func validateGame(g types.ImportedGame, byName map[string]types.Team) error {
+	invalid := func(reason string) error {
+		return &types.ScheduleImportInvalidError{Line: g.Line, Reason: reason}
+	}
+
+	if g.Year < 1920 {
+		return invalid(fmt.Sprintf("invalid year %d", g.Year))
+	}
+	if g.Week < 1 || g.Week > 22 {
+		return invalid(fmt.Sprintf("invalid week %d (must be between 1 and 22)", g.Week))
+	}
+	if g.Kickoff.IsZero() {
+		return invalid("kickoff is required")
+	}
+	for _, shorthand := range []string{g.AwayTeam, g.HomeTeam} {
+		if _, exists := byName[strings.ToUpper(shorthand)]; !exists {
+			return invalid(fmt.Sprintf("unknown team %q", shorthand))
+		}
+	}
+	if strings.EqualFold(g.AwayTeam, g.HomeTeam) {
+		return invalid("away and home teams must be different")
+	}
+
+	return nil
+}
+
+func parseGamesCSV(r io.Reader) ([]types.ImportedGame, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	// Locate each column by its name in the header row
+	header, err := reader.Read()
+	if err != nil {
+		return nil, &types.ScheduleImportInvalidError{Line: 1, Reason: fmt.Sprintf("failed to read header row: %v", err)}
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range importColumns {
+		if _, exists := columns[name]; !exists {
+			return nil, &types.ScheduleImportInvalidError{Line: 1, Reason: fmt.Sprintf("missing %q column", name)}
+		}
+	}
+
+	// Read each game
+	games := make([]types.ImportedGame, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			line := 0
+			if pe, ok := err.(*csv.ParseError); ok {
+				line = pe.Line
+			}
+			return nil, &types.ScheduleImportInvalidError{Line: line, Reason: err.Error()}
+		}
+		line, _ := reader.FieldPos(0)
+
+		g := types.ImportedGame{
+			AwayTeam: strings.TrimSpace(record[columns["away"]]),
+			HomeTeam: strings.TrimSpace(record[columns["home"]]),
+			Line:     line,
+		}
+		if g.Year, err = strconv.Atoi(strings.TrimSpace(record[columns["year"]])); err != nil {
+			return nil, &types.ScheduleImportInvalidError{Line: line, Reason: fmt.Sprintf("invalid year %q", record[columns["year"]])}
+		}
+		if g.Week, err = strconv.Atoi(strings.TrimSpace(record[columns["week"]])); err != nil {
+			return nil, &types.ScheduleImportInvalidError{Line: line, Reason: fmt.Sprintf("invalid week %q", record[columns["week"]])}
+		}
+		if g.Kickoff, err = parseKickoff(record[columns["kickoff"]]); err != nil {
+			return nil, &types.ScheduleImportInvalidError{Line: line, Reason: err.Error()}
+		}
+		games = append(games, g)
+	}
+
+	return games, nil
+}
+
+func parseGamesJSON(r io.Reader) ([]types.ImportedGame, error) {
+	// Kickoffs are decoded as strings so that date/times without a UTC offset are accepted
+	var records []struct {
+		Year    int    `json:"year"`
+		Week    int    `json:"week"`
+		Kickoff string `json:"kickoff"`
+		Away    string `json:"away"`
+		Home    string `json:"home"`
+	}
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, &types.ScheduleImportInvalidError{Reason: fmt.Sprintf("failed to parse json: %v", err)}
+	}
+
+	games := make([]types.ImportedGame, 0, len(records))
+	for i, rec := range records {
+		kickoff, err := parseKickoff(rec.Kickoff)
+		if err != nil {
+			return nil, &types.ScheduleImportInvalidError{Line: i + 1, Reason: err.Error()}
+		}
+		games = append(games, types.ImportedGame{
+			Year:     rec.Year,
+			Week:     rec.Week,
+			Kickoff:  kickoff,
+			AwayTeam: strings.TrimSpace(rec.Away),
+			HomeTeam: strings.TrimSpace(rec.Home),
+			Line:     i + 1,
+		})
+	}
+
+	return games, nil
+}
+
+func parseGamesICS(r io.Reader) ([]types.ImportedGame, error) {
+	tz, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return nil, err
+	}
+	events, err := ical.Parse(r, tz)
+	if err != nil {
+		return nil, &types.ScheduleImportInvalidError{Reason: fmt.Sprintf("failed to parse calendar: %v", err)}
+	}
+
+	// Read the Teams and week of each game, noting the calendar weeks of each season
+	games := make([]types.ImportedGame, 0, len(events))
+	seasonWeeks := make(map[int]map[time.Time]struct{})
+	for _, e := range events {
+		if e.Start.IsZero() {
+			return nil, &types.ScheduleImportInvalidError{Line: e.Line, Reason: "event has no start date/time"}
+		}
+		away, home, ok := parseMatchupSummary(e.Summary)
+		if !ok {
+			return nil, &types.ScheduleImportInvalidError{Line: e.Line, Reason: fmt.Sprintf("unable to read teams from summary %q", e.Summary)}
+		}
+
+		g := types.ImportedGame{Year: seasonOf(e.Start, tz), Kickoff: e.Start, AwayTeam: away, HomeTeam: home, Line: e.Line}
+		for _, text := range append([]string{e.Summary, e.Description}, e.Categories...) {
+			if m := weekLabelPattern.FindStringSubmatch(text); m != nil {
+				g.Week, _ = strconv.Atoi(m[1])
+				break
+			}
+		}
+		if seasonWeeks[g.Year] == nil {
+			seasonWeeks[g.Year] = make(map[time.Time]struct{})
+		}
+		seasonWeeks[g.Year][weekStart(g.Kickoff, tz)] = struct{}{}
+		games = append(games, g)
+	}
+
+	// Number the games without a week label by their calendar week within the season
+	for i, g := range games {
+		if g.Week > 0 {
+			continue
+		}
+		start := weekStart(g.Kickoff, tz)
+		for other := range seasonWeeks[g.Year] {
+			if !other.After(start) {
+				games[i].Week++
+			}
+		}
+	}
+
+	return games, nil
+}
+
+// weekLabelPattern matches a "Week N" label within the text of a calendar event.
+var weekLabelPattern = regexp.MustCompile(`(?i)\bweek\s+(\d{1,2})\b`)
+
+// awayAtHomePattern and homeVsAwayPattern match the Teams of a game within the summary of a calendar event.
+var (
+	awayAtHomePattern = regexp.MustCompile(`(?i)^(.+?)\s+(?:at|@)\s+(.+)$`)
+	homeVsAwayPattern = regexp.MustCompile(`(?i)^(.+?)\s+vs?\.?\s+(.+)$`)
+)
+
+// parseMatchupSummary reads the away and home Teams from the summary of a calendar event, ignoring any label before
+// a colon (e.g. - "Week 1: ") and any parenthetical notes (e.g. - " (Thursday Night Football)").
+func parseMatchupSummary(summary string) (string, string, bool) {
+	if _, rest, found := strings.Cut(summary, ": "); found {
+		summary = rest
+	}
+	if i := strings.Index(summary, " ("); i >= 0 {
+		summary = summary[:i]
+	}
+	summary = strings.TrimSpace(summary)
+
+	if m := awayAtHomePattern.FindStringSubmatch(summary); m != nil {
+		return strings.TrimSpace(m[1]), strings.TrimSpace(m[2]), true
+	}
+	if m := homeVsAwayPattern.FindStringSubmatch(summary); m != nil {
+		return strings.TrimSpace(m[2]), strings.TrimSpace(m[1]), true
+	}
+
+	return "", "", false
+}
+
+// seasonOf returns the starting year of the season in which the date/time falls (seasons end by March).
+func seasonOf(t time.Time, tz *time.Location) int {
+	local := t.In(tz)
+	if local.Month() < time.March {
+		return local.Year() - 1
+	}
+
+	return local.Year()
+}
+
+// parseKickoff parses an RFC 3339 date/time, or a date/time without a UTC offset in Eastern US time.
+func parseKickoff(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	tz, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, layout := range importKickoffLayouts {
+		if t, err := time.ParseInLocation(layout, value, tz); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid kickoff %q", value)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,20 +12,24 @@ import (
 
 // ScheduleService represents a service for managing weekly Schedules of Matchups available for picks.
 type ScheduleService struct {
-	repo *repos.ScheduleRepository
+	repo     *repos.ScheduleRepository
+	teamRepo *repos.TeamRepository
 }
 
 // NewScheduleService creates a new instance of a ScheduleService and returns a pointer to it.
 //
 // r is the ScheduleRepository used to manage Schedule records in the database.
-func NewScheduleService(r *repos.ScheduleRepository) *ScheduleService {
-	return &ScheduleService{repo: r}
+//
+// tr is the TeamRepository used to load the Teams available for each Schedule's Matchups.
+func NewScheduleService(r *repos.ScheduleRepository, tr *repos.TeamRepository) *ScheduleService {
+	return &ScheduleService{repo: r, teamRepo: tr}
 }
 
 // CreateSchedule creates a new Schedule from the provided information.
 // Returns an updated version of the Schedule model containing its ID after creation.
 // Returns ScheduleConflictException if the request contains information that matches with another
 // Schedule that already exists.
+// Returns ScheduleDateInvalidError if the request's Date is not an ISO-8601 date-only string.
 // Returns ScheduleInvalidClosesError if the request's Closes date/time falls outside the Schedule's
 // calendar week.
 //
@@ -48,7 +53,7 @@ func (s *ScheduleService) CreateSchedule(req *types.CreateScheduleRequest) (*typ
 	}
 	date, err := time.ParseInLocation(time.DateOnly, req.Date, tz)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create schedule: %v", err)
+		return nil, &types.ScheduleDateInvalidError{Date: req.Date}
 	}
 
 	// If required, normalize the Schedule date
@@ -61,7 +66,7 @@ func (s *ScheduleService) CreateSchedule(req *types.CreateScheduleRequest) (*typ
 	sch, err := s.repo.GetByDateTime(date)
 	if err != nil {
 		return nil, err
-	} else if sch != nil {
+	} else if sch.Active {
 		return nil, &types.ScheduleConflictError{Date: req.Date, ID: sch.ID}
 	}
 
@@ -69,7 +74,7 @@ func (s *ScheduleService) CreateSchedule(req *types.CreateScheduleRequest) (*typ
 	sch, err = s.repo.GetByYearAndWeek(req.Year, req.Week)
 	if err != nil {
 		return nil, err
-	} else if sch != nil {
+	} else if sch.Active {
 		return nil, &types.ScheduleConflictError{Year: req.Year, Week: req.Week, ID: sch.ID}
 	}
 
@@ -105,7 +110,80 @@ func (s *ScheduleService) CreateSchedule(req *types.CreateScheduleRequest) (*typ
 	return sch, nil
 }
 
+// UpdateCloses changes the date/time at which picks close for an existing Schedule.
+// Returns the updated state of the Schedule.
+// Returns ScheduleInvalidClosesError if the Closes date/time falls outside the Schedule's calendar week.
+//
+// req is the UpdateScheduleClosesRequest containing the new Closes date/time of the Schedule.
+func (s *ScheduleService) UpdateCloses(req *types.UpdateScheduleClosesRequest) (*types.Schedule, error) {
+	// Load the Schedule from the database
+	sch, err := s.GetByID(req.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the close date/time falls between the start and end date/times
+	if req.Closes.Before(sch.Start) || req.Closes.After(sch.End) {
+		return nil, &types.ScheduleInvalidClosesError{Start: sch.Start, End: sch.End}
+	}
+
+	// Update the Schedule
+	sch.Closes = req.Closes
+	if err = s.repo.Update(sch); err != nil {
+		return nil, err
+	}
+
+	return sch, nil
+}
+
+// DescribeEditor describes the Schedule as it is shown in the admin schedule editor, including the Teams that can
+// be added to its Matchups and the Teams that are on a bye (not featured in any Matchup) for the week.
+//
+// sch is the pointer to the Schedule to describe.
+func (s *ScheduleService) DescribeEditor(sch *types.Schedule) (*types.ScheduleEditorResponse, error) {
+	teams, err := s.teamRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[types.TeamID]types.Team, len(teams))
+	for _, t := range teams {
+		byID[t.ID] = t
+	}
+
+	// Describe each Matchup in order of kickoff, noting the Teams that are playing
+	resp := &types.ScheduleEditorResponse{Schedule: sch, Teams: teams, Matchups: make([]types.MatchupEditorResponse, 0, len(sch.Matchups))}
+	playing := make(map[types.TeamID]struct{}, len(sch.Matchups)*2)
+	for id, m := range sch.Matchups {
+		resp.Matchups = append(resp.Matchups, types.MatchupEditorResponse{
+			ID:       id,
+			AwayTeam: byID[m.AwayTeam],
+			HomeTeam: byID[m.HomeTeam],
+			DateTime: m.DateTime,
+			Result:   m.Result,
+		})
+		playing[m.AwayTeam] = struct{}{}
+		playing[m.HomeTeam] = struct{}{}
+	}
+	sort.Slice(resp.Matchups, func(i, j int) bool {
+		if !resp.Matchups[i].DateTime.Equal(resp.Matchups[j].DateTime) {
+			return resp.Matchups[i].DateTime.Before(resp.Matchups[j].DateTime)
+		}
+		return resp.Matchups[i].ID < resp.Matchups[j].ID
+	})
+
+	// Any Team that is not playing is on a bye
+	resp.Byes = make([]types.Team, 0)
+	for _, t := range teams {
+		if _, exists := playing[t.ID]; !exists {
+			resp.Byes = append(resp.Byes, t)
+		}
+	}
+
+	return resp, nil
+}
+
 // GetByID gets the Schedule for the provided ID.
+// Returns ScheduleNotFoundError if no such Schedule exists or that Schedule has been deactivated.
 //
 // id is the unique identifier of the Schedule to look up.
 func (s *ScheduleService) GetByID(id types.ScheduleID) (*types.Schedule, error) {
@@ -214,7 +292,8 @@ func (s *ScheduleService) UpdateMatchup(req *types.UpdateMatchupRequest) (*types
 		return nil, err
 	}
 
-	// Update the Matchup in the Schedule
+	// Update the Matchup in the Schedule (its Result can only be changed by RecordResult)
+	matchup.Result = sch.Matchups[req.MatchupID].Result
 	sch.Matchups[req.MatchupID] = *matchup
 	if err = s.repo.Update(sch); err != nil {
 		return nil, err
@@ -223,7 +302,60 @@ func (s *ScheduleService) UpdateMatchup(req *types.UpdateMatchupRequest) (*types
 	return sch, nil
 }
 
+// RecordResult records the final score of an existing Matchup in a Schedule, replacing any previously recorded score.
+// Returns the updated state of the Schedule containing the Matchup.
+// Returns MatchupNotFoundError if the Schedule does not contain the Matchup.
+// Returns MatchupInvalidError if the Matchup has not started yet.
+//
+// req is the RecordResultRequest containing the final score of the Matchup.
+func (s *ScheduleService) RecordResult(req *types.RecordResultRequest) (*types.Schedule, error) {
+	// Load the Schedule from the database
+	sch, err := s.GetByID(req.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the specified Matchup exists in the Schedule and has started
+	m, exists := sch.Matchups[req.MatchupID]
+	if !exists {
+		return nil, &types.MatchupNotFoundError{ScheduleID: req.ScheduleID, MatchupID: req.MatchupID}
+	}
+	if !m.IsLocked(time.Now()) {
+		return nil, &types.MatchupInvalidError{
+			ScheduleID: sch.ID,
+			Matchup:    &m,
+			Reason:     "matchup has not started yet",
+		}
+	}
+
+	// Record the Result of the Matchup
+	m.Result = &types.MatchupResult{AwayScore: req.AwayScore, HomeScore: req.HomeScore}
+	sch.Matchups[req.MatchupID] = m
+	if err = s.repo.Update(sch); err != nil {
+		return nil, err
+	}
+
+	return sch, nil
+}
+
+// GetByYear gets all active Schedules for the provided season, sorted by week.
+//
+// year is the integer value of the season's starting year (e.g. - 2024 for the 2024-2025 season).
+func (s *ScheduleService) GetByYear(year int) ([]types.Schedule, error) {
+	schedules, err := s.repo.GetByYear(year)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].Week < schedules[j].Week
+	})
+
+	return schedules, nil
+}
+
 // RemoveMatchup removes the Matchup with the provided ID from the specified Schedule.
+// Returns MatchupNotFoundError if the Schedule does not contain the Matchup.
 //
 // schID is the unique identifier of the Schedule containing the Matchup to remove.
 //
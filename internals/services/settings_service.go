@@ -0,0 +1,30 @@
+package services
+
+import (
+	"github.com/mhs294/mulhall/internals/repos"
+	"github.com/mhs294/mulhall/internals/types"
+)
+
+// SettingsService represents a service for managing site-wide Settings.
+type SettingsService struct {
+	repo *repos.SettingsRepository
+}
+
+// NewSettingsService creates a new instance of a SettingsService and returns a pointer to it.
+//
+// r is the SettingsRepository that will be used to manage the Settings record in the database.
+func NewSettingsService(r *repos.SettingsRepository) *SettingsService {
+	return &SettingsService{repo: r}
+}
+
+// Get returns the current site-wide Settings.
+func (s *SettingsService) Get() (*types.Settings, error) {
+	return s.repo.Get()
+}
+
+// SetRequireAdministratorMFA sets whether all Administrators must use a second authentication factor to log in.
+//
+// required indicates whether a second authentication factor is required for Administrators.
+func (s *SettingsService) SetRequireAdministratorMFA(required bool) error {
+	return s.repo.Set("requireadministratormfa", required)
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/outcomes"
+	"github.com/mhs294/mulhall/internals/types/standings"
+	"github.com/mhs294/mulhall/internals/types/status"
+)
+
+// StandingsService represents a service for describing the standings of the Contestants in each Pool.
+type StandingsService struct {
+	conService   *ContestantService
+	schService   *ScheduleService
+	picksService *PicksService
+}
+
+// NewStandingsService creates a new instance of a StandingsService and returns a pointer to it.
+//
+// cs is the ContestantService used to load the Pool whose standings are described, and the Contestants in it.
+//
+// ss is the ScheduleService used to load the season's Schedules, which determine when picks are published.
+//
+// pks is the PicksService used to load each Contestant's picks and their Outcomes for the season.
+func NewStandingsService(cs *ContestantService, ss *ScheduleService, pks *PicksService) *StandingsService {
+	return &StandingsService{conService: cs, schService: ss, picksService: pks}
+}
+
+// GetStandings describes the standing of each Contestant in a Pool for a season. A Contestant's pick for a week
+// is published (and counted among the Teams it has used) once that pick's Matchup has started or the week's
+// picks have closed, so that Contestants cannot copy each other's picks.
+// Returns PoolNotFoundError if no such Pool exists or that Pool has been deactivated.
+// Returns PoolForbiddenError if the acting User is not a member of the Pool.
+// Returns StatusInvalidError if the Status to filter by is not valid.
+// Returns StandingsSortInvalidError if the order to sort by is not valid.
+//
+// actorID is the unique identifier of the User requesting the standings.
+//
+// poolID is the unique identifier of the Pool whose standings will be described.
+//
+// year is the integer value of the season's starting year (e.g. - 2024 for the 2024-2025 season). If 0, the
+// current season is used.
+//
+// st is the Status of the Contestants to include. If empty, all Contestants are included.
+//
+// by is the order in which to sort the standings. If empty, the standings are sorted by STATUS.
+func (s *StandingsService) GetStandings(actorID types.UserID, poolID types.PoolID, year int, st status.Status, by standings.Sort) (*types.StandingsResponse, error) {
+	// Validate the filter and sort order
+	if len(st) > 0 {
+		if err := validateStatus(st); err != nil {
+			return nil, err
+		}
+	}
+	if len(by) == 0 {
+		by = standings.STATUS
+	}
+	if err := validateSort(by); err != nil {
+		return nil, err
+	}
+
+	// Load the Pool and the season's Schedules
+	p, err := s.conService.GetPoolAsMember(actorID, poolID)
+	if err != nil {
+		return nil, err
+	}
+	if year == 0 {
+		if year, err = s.picksService.GetCurrentSeason(); err != nil {
+			return nil, err
+		}
+	}
+	schedules, err := s.schService.GetByYear(year)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[types.ScheduleID]types.Schedule, len(schedules))
+	for _, sch := range schedules {
+		byID[sch.ID] = sch
+	}
+	current, err := s.schService.GetByDateTime(time.Now())
+	if err != nil {
+		if _, ok := err.(*types.ScheduleNotFoundError); !ok {
+			return nil, err
+		}
+		current = nil
+	}
+
+	// Describe the standing of each Contestant in the Pool
+	cons, err := s.conService.GetByPool(poolID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &types.StandingsResponse{Pool: p, Year: year, Status: st, Sort: by, Standings: make([]types.StandingResponse, 0, len(cons))}
+	for _, c := range cons {
+		if len(st) > 0 && c.Status != st {
+			continue
+		}
+
+		weeks, eliminated, err := s.picksService.GetSeasonHistory(c.ID, year)
+		if err != nil {
+			return nil, err
+		}
+		standing := types.StandingResponse{
+			Contestant:     c.ID,
+			Name:           c.Name,
+			Status:         c.Status,
+			TeamsUsed:      make([]types.Team, 0),
+			EliminatedWeek: eliminated,
+		}
+		for _, w := range weeks {
+			if w.Outcome == outcomes.WON && !w.Eliminated {
+				standing.WeeksSurvived++
+			}
+			if w.Pick == nil || !isPublished(byID[w.Schedule], w.Pick.Matchup) {
+				continue
+			}
+			standing.TeamsUsed = append(standing.TeamsUsed, w.Pick.Team)
+			if current != nil && w.Schedule == current.ID {
+				standing.CurrentPick = w.Pick
+			}
+		}
+		resp.Standings = append(resp.Standings, standing)
+	}
+
+	// Sort the standings in the requested order
+	sort.SliceStable(resp.Standings, func(i, j int) bool {
+		return lessStanding(resp.Standings[i], resp.Standings[j], by)
+	})
+
+	return resp, nil
+}
+
+// isPublished indicates whether a pick made in the specified Matchup of the Schedule can be shown to the other
+// Contestants in the Pool.
+func isPublished(sch types.Schedule, mID types.MatchupID) bool {
+	now := time.Now()
+	if now.After(sch.Closes) {
+		return true
+	}
+	m, exists := sch.Matchups[mID]
+
+	return exists && m.IsLocked(now)
+}
+
+// lessStanding indicates whether standing a should be sorted before standing b in the provided order.
+func lessStanding(a types.StandingResponse, b types.StandingResponse, by standings.Sort) bool {
+	if by == standings.STATUS && a.Status != b.Status {
+		return statusRank(a.Status) < statusRank(b.Status)
+	}
+	if by != standings.NAME && a.WeeksSurvived != b.WeeksSurvived {
+		return a.WeeksSurvived > b.WeeksSurvived
+	}
+
+	return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+}
+
+// statusRank orders Statuses from the most to the least likely to win the Pool.
+func statusRank(st status.Status) int {
+	switch st {
+	case status.ACTIVE:
+		return 0
+	case status.ELIMINATED:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func validateSort(by standings.Sort) error {
+	switch by {
+	case standings.STATUS, standings.SURVIVED, standings.NAME:
+		return nil
+	default:
+		return &types.StandingsSortInvalidError{Sort: by}
+	}
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/mhs294/mulhall/internals/repos"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/audit"
+	"github.com/mhs294/mulhall/internals/types/roles"
+)
+
+// UserAdminService represents a service for Administrators to manage other Users' accounts.
+type UserAdminService struct {
+	userRepo     *repos.UserRepository
+	sessRepo     *repos.SessionRepository
+	tokenRepo    *repos.APITokenRepository
+	conService   *ContestantService
+	auditService *AuditService
+}
+
+// NewUserAdminService creates a new instance of a UserAdminService and returns a pointer to it.
+//
+// ur is the UserRepository that will be used to manage User records in the database.
+//
+// sr is the SessionRepository that will be used to revoke the Sessions of deactivated Users.
+//
+// tr is the APITokenRepository that will be used to revoke the APITokens of deactivated Users.
+//
+// cs is the ContestantService that will be used to load Users' Contestant memberships.
+//
+// as is the AuditService that will be used to record changes made by Administrators.
+func NewUserAdminService(ur *repos.UserRepository, sr *repos.SessionRepository, tr *repos.APITokenRepository, cs *ContestantService, as *AuditService) *UserAdminService {
+	return &UserAdminService{
+		userRepo:     ur,
+		sessRepo:     sr,
+		tokenRepo:    tr,
+		conService:   cs,
+		auditService: as,
+	}
+}
+
+// Search returns all Users (active or not) whose email address contains the provided text, ignoring case.
+// All Users are returned if the text is empty.
+//
+// text is the text to search User email addresses for.
+func (s *UserAdminService) Search(text string) ([]types.User, error) {
+	return s.userRepo.Search(text)
+}
+
+// GetDetails returns the specified User (active or not) along with their Contestant memberships.
+// Returns UserNotFoundError if no such User exists.
+//
+// userID is the unique identifier of the User to load.
+func (s *UserAdminService) GetDetails(userID types.UserID) (*types.UserDetailsResponse, error) {
+	// Load the User
+	u, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the User's Contestant memberships
+	cons, err := s.conService.GetByAuthorizedUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	memberships := make([]types.MembershipResponse, 0, len(cons))
+	for _, c := range cons {
+		memberships = append(memberships, types.MembershipResponse{
+			Contestant: c.ID,
+			Name:       c.Name,
+			Role:       c.AuthorizedUsers[userID],
+		})
+	}
+
+	return &types.UserDetailsResponse{User: u, Memberships: memberships}, nil
+}
+
+// SetActive activates or deactivates the specified User's account. Deactivating an account immediately revokes
+// all of the User's Sessions and APITokens.
+// Returns UserNotFoundError if no such User exists.
+// Returns AdminSelfModificationError if an Administrator attempts to deactivate their own account.
+// Returns ContestantOwnerRequiredError if deactivating the User would leave a Contestant with no active OWNER.
+//
+// adminID is the unique identifier of the Administrator making the change.
+//
+// userID is the unique identifier of the User to activate or deactivate.
+//
+// active is whether the User's account should be active.
+func (s *UserAdminService) SetActive(adminID types.UserID, userID types.UserID, active bool) (*types.User, error) {
+	// Load the User
+	u, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if u.Active == active {
+		return u, nil
+	}
+
+	// Validate the change
+	action := audit.ACTIVATE
+	if !active {
+		action = audit.DEACTIVATE
+		if adminID == userID {
+			return nil, &types.AdminSelfModificationError{ID: userID}
+		}
+		if err = s.verifyNotLastOwner(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update the User
+	u.Active = active
+	if err = s.userRepo.Update(u); err != nil {
+		return nil, fmt.Errorf("failed to update user: %v", err)
+	}
+
+	// Revoke all existing credentials of a deactivated User
+	if !active {
+		if err = s.sessRepo.DeleteByUser(userID, ""); err != nil {
+			return nil, err
+		}
+		if err = s.tokenRepo.RevokeByUser(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return u, s.record(adminID, action, u)
+}
+
+// SetAdministrator grants or revokes the specified User's Administrator privileges.
+// Returns UserNotFoundError if no such User exists.
+// Returns AdminSelfModificationError if an Administrator attempts to revoke their own privileges.
+//
+// adminID is the unique identifier of the Administrator making the change.
+//
+// userID is the unique identifier of the User to grant or revoke Administrator privileges for.
+//
+// administrator is whether the User should be an Administrator.
+func (s *UserAdminService) SetAdministrator(adminID types.UserID, userID types.UserID, administrator bool) (*types.User, error) {
+	// Load the User
+	u, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if u.Administrator == administrator {
+		return u, nil
+	}
+
+	// Validate the change
+	action := audit.GRANT_ADMINISTRATOR
+	if !administrator {
+		action = audit.REVOKE_ADMINISTRATOR
+		if adminID == userID {
+			return nil, &types.AdminSelfModificationError{ID: userID}
+		}
+	}
+
+	// Update the User
+	u.Administrator = administrator
+	if err = s.userRepo.Update(u); err != nil {
+		return nil, fmt.Errorf("failed to update user: %v", err)
+	}
+
+	return u, s.record(adminID, action, u)
+}
+
+func (s *UserAdminService) getUser(userID types.UserID) (*types.User, error) {
+	u, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil || len(u.ID) == 0 {
+		return nil, &types.UserNotFoundError{ID: userID}
+	}
+
+	return u, nil
+}
+
+// verifyNotLastOwner returns ContestantOwnerRequiredError if the User is the only active OWNER of any Contestant.
+func (s *UserAdminService) verifyNotLastOwner(userID types.UserID) error {
+	cons, err := s.conService.GetByAuthorizedUser(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range cons {
+		if c.AuthorizedUsers[userID] != roles.OWNER {
+			continue
+		}
+
+		// Look for another active OWNER of the Contestant
+		others := make([]types.UserID, 0)
+		for id, role := range c.AuthorizedUsers {
+			if id != userID && role == roles.OWNER {
+				others = append(others, id)
+			}
+		}
+		owners, err := s.userRepo.GetByIDs(others)
+		if err != nil {
+			return err
+		}
+		hasOwner := false
+		for _, o := range owners {
+			hasOwner = hasOwner || o.Active
+		}
+		if !hasOwner {
+			return &types.ContestantOwnerRequiredError{ContestantID: c.ID, UserID: userID}
+		}
+	}
+
+	return nil
+}
+
+func (s *UserAdminService) record(adminID types.UserID, action audit.Action, u *types.User) error {
+	details := map[string]string{"user": string(u.ID), "email": u.Email}
+	if err := s.auditService.Record(adminID, action, audit.ACCOUNT, details); err != nil {
+		return fmt.Errorf("failed to record audit event: %v", err)
+	}
+
+	return nil
+}
@@ -6,17 +6,29 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/mhs294/mulhall/internals/env"
+	"github.com/mhs294/mulhall/internals/passwords"
 	"github.com/mhs294/mulhall/internals/repos"
+	"github.com/mhs294/mulhall/internals/totp"
 	"github.com/mhs294/mulhall/internals/types"
 	"github.com/mhs294/mulhall/internals/utils"
-	"golang.org/x/crypto/bcrypt"
 )
 
+// mfaIssuer is the issuer name shown by authenticator apps for enrolled TOTP secrets.
+const mfaIssuer = "Mulhall"
+
+// recoveryCodeCount is the number of single-use recovery codes generated when a User enables TOTP.
+const recoveryCodeCount = 10
+
 // UserService represents a service for interacting with Users and their accounts on the site.
 type UserService struct {
 	invService *InviteService
 	userRepo   *repos.UserRepository
 	sessRepo   *repos.SessionRepository
+	attempts   *LoginAttemptService
+	settings   *SettingsService
+	notifier   *NotificationService
+	hasher     passwords.Hasher
+	policy     *passwords.Policy
 }
 
 // NewUserService creates a new instance of a UserService and returns a pointer to it.
@@ -26,16 +38,32 @@ type UserService struct {
 // ur is the UserRepository that will be used to manage User records in the database.
 //
 // sr is the SessionRepository that will be used to manage Session records in the database.
-func NewUserService(s *InviteService, ur *repos.UserRepository, sr *repos.SessionRepository) *UserService {
+//
+// as is the LoginAttemptService that will be used to throttle repeated failed login attempts.
+//
+// ss is the SettingsService that will be used to determine whether Administrators require a second factor.
+//
+// ns is the NotificationService that will be used to email links for verifying new email addresses.
+//
+// h is the Hasher that will be used to hash new passwords and upgrade outdated password hashes.
+//
+// p is the Policy that new passwords must satisfy.
+func NewUserService(s *InviteService, ur *repos.UserRepository, sr *repos.SessionRepository, as *LoginAttemptService, ss *SettingsService, ns *NotificationService, h passwords.Hasher, p *passwords.Policy) *UserService {
 	return &UserService{
 		invService: s,
 		userRepo:   ur,
 		sessRepo:   sr,
+		attempts:   as,
+		settings:   ss,
+		notifier:   ns,
+		hasher:     h,
+		policy:     p,
 	}
 }
 
 // Register handles the creation of a new User from an accepted Invite.
 // Returns PasswordMismatchError when the password and confirmation provided in the request do not match.
+// Returns PasswordPolicyError when the password does not satisfy the password policy.
 //
 // req is the RegisterUserRequest containing the information required to create the User and accept the Invite.
 func (s *UserService) Register(req *types.RegisterUserRequest) (*types.User, error) {
@@ -43,19 +71,22 @@ func (s *UserService) Register(req *types.RegisterUserRequest) (*types.User, err
 	if req.Password != req.Confirm {
 		return nil, &types.PasswordMismatchError{}
 	}
+	if err := s.policy.Check(req.Password); err != nil {
+		return nil, err
+	}
 	invId, err := s.invService.Validate(req.Email, req.Token)
 	if err != nil {
 		return nil, err
 	}
 
 	// Create the User
-	u, err := s.createUser(req)
+	u, err := s.createUser(req.Email, req.Password)
 	if err != nil {
 		return nil, err
 	}
 
 	// Mark the Invite as accepted
-	if err = s.invService.Accept(invId); err != nil {
+	if err = s.invService.Accept(invId, u.ID); err != nil {
 		// TODO - figure out how rollbacks should be handled
 		return nil, err
 	}
@@ -63,14 +94,56 @@ func (s *UserService) Register(req *types.RegisterUserRequest) (*types.User, err
 	return u, nil
 }
 
+// CreateAdministrator creates a new, active Administrator without requiring an Invite. This is intended for
+// bootstrapping a new deployment, where no User exists yet to send Invites.
+// Returns EmailInUseError when the email address already belongs to another User.
+// Returns PasswordPolicyError when the password does not satisfy the password policy.
+//
+// email is the email address of the new Administrator.
+//
+// pwd is the raw password of the new Administrator.
+func (s *UserService) CreateAdministrator(email string, pwd string) (*types.User, error) {
+	// Validate the request
+	if err := s.verifyEmailAvailable(email, ""); err != nil {
+		return nil, err
+	}
+	if err := s.policy.Check(pwd); err != nil {
+		return nil, err
+	}
+
+	// Create the User and grant Administrator privileges
+	u, err := s.createUser(email, pwd)
+	if err != nil {
+		return nil, err
+	}
+	u.Administrator = true
+	if err = s.userRepo.Update(u); err != nil {
+		return nil, fmt.Errorf("failed to create administrator: %v", err)
+	}
+
+	return u, nil
+}
+
 // Login authenticates a User from the provided email and password and returns a new
 // Session for that User if authentication succeeds. If login fails, an error is returned.
+// If the User's password hash was produced with outdated parameters, it is transparently rehashed.
+// If the User must provide a second authentication factor, the returned Session is a short-lived pending
+// Session that can only be used to complete the second factor with VerifySecondFactor (or ConfirmMFA).
+// Returns LoginThrottledError if too many recent login attempts have failed for the email or client address.
 // Returns UserNotFoundError if no such User exists or the User has been deactivated.
+// Returns PasswordIncorrectError if the password is incorrect.
 //
 // email is the User's email address
 //
 // pwd is the raw password submitted by the User.
-func (s *UserService) Login(email string, pwd string) (*types.Session, error) {
+//
+// ip is the address of the client attempting to log in.
+func (s *UserService) Login(email string, pwd string, ip string) (*types.Session, error) {
+	// Verify that login attempts are not currently being throttled
+	if err := s.attempts.Check(email, ip); err != nil {
+		return nil, err
+	}
+
 	// Look up the User
 	u, err := s.userRepo.GetByEmail(email)
 	if err != nil {
@@ -79,47 +152,602 @@ func (s *UserService) Login(email string, pwd string) (*types.Session, error) {
 
 	// Verify that the User exists and is active
 	if u == nil || !u.Active {
+		// Hash the password anyway so that unknown accounts take as long to reject as known ones
+		s.hasher.Hash(pwd)
+		if err = s.attempts.RecordFailure(email, ip); err != nil {
+			return nil, fmt.Errorf("failed to login user: %v", err)
+		}
 		return nil, &types.UserNotFoundError{Email: email}
 	}
 
 	// Compare the submitted password against the User's password
-	saltedPwd := []byte(pwd + u.Salt)
-	err = bcrypt.CompareHashAndPassword([]byte(u.Hash), saltedPwd)
-	if err == bcrypt.ErrMismatchedHashAndPassword {
-		return nil, &types.PasswordIncorrectError{}
-	} else if err != nil {
+	if err = passwords.Verify(u, pwd); err != nil {
+		if _, ok := err.(*types.PasswordIncorrectError); ok {
+			if err := s.attempts.RecordFailure(email, ip); err != nil {
+				return nil, fmt.Errorf("failed to login user: %v", err)
+			}
+		}
+		return nil, err
+	}
+	if err = s.attempts.RecordSuccess(email); err != nil {
 		return nil, fmt.Errorf("failed to login user: %v", err)
 	}
 
+	// Upgrade the User's password hash if it was produced with outdated parameters
+	if passwords.NeedsRehash(u, s.hasher) {
+		if err = s.setPassword(u, pwd); err != nil {
+			return nil, fmt.Errorf("failed to upgrade password hash: %v", err)
+		}
+	}
+
 	// Authentication successful, create new Session for the User
+	return s.createLoginSession(u)
+}
+
+// LoginExternal returns a new Session for the User with the provided email address, which has already been
+// verified by an external identity provider. If no User exists for the email address but an active Invite
+// does, a new User (without a password) is created from the Invite. As with Login, the returned Session is
+// pending if the User must provide a second authentication factor.
+// Returns UserNotFoundError if the User has been deactivated, or no User or active Invite exists for the email.
+//
+// email is the verified email address asserted by the identity provider.
+func (s *UserService) LoginExternal(email string) (*types.Session, error) {
+	// Look up the User
+	u, err := s.userRepo.GetByEmail(email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to login user: %v", err)
+	}
+
+	// Fall back to creating the User from a pending Invite
+	if u == nil || len(u.ID) == 0 {
+		inv, err := s.invService.GetPending(email)
+		if err != nil {
+			if _, ok := err.(*types.InviteNotFoundError); ok {
+				return nil, &types.UserNotFoundError{Email: email}
+			}
+			return nil, err
+		}
+
+		if u, err = s.createUser(email, ""); err != nil {
+			return nil, err
+		}
+		if err = s.invService.Accept(inv.ID, u.ID); err != nil {
+			// TODO - figure out how rollbacks should be handled
+			return nil, err
+		}
+	}
+
+	// Verify that the User is active
+	if !u.Active {
+		return nil, &types.UserNotFoundError{Email: email}
+	}
+
+	return s.createLoginSession(u)
+}
+
+// VerifySecondFactor completes a login that is awaiting a second authentication factor, replacing the
+// pending Session with a full Session if the provided TOTP or recovery code is valid.
+// Returns LoginThrottledError if too many recent login attempts have failed for the User or client address.
+// Returns MFANotEnrolledError if the User has not enabled a second authentication factor.
+// Returns MFACodeInvalidError if the code is invalid or has already been used.
+//
+// sess is the pending Session issued by Login.
+//
+// code is the TOTP code or recovery code submitted by the User.
+//
+// ip is the address of the client attempting to log in.
+func (s *UserService) VerifySecondFactor(sess *types.Session, code string, ip string) (*types.Session, error) {
+	// Load the User
+	u, err := s.GetByID(sess.User)
+	if err != nil {
+		return nil, err
+	}
+	if !u.TOTPEnabled {
+		return nil, &types.MFANotEnrolledError{ID: u.ID}
+	}
+
+	// Verify the code
+	if err = s.checkThrottledSecondFactor(u, code, true, ip); err != nil {
+		return nil, err
+	}
+
+	// Replace the pending Session with a full Session
+	return s.upgradeSession(sess)
+}
+
+// GetMFAStatus returns whether the specified User has a second authentication factor enabled, and whether
+// one is required for them.
+//
+// userID is the unique identifier of the User to check.
+func (s *UserService) GetMFAStatus(userID types.UserID) (*types.MFAStatusResponse, error) {
+	u, err := s.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	required, err := s.mfaRequired(u)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MFAStatusResponse{Enabled: u.TOTPEnabled, Required: required}, nil
+}
+
+// EnrollMFA generates a new TOTP secret for the specified User. The secret does not take effect until
+// it has been confirmed with ConfirmMFA.
+// Returns MFAAlreadyEnabledError if the User already has a second authentication factor enabled.
+//
+// userID is the unique identifier of the User enrolling a second authentication factor.
+func (s *UserService) EnrollMFA(userID types.UserID) (*types.MFAEnrollmentResponse, error) {
+	// Load the User
+	u, err := s.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if u.TOTPEnabled {
+		return nil, &types.MFAAlreadyEnabledError{ID: u.ID}
+	}
+
+	// Generate and store the unconfirmed secret
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	u.TOTPSecret = secret
+	u.TOTPLastStep = 0
+	if err = s.userRepo.Update(u); err != nil {
+		return nil, fmt.Errorf("failed to enroll second factor: %v", err)
+	}
+
+	return &types.MFAEnrollmentResponse{
+		Secret: secret,
+		URI:    totp.ProvisioningURI(mfaIssuer, u.Email, secret),
+	}, nil
+}
+
+// ConfirmMFA enables the TOTP secret generated by EnrollMFA once the User proves they can produce codes
+// for it, and returns a new set of single-use recovery codes. If the provided Session is pending, it is
+// replaced with a full Session.
+// Returns MFAAlreadyEnabledError if the User already has a second authentication factor enabled.
+// Returns MFANotEnrolledError if the User has not generated a TOTP secret.
+// Returns LoginThrottledError if too many recent login attempts have failed for the User or client address.
+// Returns MFACodeInvalidError if the code is invalid.
+//
+// sess is the Session of the User confirming their second authentication factor.
+//
+// code is the TOTP code submitted by the User.
+//
+// ip is the address of the client confirming the second authentication factor.
+func (s *UserService) ConfirmMFA(sess *types.Session, code string, ip string) (*types.Session, []string, error) {
+	// Load the User
+	u, err := s.GetByID(sess.User)
+	if err != nil {
+		return nil, nil, err
+	}
+	if u.TOTPEnabled {
+		return nil, nil, &types.MFAAlreadyEnabledError{ID: u.ID}
+	}
+	if len(u.TOTPSecret) == 0 {
+		return nil, nil, &types.MFANotEnrolledError{ID: u.ID}
+	}
+
+	// Verify the code against the unconfirmed secret
+	if err = s.checkThrottledSecondFactor(u, code, false, ip); err != nil {
+		return nil, nil, err
+	}
+
+	// Enable the second factor with a new set of recovery codes
+	codes, hashes, err := totp.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, nil, err
+	}
+	u.TOTPEnabled = true
+	u.RecoveryCodes = hashes
+	if err = s.userRepo.Update(u); err != nil {
+		return nil, nil, fmt.Errorf("failed to confirm second factor: %v", err)
+	}
+
+	// Replace a pending Session with a full Session
+	if !sess.Pending {
+		return sess, codes, nil
+	}
+	sess, err = s.upgradeSession(sess)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sess, codes, nil
+}
+
+// DisableMFA disables the second authentication factor of a logged in User after verifying both their
+// password and a current TOTP or recovery code.
+// Returns PasswordIncorrectError when the User's password is incorrect.
+// Returns MFANotEnrolledError if the User does not have a second authentication factor enabled.
+// Returns MFACodeInvalidError if the code is invalid.
+// Returns MFARequiredError if a second authentication factor is required for the User.
+// Returns LoginThrottledError if too many recent login attempts have failed for the User or client address.
+//
+// userID is the unique identifier of the User disabling their second authentication factor.
+//
+// req is the DisableMFARequest containing the User's password and a current code.
+//
+// ip is the address of the client disabling the second authentication factor.
+func (s *UserService) DisableMFA(userID types.UserID, req *types.DisableMFARequest, ip string) error {
+	// Load the User
+	u, err := s.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if !u.TOTPEnabled {
+		return &types.MFANotEnrolledError{ID: u.ID}
+	}
+
+	// Validate the request
+	required, err := s.mfaRequired(u)
+	if err != nil {
+		return err
+	}
+	if required {
+		return &types.MFARequiredError{ID: u.ID}
+	}
+	if err = s.attempts.Check(u.Email, ip); err != nil {
+		return err
+	}
+	if err = passwords.Verify(u, req.Password); err != nil {
+		if _, ok := err.(*types.PasswordIncorrectError); ok {
+			if err := s.attempts.RecordFailure(u.Email, ip); err != nil {
+				return fmt.Errorf("failed to disable second factor: %v", err)
+			}
+		}
+		return err
+	}
+	if err = s.checkThrottledSecondFactor(u, req.Code, true, ip); err != nil {
+		return err
+	}
+
+	// Remove the second factor
+	u.TOTPEnabled = false
+	u.TOTPSecret = ""
+	u.TOTPLastStep = 0
+	u.RecoveryCodes = nil
+	if err = s.userRepo.Update(u); err != nil {
+		return fmt.Errorf("failed to disable second factor: %v", err)
+	}
+
+	return nil
+}
+
+// GetByID returns the active User with the provided ID.
+// Returns UserNotFoundError if no such User exists or the User has been deactivated.
+//
+// id is the unique identifier of the User to look up.
+func (s *UserService) GetByID(id types.UserID) (*types.User, error) {
+	// Load the User from the database
+	u, err := s.userRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify that the User exists and is active
+	if u == nil || !u.Active {
+		return nil, &types.UserNotFoundError{ID: id}
+	}
+
+	return u, nil
+}
+
+// ChangePassword changes the password of a logged in User after verifying their current password.
+// All of the User's other Sessions are revoked once the password has been changed.
+// Returns PasswordIncorrectError when the User's current password is incorrect.
+// Returns PasswordMismatchError when the new password and confirmation provided in the request do not match.
+// Returns PasswordPolicyError when the new password does not satisfy the password policy.
+//
+// sess is the Session of the logged in User changing their password.
+//
+// req is the ChangePasswordRequest containing the User's current and new passwords.
+func (s *UserService) ChangePassword(sess *types.Session, req *types.ChangePasswordRequest) error {
+	// Load the User
+	u, err := s.GetByID(sess.User)
+	if err != nil {
+		return err
+	}
+
+	// Validate the request (Users created through an external identity provider may set an initial password)
+	if len(u.Hash) > 0 {
+		if err = passwords.Verify(u, req.Current); err != nil {
+			return err
+		}
+	}
+	if req.Password != req.Confirm {
+		return &types.PasswordMismatchError{}
+	}
+	if err = s.policy.Check(req.Password); err != nil {
+		return err
+	}
+
+	// Hash and store the new password
+	if err = s.setPassword(u, req.Password); err != nil {
+		return fmt.Errorf("failed to change password: %v", err)
+	}
+
+	// Revoke all other Sessions so that any other device must log in with the new password
+	if err = s.sessRepo.DeleteByUser(u.ID, sess.ID); err != nil {
+		return fmt.Errorf("failed to revoke sessions after password change: %v", err)
+	}
+
+	return nil
+}
+
+// RequestEmailChange begins changing the email address of a logged in User after verifying their password.
+// The User's email address is not changed until the new address has been verified with VerifyEmailChange.
+// Returns PasswordIncorrectError when the User's password is incorrect.
+// Returns EmailInUseError when the new email address already belongs to another User.
+//
+// userID is the unique identifier of the logged in User changing their email address.
+//
+// req is the ChangeEmailRequest containing the new email address and the User's password.
+func (s *UserService) RequestEmailChange(userID types.UserID, req *types.ChangeEmailRequest) (*types.User, error) {
+	// Load the User
+	u, err := s.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the request
+	if err = passwords.Verify(u, req.Password); err != nil {
+		return nil, err
+	}
+	if err = s.verifyEmailAvailable(req.Email, u.ID); err != nil {
+		return nil, err
+	}
+
+	// Prepare the pending email address with a randomly generated verification token
+	token, err := utils.CreateSecureAlphaNumToken(64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create email verification token: %v", err)
+	}
+	u.PendingEmail = req.Email
+	u.PendingEmailToken = token
+	u.PendingEmailExpiration = time.Now().UTC().Add(env.EmailVerificationExpiration)
+
+	// Email the verification link before storing the pending change, so that it can be requested again if delivery fails
+	if _, err = s.notifier.SendEmailVerification(u); err != nil {
+		return nil, fmt.Errorf("failed to send email verification: %v", err)
+	}
+	if err = s.userRepo.Update(u); err != nil {
+		return nil, fmt.Errorf("failed to request email change: %v", err)
+	}
+
+	return u, nil
+}
+
+// VerifyEmailChange completes a pending email address change for the provided email/token combination.
+// Returns EmailChangeNotFoundError if no pending change exists for the provided email/token combination.
+// Returns EmailChangeExpiredError if the pending change has expired.
+// Returns EmailInUseError if the email address has since been taken by another User.
+//
+// email is the new email address being verified.
+//
+// token is the token string that should match with the pending email address.
+func (s *UserService) VerifyEmailChange(email string, token string) error {
+	// Look up the User with the pending email address
+	u, err := s.userRepo.GetByPendingEmail(email)
+	if err != nil {
+		return err
+	}
+
+	// Verify that the pending change exists, matches the token and is still active
+	if u == nil || !u.Active || u.PendingEmailToken != token {
+		return &types.EmailChangeNotFoundError{Email: email, Token: token}
+	}
+	if u.PendingEmailExpiration.Before(time.Now().UTC()) {
+		return &types.EmailChangeExpiredError{Email: email, Token: token}
+	}
+	if err = s.verifyEmailAvailable(email, u.ID); err != nil {
+		return err
+	}
+
+	// Apply the new email address and clear the pending change
+	u.Email = email
+	u.PendingEmail = ""
+	u.PendingEmailToken = ""
+	u.PendingEmailExpiration = time.Time{}
+	if err = s.userRepo.Update(u); err != nil {
+		return fmt.Errorf("failed to verify email change: %v", err)
+	}
+
+	return nil
+}
+
+// GetSessions describes all active Sessions belonging to the specified User, identified by their handles.
+//
+// userID is the unique identifier of the User whose Sessions should be loaded.
+//
+// current is the unique identifier of the Session making the request, which is marked as current.
+func (s *UserService) GetSessions(userID types.UserID, current types.SessionID) ([]types.SessionResponse, error) {
+	sessions, err := s.sessRepo.GetByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]types.SessionResponse, 0, len(sessions))
+	for _, sess := range sessions {
+		res = append(res, types.SessionResponse{
+			Handle:     sess.Handle(),
+			Created:    sess.Created,
+			Expiration: sess.Expiration,
+			Current:    sess.ID == current,
+		})
+	}
+
+	return res, nil
+}
+
+// RevokeSession ends one of the specified User's Sessions.
+// Returns SessionNotFoundError if no such Session exists for the User.
+//
+// userID is the unique identifier of the User that owns the Session.
+//
+// handle is the handle of the Session to revoke (see SessionHandle).
+func (s *UserService) RevokeSession(userID types.UserID, handle string) error {
+	// Find the User's Session with the provided handle
+	sessions, err := s.sessRepo.GetByUser(userID)
+	if err != nil {
+		return err
+	}
+	for _, sess := range sessions {
+		if sess.Handle() == handle {
+			return s.sessRepo.Delete(sess.ID)
+		}
+	}
+
+	return &types.SessionNotFoundError{Handle: handle}
+}
+
+func (s *UserService) createSession(userID types.UserID, pending bool) (*types.Session, error) {
+	now := time.Now().UTC()
+	expiration := now.Add(env.SessionExpiration)
+	if pending {
+		expiration = now.Add(env.PendingSessionExpiration)
+	}
+
 	sess := &types.Session{
 		ID:         types.SessionID(uuid.NewString()),
-		User:       u.ID,
-		Expiration: time.Now().UTC().Add(env.SessionExpiration),
+		User:       userID,
+		Created:    now,
+		Expiration: expiration,
+		Pending:    pending,
 	}
-	if err = s.sessRepo.Insert(sess); err != nil {
+	if err := s.sessRepo.Insert(sess); err != nil {
 		return nil, fmt.Errorf("failed to create new session for user: %v", err)
 	}
 
 	return sess, nil
 }
 
-func (s *UserService) createUser(req *types.RegisterUserRequest) (*types.User, error) {
-	// Create the User with a new randomly generated salt and hash the provided password with it
-	salt := utils.CreateToken(16)
-	hash, err := hashPassword(req.Password, salt)
+// createLoginSession creates a Session for an authenticated User, which is pending if the User must still
+// provide a second authentication factor.
+func (s *UserService) createLoginSession(u *types.User) (*types.Session, error) {
+	required, err := s.mfaRequired(u)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create user: %v", err)
+		return nil, fmt.Errorf("failed to login user: %v", err)
+	}
+
+	return s.createSession(u.ID, u.TOTPEnabled || required)
+}
+
+func (s *UserService) upgradeSession(pending *types.Session) (*types.Session, error) {
+	if err := s.sessRepo.Delete(pending.ID); err != nil {
+		return nil, err
 	}
+
+	return s.createSession(pending.User, false)
+}
+
+func (s *UserService) mfaRequired(u *types.User) (bool, error) {
+	if !u.Administrator {
+		return false, nil
+	}
+
+	settings, err := s.settings.Get()
+	if err != nil {
+		return false, err
+	}
+
+	return settings.RequireAdministratorMFA, nil
+}
+
+// checkThrottledSecondFactor verifies a second factor code in the same way as checkSecondFactor, subject to the same
+// throttling of repeated failures as logins for the User and client address.
+func (s *UserService) checkThrottledSecondFactor(u *types.User, code string, allowRecovery bool, ip string) error {
+	// Verify that login attempts are not currently being throttled
+	if err := s.attempts.Check(u.Email, ip); err != nil {
+		return err
+	}
+
+	// Verify the code
+	if err := s.checkSecondFactor(u, code, allowRecovery); err != nil {
+		if _, ok := err.(*types.MFACodeInvalidError); ok {
+			if err := s.attempts.RecordFailure(u.Email, ip); err != nil {
+				return fmt.Errorf("failed to verify second factor: %v", err)
+			}
+		}
+		return err
+	}
+	if err := s.attempts.RecordSuccess(u.Email); err != nil {
+		return fmt.Errorf("failed to verify second factor: %v", err)
+	}
+
+	return nil
+}
+
+// checkSecondFactor verifies a TOTP code (rejecting replays of previously accepted codes) or, if allowed,
+// consumes a single-use recovery code. The User is updated to record the accepted code.
+func (s *UserService) checkSecondFactor(u *types.User, code string, allowRecovery bool) error {
+	step, err := totp.Validate(u.TOTPSecret, code, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	if step > u.TOTPLastStep {
+		u.TOTPLastStep = step
+		return s.userRepo.Update(u)
+	}
+
+	if allowRecovery && step < 0 {
+		hash := totp.HashRecoveryCode(code)
+		for i, h := range u.RecoveryCodes {
+			if h == hash {
+				u.RecoveryCodes = append(u.RecoveryCodes[:i], u.RecoveryCodes[i+1:]...)
+				return s.userRepo.Update(u)
+			}
+		}
+	}
+
+	return &types.MFACodeInvalidError{}
+}
+
+func (s *UserService) verifyEmailAvailable(email string, userID types.UserID) error {
+	// Check both the current and pending email addresses of other Users
+	u, err := s.userRepo.GetByEmail(email)
+	if err != nil {
+		return err
+	}
+	if u != nil && len(u.ID) > 0 && u.ID != userID {
+		return &types.EmailInUseError{Email: email}
+	}
+
+	u, err = s.userRepo.GetByPendingEmail(email)
+	if err != nil {
+		return err
+	}
+	if u != nil && len(u.ID) > 0 && u.ID != userID {
+		return &types.EmailInUseError{Email: email}
+	}
+
+	return nil
+}
+
+func (s *UserService) createUser(email string, pwd string) (*types.User, error) {
 	u := &types.User{
 		ID:            types.UserID(uuid.NewString()),
-		Email:         req.Email,
-		Salt:          salt,
-		Hash:          hash,
+		Email:         email,
 		Administrator: false,
 		Active:        true,
 	}
 
+	// Hash the provided password with the configured Hasher (Users created through an external identity
+	// provider have no password)
+	if len(pwd) > 0 {
+		hash, salt, params, err := s.hasher.Hash(pwd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create user: %v", err)
+		}
+		u.Hash = hash
+		u.Salt = salt
+		u.HashParams = params
+	}
+
 	// Insert the User into the database
 	if err := s.userRepo.Insert(u); err != nil {
 		return nil, fmt.Errorf("failed to create user: %v", err)
@@ -128,12 +756,14 @@ func (s *UserService) createUser(req *types.RegisterUserRequest) (*types.User, e
 	return u, nil
 }
 
-func hashPassword(pwd string, salt string) (string, error) {
-	bytes := []byte(pwd + salt)
-	hash, err := bcrypt.GenerateFromPassword(bytes, bcrypt.MinCost)
+func (s *UserService) setPassword(u *types.User, pwd string) error {
+	hash, salt, params, err := s.hasher.Hash(pwd)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	return string(hash), nil
+	u.Hash = hash
+	u.Salt = salt
+	u.HashParams = params
+	return s.userRepo.Update(u)
 }
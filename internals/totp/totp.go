@@ -0,0 +1,132 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Time-based one-time passwords (RFC 6238) using the parameters supported by all common authenticator apps.
+const (
+	period = 30 * time.Second
+	digits = 6
+	skew   = 1 // number of periods before/after the current period that are also accepted
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret creates a new random base32-encoded TOTP secret.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate totp secret: %v", err)
+	}
+
+	return encoding.EncodeToString(secret), nil
+}
+
+// ProvisioningURI returns the otpauth:// URI that authenticator apps use (typically via a QR code)
+// to enroll the provided secret.
+//
+// issuer is the name of the site issuing the secret.
+//
+// account is the name of the account the secret belongs to (e.g. - the User's email address).
+//
+// secret is the base32-encoded TOTP secret.
+func ProvisioningURI(issuer string, account string, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprintf("%d", digits))
+	params.Set("period", fmt.Sprintf("%d", int(period.Seconds())))
+
+	label := url.PathEscape(issuer + ":" + account)
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, params.Encode())
+}
+
+// Validate checks the provided code against the secret at the specified time, allowing for a small amount
+// of clock skew. Returns the time step that the code matched (or -1 if it did not match any step). Callers
+// should reject a step that is not greater than the last step accepted for the secret to prevent replays.
+//
+// secret is the base32-encoded TOTP secret.
+//
+// code is the one-time code submitted by the User.
+//
+// at is the [time.Time] at which the code was submitted.
+func Validate(secret string, code string, at time.Time) (int64, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return -1, fmt.Errorf("failed to decode totp secret: %v", err)
+	}
+
+	code = strings.TrimSpace(code)
+	step := at.Unix() / int64(period.Seconds())
+	for i := int64(-skew); i <= skew; i++ {
+		if hmac.Equal([]byte(generate(key, step+i)), []byte(code)) {
+			return step + i, nil
+		}
+	}
+
+	return -1, nil
+}
+
+// GenerateRecoveryCodes creates a set of random single-use recovery codes, returning both the plaintext
+// codes (to show the User once) and their hashes (to store).
+//
+// n is the number of recovery codes to generate.
+func GenerateRecoveryCodes(n int) ([]string, []string, error) {
+	const charset = "abcdefghjkmnpqrstuvwxyz23456789"
+
+	codes := make([]string, n)
+	hashes := make([]string, n)
+	for i := range codes {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery codes: %v", err)
+		}
+		for j := range b {
+			b[j] = charset[int(b[j])%len(charset)]
+		}
+
+		codes[i] = fmt.Sprintf("%s-%s", b[:5], b[5:])
+		hashes[i] = HashRecoveryCode(codes[i])
+	}
+
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode returns the hash under which the provided recovery code is stored.
+//
+// code is the plaintext recovery code.
+func HashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
+
+func generate(key []byte, step int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation (RFC 4226 section 5.3)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
@@ -0,0 +1,14 @@
+package algorithms
+
+type Algorithm string
+
+// The enumerated password hashing Algorithms that may have been used to produce a User's password Hash.
+const (
+	// LEGACY is bcrypt at its minimum cost over the password with the User's Salt appended to it.
+	// Users with LEGACY hashes are upgraded to the configured Algorithm the next time they log in.
+	LEGACY Algorithm = ""
+	// BCRYPT is bcrypt over the raw password at a configurable cost.
+	BCRYPT Algorithm = "bcrypt"
+	// ARGON2ID is argon2id over the raw password and the User's Salt with configurable time/memory/thread costs.
+	ARGON2ID Algorithm = "argon2id"
+)
@@ -0,0 +1,27 @@
+package audit
+
+type Action string
+
+// The enumerated Actions that can be recorded by an AuditEvent.
+const (
+	// LOCKOUT indicates that login attempts were temporarily blocked after too many failures.
+	LOCKOUT Action = "Lockout"
+	// ACTIVATE indicates that an Administrator activated a User's account.
+	ACTIVATE Action = "Activate"
+	// DEACTIVATE indicates that an Administrator deactivated a User's account.
+	DEACTIVATE Action = "Deactivate"
+	// GRANT_ADMINISTRATOR indicates that an Administrator granted Administrator privileges to a User.
+	GRANT_ADMINISTRATOR Action = "GrantAdministrator"
+	// REVOKE_ADMINISTRATOR indicates that an Administrator revoked Administrator privileges from a User.
+	REVOKE_ADMINISTRATOR Action = "RevokeAdministrator"
+)
+
+type Resource string
+
+// The enumerated Resources that an AuditEvent can be recorded against.
+const (
+	// ACCOUNT is a User account, identified by its email address.
+	ACCOUNT Resource = "Account"
+	// ADDRESS is a client IP address.
+	ADDRESS Resource = "Address"
+)
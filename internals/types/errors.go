@@ -3,8 +3,54 @@ package types
 import (
 	"fmt"
 	"time"
+
+	"github.com/mhs294/mulhall/internals/types/roles"
+	"github.com/mhs294/mulhall/internals/types/scopes"
+	"github.com/mhs294/mulhall/internals/types/standings"
+	"github.com/mhs294/mulhall/internals/types/status"
 )
 
+// The system attempted to handle a request whose parameters or body could not be read.
+type RequestInvalidError struct {
+	Reason string
+}
+
+func (e *RequestInvalidError) Error() string {
+	return fmt.Sprintf("invalid request, reason=%s.", e.Reason)
+}
+
+// The system attempted to handle a request whose body was read but contained missing or invalid fields.
+type RequestValidationError struct {
+	Fields map[string]string // Keyed by the JSON path of each invalid field
+}
+
+func (e *RequestValidationError) Error() string {
+	return fmt.Sprintf("invalid request fields, fields=%v.", e.Fields)
+}
+
+// The system attempted to handle a request that requires an authenticated User without one.
+type AuthenticationRequiredError struct{}
+
+func (e *AuthenticationRequiredError) Error() string {
+	return "authentication is required."
+}
+
+// The system attempted to handle a request that requires an Administrator on behalf of another User.
+type AdministratorRequiredError struct {
+	ID UserID
+}
+
+func (e *AdministratorRequiredError) Error() string {
+	return fmt.Sprintf("user is not an administrator. id=%s", e.ID)
+}
+
+// The system attempted to handle a state-changing request whose CSRF token was missing or did not match.
+type CSRFTokenInvalidError struct{}
+
+func (e *CSRFTokenInvalidError) Error() string {
+	return "csrf token is missing or invalid."
+}
+
 // The system attempted to find an Invite that does not exist.
 type InviteNotFoundError struct {
 	Email string
@@ -59,6 +105,15 @@ func (e *PasswordMismatchError) Error() string {
 	return "password and confirm password fields do not match."
 }
 
+// The system attempted to set a User's password to one that does not satisfy the password policy.
+type PasswordPolicyError struct {
+	Reason string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return fmt.Sprintf("password does not satisfy the password policy, reason=%s.", e.Reason)
+}
+
 // The system attempted to login a User with an incorrect password.
 type PasswordIncorrectError struct{}
 
@@ -66,6 +121,85 @@ func (e *PasswordIncorrectError) Error() string {
 	return "password was incorrect."
 }
 
+// The system attempted to change a User's email address to one that already belongs to another User.
+type EmailInUseError struct {
+	Email string
+}
+
+func (e *EmailInUseError) Error() string {
+	return fmt.Sprintf("email address is already in use. email=%s", e.Email)
+}
+
+// The system attempted to verify an email address change that does not exist.
+type EmailChangeNotFoundError struct {
+	Email string
+	Token string
+}
+
+func (e *EmailChangeNotFoundError) Error() string {
+	return fmt.Sprintf("failed to find email change. email=%s, token=%s", e.Email, e.Token)
+}
+
+// The system attempted to verify an expired email address change.
+type EmailChangeExpiredError struct {
+	Email string
+	Token string
+}
+
+func (e *EmailChangeExpiredError) Error() string {
+	return fmt.Sprintf("email change expired. email=%s, token=%s", e.Email, e.Token)
+}
+
+// The system rejected a login attempt because too many recent attempts have failed.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, retry after %s.", e.RetryAfter)
+}
+
+// The system rejected a login attempt with an unknown email address or incorrect password.
+type LoginFailedError struct{}
+
+func (e *LoginFailedError) Error() string {
+	return "email address or password is incorrect."
+}
+
+// The system attempted to verify a second authentication factor with an invalid or reused code.
+type MFACodeInvalidError struct{}
+
+func (e *MFACodeInvalidError) Error() string {
+	return "two-factor authentication code was invalid."
+}
+
+// The system attempted to enroll a second authentication factor for a User who already has one enabled.
+type MFAAlreadyEnabledError struct {
+	ID UserID
+}
+
+func (e *MFAAlreadyEnabledError) Error() string {
+	return fmt.Sprintf("two-factor authentication is already enabled. id=%s", e.ID)
+}
+
+// The system attempted to verify a second authentication factor for a User who has not enrolled one.
+type MFANotEnrolledError struct {
+	ID UserID
+}
+
+func (e *MFANotEnrolledError) Error() string {
+	return fmt.Sprintf("two-factor authentication has not been enrolled. id=%s", e.ID)
+}
+
+// The system attempted to disable a second authentication factor that is required for the User.
+type MFARequiredError struct {
+	ID UserID
+}
+
+func (e *MFARequiredError) Error() string {
+	return fmt.Sprintf("two-factor authentication is required for this user. id=%s", e.ID)
+}
+
 // The system attempted to authenticate a request with a missing/empty Session-ID header.
 type MissingSessionIDError struct{}
 
@@ -75,11 +209,11 @@ func (e *MissingSessionIDError) Error() string {
 
 // The system attempted to find a Session that does not exist.
 type SessionNotFoundError struct {
-	ID SessionID
+	Handle string // See SessionHandle
 }
 
 func (e *SessionNotFoundError) Error() string {
-	return fmt.Sprintf("failed to find session. id=%s", e.ID)
+	return fmt.Sprintf("failed to find session. handle=%s", e.Handle)
 }
 
 // The system attempted to authenticate a request with an expired Session.
@@ -91,6 +225,180 @@ func (e *SessionExpiredError) Error() string {
 	return fmt.Sprintf("session is expired. id=%s", e.ID)
 }
 
+// The system attempted to authenticate a request with a Session that is still awaiting a second authentication factor.
+type SessionPendingError struct {
+	ID SessionID
+}
+
+func (e *SessionPendingError) Error() string {
+	return fmt.Sprintf("session is awaiting a second authentication factor. id=%s", e.ID)
+}
+
+// The system attempted to log in with an OpenID Connect identity provider that is not configured.
+type OIDCProviderNotFoundError struct {
+	Name string
+}
+
+func (e *OIDCProviderNotFoundError) Error() string {
+	return fmt.Sprintf("oidc provider not found. name=%s", e.Name)
+}
+
+// The system attempted to complete an OpenID Connect login whose state is unknown, expired or already used.
+type OIDCStateInvalidError struct {
+	State string
+}
+
+func (e *OIDCStateInvalidError) Error() string {
+	return fmt.Sprintf("invalid oidc login state. state=%s", e.State)
+}
+
+// The system attempted to log in with an identity whose email address has not been verified by the identity provider.
+type OIDCEmailNotVerifiedError struct {
+	Email string
+}
+
+func (e *OIDCEmailNotVerifiedError) Error() string {
+	return fmt.Sprintf("oidc identity email address is not verified. email=%s", e.Email)
+}
+
+// The system attempted to complete an OpenID Connect login that the identity provider reported as failed.
+type OIDCProviderError struct {
+	Name        string
+	Code        string
+	Description string
+}
+
+func (e *OIDCProviderError) Error() string {
+	return fmt.Sprintf("oidc provider returned an error. name=%s, error=%s (%s)", e.Name, e.Code, e.Description)
+}
+
+// The system attempted to log in with an identity whose email address has no active User or pending Invite.
+type OIDCAccountNotFoundError struct {
+	Email string
+}
+
+func (e *OIDCAccountNotFoundError) Error() string {
+	return fmt.Sprintf("no account or invite exists for oidc identity. email=%s", e.Email)
+}
+
+// The system attempted to change an Administrator's own account in a way that could lock them out of the site.
+type AdminSelfModificationError struct {
+	ID UserID
+}
+
+func (e *AdminSelfModificationError) Error() string {
+	return fmt.Sprintf("administrators may not deactivate or revoke administrator from their own account. id=%s", e.ID)
+}
+
+// The system attempted to change a User or Contestant in a way that would leave a Contestant with no active OWNER.
+type ContestantOwnerRequiredError struct {
+	ContestantID ContestantID
+	UserID       UserID
+}
+
+func (e *ContestantOwnerRequiredError) Error() string {
+	return fmt.Sprintf("contestant must have at least one active owner. contestant=%s, user=%s", e.ContestantID, e.UserID)
+}
+
+// The system attempted to change a Contestant on behalf of a User without the required Role.
+type ContestantForbiddenError struct {
+	ContestantID ContestantID
+	UserID       UserID
+}
+
+func (e *ContestantForbiddenError) Error() string {
+	return fmt.Sprintf("user is not authorized to manage contestant. contestant=%s, user=%s", e.ContestantID, e.UserID)
+}
+
+// The system attempted to describe a Pool on behalf of a User who is neither an Administrator nor authorized for a
+// Contestant in that Pool.
+type PoolForbiddenError struct {
+	PoolID PoolID
+	UserID UserID
+}
+
+func (e *PoolForbiddenError) Error() string {
+	return fmt.Sprintf("user is not a member of pool. pool=%s, user=%s", e.PoolID, e.UserID)
+}
+
+// The system attempted to change the membership of a User who is not an eligible member of a Contestant.
+type ContestantMemberNotFoundError struct {
+	ContestantID ContestantID
+	UserID       UserID
+}
+
+func (e *ContestantMemberNotFoundError) Error() string {
+	return fmt.Sprintf("failed to find contestant member. contestant=%s, user=%s", e.ContestantID, e.UserID)
+}
+
+// The system attempted to assign an unknown or disallowed Role.
+type RoleInvalidError struct {
+	Role roles.Role
+}
+
+func (e *RoleInvalidError) Error() string {
+	return fmt.Sprintf("invalid role. role=%s", e.Role)
+}
+
+// The system attempted to assign a Contestant a Status that does not exist.
+type StatusInvalidError struct {
+	Status status.Status
+}
+
+func (e *StatusInvalidError) Error() string {
+	return fmt.Sprintf("invalid status. status=%s", e.Status)
+}
+
+// The system attempted to sort a Pool's standings in an order that does not exist.
+type StandingsSortInvalidError struct {
+	Sort standings.Sort
+}
+
+func (e *StandingsSortInvalidError) Error() string {
+	return fmt.Sprintf("invalid standings sort. sort=%s", e.Sort)
+}
+
+// The system attempted to find an APIToken that does not exist or has been revoked.
+type APITokenNotFoundError struct {
+	ID APITokenID
+}
+
+func (e *APITokenNotFoundError) Error() string {
+	return fmt.Sprintf("failed to find api token. id=%s", e.ID)
+}
+
+// The system attempted to authenticate a request with an APIToken that is unknown, revoked or expired.
+type APITokenInvalidError struct{}
+
+func (e *APITokenInvalidError) Error() string {
+	return "api token is invalid, revoked or expired."
+}
+
+// The system attempted to load a calendar feed with a feed token that is unknown or has been reset.
+type FeedTokenInvalidError struct{}
+
+func (e *FeedTokenInvalidError) Error() string {
+	return "feed token is invalid or has been reset."
+}
+
+// The system attempted to authenticate a request with an APIToken that lacks the Scope required by the route.
+type APITokenScopeError struct {
+	Scope scopes.Scope
+}
+
+func (e *APITokenScopeError) Error() string {
+	return fmt.Sprintf("api token is missing a required scope. scope=%s", e.Scope)
+}
+
+// The system attempted to create an APIToken with missing or invalid parameters.
+type APITokenRequestInvalidError struct {
+	Reason string
+}
+
+func (e *APITokenRequestInvalidError) Error() string {
+	return fmt.Sprintf("invalid api token request, reason=%s.", e.Reason)
+}
+
 // The system attempted to find a Pool that does not exist or has been deactivated.
 type PoolNotFoundError struct {
 	ID PoolID
@@ -122,7 +430,7 @@ func (e *ScheduleNotFoundError) Error() string {
 		return fmt.Sprintf("failed to find schedule. id=%v", e.ID)
 	}
 
-	if e.Date.IsZero() {
+	if !e.Date.IsZero() {
 		return fmt.Sprintf("failed to find schedule. date=%s", e.Date.Format(time.UnixDate))
 	}
 
@@ -145,11 +453,20 @@ func (e *ScheduleConflictError) Error() string {
 	return fmt.Sprintf("a schedule already exists for year=%d/week=%d (id=%v).", e.Year, e.Week, e.ID)
 }
 
+// The system attempted to create a new Schedule with a date that is not an ISO-8601 date-only string.
+type ScheduleDateInvalidError struct {
+	Date string
+}
+
+func (e *ScheduleDateInvalidError) Error() string {
+	return fmt.Sprintf("invalid schedule date, expected YYYY-MM-DD. date=%s", e.Date)
+}
+
 // The system attempted to create a new Schedule with a closing date/time that falls outside the start and end of the week.
 type ScheduleInvalidClosesError struct {
 	Start   time.Time
 	End     time.Time
-	Request *CreateScheduleRequest
+	Request *CreateScheduleRequest // nil when updating an existing Schedule
 }
 
 func (e *ScheduleInvalidClosesError) Error() string {
@@ -159,6 +476,16 @@ func (e *ScheduleInvalidClosesError) Error() string {
 		e.Request)
 }
 
+// The system attempted to import a season's Schedules from a file containing invalid games.
+type ScheduleImportInvalidError struct {
+	Line   int // Line of the CSV file (or position in the JSON array, starting at 1), or 0 if not specific to a game
+	Reason string
+}
+
+func (e *ScheduleImportInvalidError) Error() string {
+	return fmt.Sprintf("invalid schedule import (line=%d): %s", e.Line, e.Reason)
+}
+
 // The system attempted to add/update a Matchup with incomplete or invalid parameters.
 type MatchupInvalidError struct {
 	ScheduleID ScheduleID
@@ -184,3 +511,83 @@ func (e *MatchupNotFoundError) Error() string {
 		e.ScheduleID,
 		e.MatchupID)
 }
+
+// The system attempted to find an Entry that does not exist or has been deactivated.
+type EntryNotFoundError struct {
+	ID EntryID
+}
+
+func (e *EntryNotFoundError) Error() string {
+	return fmt.Sprintf("failed to find entry. id=%s", e.ID)
+}
+
+// The system attempted to create a new Entry for a Contestant and Schedule which already have an existing Entry.
+type EntryConflictError struct {
+	ContestantID ContestantID
+	ScheduleID   ScheduleID
+	ID           EntryID
+}
+
+func (e *EntryConflictError) Error() string {
+	return fmt.Sprintf("an entry already exists for contestant=%s/schedule=%s (id=%s).", e.ContestantID, e.ScheduleID, e.ID)
+}
+
+// The system attempted to change the picks for a Schedule outside of its open/close window.
+type ScheduleClosedError struct {
+	ID     ScheduleID
+	Opens  time.Time
+	Closes time.Time
+}
+
+func (e *ScheduleClosedError) Error() string {
+	return fmt.Sprintf("schedule is not open for picks (opens=%s, closes=%s). id=%s",
+		e.Opens.Format(time.UnixDate),
+		e.Closes.Format(time.UnixDate),
+		e.ID)
+}
+
+// The system attempted to change the picks of a Contestant that is no longer ACTIVE (e.g. - it has been ELIMINATED).
+type ContestantNotActiveError struct {
+	ContestantID ContestantID
+	Status       status.Status
+}
+
+func (e *ContestantNotActiveError) Error() string {
+	return fmt.Sprintf("contestant is not active (status=%s). contestant=%s", e.Status, e.ContestantID)
+}
+
+// The system attempted to save a pick for a Team that is not part of the specified Matchup.
+type PickInvalidError struct {
+	EntryID   EntryID
+	MatchupID MatchupID
+	TeamID    TeamID
+}
+
+func (e *PickInvalidError) Error() string {
+	return fmt.Sprintf("team is not part of the matchup. entry=%s, matchup=%s, team=%s", e.EntryID, e.MatchupID, e.TeamID)
+}
+
+// The system attempted to save a pick for a Matchup that has already started.
+type MatchupLockedError struct {
+	ScheduleID ScheduleID
+	MatchupID  MatchupID
+	DateTime   time.Time
+}
+
+func (e *MatchupLockedError) Error() string {
+	return fmt.Sprintf("matchup has already started (dateTime=%s). schedule=%s, matchup=%s",
+		e.DateTime.Format(time.UnixDate),
+		e.ScheduleID,
+		e.MatchupID)
+}
+
+// The system attempted to save a pick for a Team that the Contestant has already picked in another week of the season.
+type TeamAlreadyPickedError struct {
+	ContestantID ContestantID
+	TeamID       TeamID
+	ScheduleID   ScheduleID // The Schedule for which the Team was previously picked
+}
+
+func (e *TeamAlreadyPickedError) Error() string {
+	return fmt.Sprintf("team has already been picked this season. contestant=%s, team=%s, schedule=%s", e.ContestantID, e.TeamID, e.ScheduleID)
+}
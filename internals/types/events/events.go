@@ -0,0 +1,17 @@
+package events
+
+type Event string
+
+// The enumerated Events pushed to subscribers of live updates.
+const (
+	// A Contestant's Selected or Suggested picks changed.
+	PICKS Event = "picks"
+	// A pick deadline passed or a Matchup started, publishing the picks made for it.
+	PUBLISHED Event = "published"
+	// The final score of a Matchup was recorded.
+	RESULT Event = "result"
+	// A Contestant was eliminated (or disqualified) from its Pool.
+	ELIMINATED Event = "eliminated"
+	// A Contestant was reinstated (made ACTIVE again) in its Pool.
+	REINSTATED Event = "reinstated"
+)
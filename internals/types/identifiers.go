@@ -26,3 +26,12 @@ type MatchupID string
 
 // The unique identifier of an Entry.
 type EntryID string
+
+// The unique identifier of an AuditEvent.
+type AuditEventID string
+
+// The unique identifier of a personal APIToken.
+type APITokenID string
+
+// The unique identifier of a Notification.
+type NotificationID string
@@ -1,9 +1,16 @@
 package types
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
 	"time"
 
+	"github.com/mhs294/mulhall/internals/types/algorithms"
+	"github.com/mhs294/mulhall/internals/types/audit"
+	"github.com/mhs294/mulhall/internals/types/notifications"
 	"github.com/mhs294/mulhall/internals/types/roles"
+	"github.com/mhs294/mulhall/internals/types/scopes"
 	"github.com/mhs294/mulhall/internals/types/status"
 )
 
@@ -15,33 +22,224 @@ type Team struct {
 	Name      string `json:"name"`
 }
 
+// QuietHoursLayout is the layout of the local times at which a User's quiet hours start and end.
+const QuietHoursLayout = "15:04"
+
 // User represents an individual person within the site and their account details.
 type User struct {
-	ID            UserID `json:"id"`
-	Email         string `json:"email"`
-	Salt          string `json:"-"` // Always omit this field from JSON serialization
-	Hash          string `json:"-"` // Always omit this field from JSON serialization
-	Administrator bool   `json:"administrator"`
-	Active        bool   `json:"active"`
+	ID            UserID     `json:"id"`
+	Email         string     `json:"email"`
+	Salt          string     `json:"-"` // Always omit this field from JSON serialization
+	Hash          string     `json:"-"` // Always omit this field from JSON serialization
+	HashParams    HashParams `json:"-"` // Always omit this field from JSON serialization
+	Administrator bool       `json:"administrator"`
+	Active        bool       `json:"active"`
+
+	// Email change awaiting verification via an emailed link
+	PendingEmail           string    `json:"pendingEmail,omitempty"`
+	PendingEmailToken      string    `json:"-"` // Always omit this field from JSON serialization
+	PendingEmailExpiration time.Time `json:"-"` // Always omit this field from JSON serialization
+
+	// Time-based one-time password (TOTP) second factor
+	TOTPEnabled   bool     `json:"totpEnabled"`
+	TOTPSecret    string   `json:"-"` // Always omit this field from JSON serialization
+	TOTPLastStep  int64    `json:"-"` // Always omit this field from JSON serialization
+	RecoveryCodes []string `json:"-"` // Always omit this field from JSON serialization
+
+	// Hash of the secret token that authenticates the User's calendar feed (empty if the feed is disabled)
+	FeedTokenHash string `json:"-"` // Always omit this field from JSON serialization
+
+	// How and when the User is notified (nil until the User first changes their preferences)
+	Preferences *NotificationPreferences `json:"preferences,omitempty"`
+
+	// Kinds of Notification that the User opted out of before NotificationPreferences were introduced. Only
+	// consulted while Preferences is nil, and cleared once the User's preferences are saved.
+	MutedNotifications []notifications.Kind `json:"mutedNotifications,omitempty"`
+}
+
+// EffectivePreferences returns the User's NotificationPreferences, or the default NotificationPreferences (without
+// any Kinds the User had muted) if the User has not changed them.
+func (u *User) EffectivePreferences() NotificationPreferences {
+	if u.Preferences != nil {
+		return *u.Preferences
+	}
+
+	prefs := DefaultNotificationPreferences()
+	prefs.Kinds = slices.DeleteFunc(prefs.Kinds, func(k notifications.Kind) bool {
+		return slices.Contains(u.MutedNotifications, k)
+	})
+
+	return prefs
+}
+
+// NotificationPreferences describes how and when a User is notified of events in their Pools.
+type NotificationPreferences struct {
+	Channels   []notifications.Channel `json:"channels"`   // Channels through which Notifications are delivered
+	Kinds      []notifications.Kind    `json:"kinds"`      // Kinds of Notification that are delivered
+	WebhookURL string                  `json:"webhookUrl"` // URL to which WEBHOOK Notifications are posted
+	TimeZone   string                  `json:"timeZone"`   // IANA name of the time zone of the quiet hours (e.g. - "America/New_York")
+	QuietStart string                  `json:"quietStart"` // Local time at which quiet hours start (e.g. - "22:00"), if any
+	QuietEnd   string                  `json:"quietEnd"`   // Local time at which quiet hours end (e.g. - "07:00"), if any
+}
+
+// DefaultNotificationPreferences returns the NotificationPreferences of Users who have not changed them: every Kind
+// of Notification is delivered IN_APP, with no quiet hours.
+func DefaultNotificationPreferences() NotificationPreferences {
+	return NotificationPreferences{
+		Channels: []notifications.Channel{notifications.IN_APP},
+		Kinds:    append([]notifications.Kind{}, notifications.Kinds...),
+	}
+}
+
+// Receives indicates whether Notifications of the specified Kind are delivered.
+//
+// kind is the Kind of Notification to check.
+func (p *NotificationPreferences) Receives(kind notifications.Kind) bool {
+	for _, k := range p.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Uses indicates whether Notifications are delivered through the specified Channel.
+//
+// ch is the Channel to check.
+func (p *NotificationPreferences) Uses(ch notifications.Channel) bool {
+	for _, c := range p.Channels {
+		if c == ch {
+			return true
+		}
+	}
+
+	return false
+}
+
+// QuietUntil returns the time at which the quiet hours containing the specified time end, if the time falls within
+// quiet hours. Quiet hours that start later in the day than they end span midnight.
+//
+// t is the time to check.
+func (p *NotificationPreferences) QuietUntil(t time.Time) (time.Time, bool) {
+	if len(p.QuietStart) == 0 || len(p.QuietEnd) == 0 {
+		return time.Time{}, false
+	}
+	start, errStart := time.Parse(QuietHoursLayout, p.QuietStart)
+	end, errEnd := time.Parse(QuietHoursLayout, p.QuietEnd)
+	loc, errLoc := time.LoadLocation(p.TimeZone)
+	if errStart != nil || errEnd != nil || errLoc != nil || start.Equal(end) {
+		return time.Time{}, false
+	}
+
+	// Compare the minute of the day at which the time occurs locally to the quiet hours
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+	var quiet bool
+	if startMinute < endMinute {
+		quiet = minute >= startMinute && minute < endMinute
+	} else {
+		quiet = minute >= startMinute || minute < endMinute
+	}
+	if !quiet {
+		return time.Time{}, false
+	}
+
+	// The quiet hours end at the next occurrence of the end time
+	until := time.Date(local.Year(), local.Month(), local.Day(), end.Hour(), end.Minute(), 0, 0, loc)
+	if !until.After(local) {
+		until = time.Date(local.Year(), local.Month(), local.Day()+1, end.Hour(), end.Minute(), 0, 0, loc)
+	}
+
+	return until, true
+}
+
+// HashParams describes the Algorithm and parameters that were used to produce a User's password Hash.
+// Parameters that do not apply to the Algorithm are left as zero values.
+type HashParams struct {
+	Algorithm algorithms.Algorithm `json:"algorithm"`
+	Cost      int                  `json:"cost"`      // bcrypt cost
+	Time      uint32               `json:"time"`      // argon2id number of passes over memory
+	Memory    uint32               `json:"memory"`    // argon2id memory in KiB
+	Threads   uint8                `json:"threads"`   // argon2id degree of parallelism
+	KeyLength uint32               `json:"keyLength"` // argon2id length of the derived key in bytes
 }
 
 // Invite represents an invitation for a new user to create an account with the site and join a Contestant.
 type Invite struct {
 	ID           InviteID     `json:"id"`
 	Email        string       `json:"email"`
-	Contestant   ContestantID `json:"contestant"`
+	Contestant   ContestantID `json:"contestant"` // Empty if the invited User will be the OWNER of a new Contestant
 	Role         roles.Role   `json:"role"`
 	InvitingUser UserID       `json:"invitingUser"`
 	Token        string       `json:"-"` // Always omit this field from JSON serialization
 	Expiration   time.Time    `json:"expiration"`
 	Accepted     bool         `json:"accepted"`
+
+	// Name of the new Contestant created when the Invite is accepted (if it is not for an existing Contestant)
+	ContestantName string `json:"contestantName,omitempty"`
 }
 
 // Session represents an authentication session for a logged in user.
 type Session struct {
-	ID         SessionID
-	User       UserID
-	Expiration time.Time
+	ID         SessionID `json:"-"` // Always omit this field from JSON serialization (it is the cookie credential)
+	User       UserID    `json:"user"`
+	Created    time.Time `json:"created"`
+	Expiration time.Time `json:"expiration"`
+	Pending    bool      `json:"pending"` // Awaiting a second authentication factor
+}
+
+// Handle returns the non-secret handle used to refer to the Session outside of its cookie (see SessionHandle).
+func (s *Session) Handle() string {
+	return SessionHandle(s.ID)
+}
+
+// SessionHandle returns the non-secret handle used to list and revoke the Session with the provided ID. The handle
+// is a hash of the ID, so it cannot be used to authenticate as the Session.
+//
+// id is the unique identifier of the Session.
+func SessionHandle(id SessionID) string {
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:])
+}
+
+// OIDCState represents an in-progress OpenID Connect login, correlating the identity provider's callback with
+// the secrets generated when the login began.
+type OIDCState struct {
+	State      string    `json:"state"`
+	Provider   string    `json:"provider"`
+	Nonce      string    `json:"-"` // Always omit this field from JSON serialization
+	Verifier   string    `json:"-"` // Always omit this field from JSON serialization
+	Expiration time.Time `json:"expiration"`
+}
+
+// APIToken represents a named personal access token that a User can use to authenticate scripted API requests.
+type APIToken struct {
+	ID         APITokenID     `json:"id"`
+	User       UserID         `json:"user"`
+	Name       string         `json:"name"`
+	Prefix     string         `json:"prefix"` // Leading characters of the token, used to identify it in listings
+	Hash       string         `json:"-"`      // Always omit this field from JSON serialization
+	Scopes     []scopes.Scope `json:"scopes"`
+	Created    time.Time      `json:"created"`
+	Expiration time.Time      `json:"expiration"` // Zero value if the token never expires
+	LastUsed   time.Time      `json:"lastUsed"`
+	Revoked    bool           `json:"revoked"`
+}
+
+// HasScope indicates whether the APIToken has been granted the specified Scope.
+//
+// scope is the Scope to check for.
+func (t *APIToken) HasScope(scope scopes.Scope) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Pool defines a set of rules for an elimination game in which a group of Contestants compete.
@@ -60,6 +258,17 @@ type Contestant struct {
 	AuthorizedUsers map[UserID]roles.Role `json:"authorizedUsers"`
 	Status          status.Status         `json:"status"`
 	Active          bool                  `json:"active"`
+
+	// Matchup result that ELIMINATED the Contestant (nil if it is ACTIVE or an Administrator set its Status)
+	Elimination *Elimination `json:"elimination,omitempty"`
+}
+
+// Elimination describes the Matchup whose result eliminated a Contestant, so that the elimination can be reversed
+// if the result is corrected.
+type Elimination struct {
+	Schedule ScheduleID `json:"schedule"`
+	Matchup  MatchupID  `json:"matchup"`
+	Week     int        `json:"week"`
 }
 
 // Schedule represents a set of Matchups that occur within a given week.
@@ -75,11 +284,47 @@ type Schedule struct {
 	Active   bool                  `json:"active"`
 }
 
+// IsOpen indicates whether picks can be made for the Schedule at the specified time.
+//
+// t is the time to check.
+func (s *Schedule) IsOpen(t time.Time) bool {
+	return !t.Before(s.Opens) && !t.After(s.Closes)
+}
+
 // Matchup represents an individual game between two Teams.
 type Matchup struct {
-	AwayTeam TeamID    `json:"awayTeam"`
-	HomeTeam TeamID    `json:"homeTeam"`
-	DateTime time.Time `json:"dateTime"`
+	AwayTeam TeamID    `json:"awayTeam" binding:"required,teamid"`
+	HomeTeam TeamID    `json:"homeTeam" binding:"required,teamid,nefield=AwayTeam"`
+	DateTime time.Time `json:"dateTime" binding:"required"`
+
+	// Final score, recorded once the Matchup has been completed (nil until then)
+	Result *MatchupResult `json:"result,omitempty"`
+}
+
+// MatchupResult contains the final score of a completed Matchup.
+type MatchupResult struct {
+	AwayScore int `json:"awayScore"`
+	HomeScore int `json:"homeScore"`
+}
+
+// IsLocked indicates whether the Matchup has started (and can therefore no longer be picked) at the specified time.
+//
+// t is the time to check.
+func (m *Matchup) IsLocked(t time.Time) bool {
+	return !t.Before(m.DateTime)
+}
+
+// Winner returns the ID of the Team that won the Matchup, or an empty TeamID if the Matchup has not been
+// completed or ended in a tie.
+func (m *Matchup) Winner() TeamID {
+	switch {
+	case m.Result == nil || m.Result.AwayScore == m.Result.HomeScore:
+		return ""
+	case m.Result.AwayScore > m.Result.HomeScore:
+		return m.AwayTeam
+	default:
+		return m.HomeTeam
+	}
 }
 
 // Entry represents a Contestant's pick for a given Schedule, as well as any potential suggested picks.
@@ -89,4 +334,60 @@ type Entry struct {
 	Schedule       ScheduleID           `json:"schedule"`
 	SelectedPick   map[MatchupID]TeamID `json:"selectedPick"`
 	SuggestedPicks map[MatchupID]TeamID `json:"suggestedPicks"`
+	SuggestedBy    map[MatchupID]UserID `json:"suggestedBy"` // User who made each of the SuggestedPicks
+	Active         bool                 `json:"active"`
+}
+
+// LoginAttempt tracks recent failed login attempts for a single account or client address.
+type LoginAttempt struct {
+	Key         string    `json:"key"`
+	Failures    int       `json:"failures"`
+	LastFailure time.Time `json:"lastFailure"`
+	LockedUntil time.Time `json:"lockedUntil"`
+}
+
+// AuditEvent represents a record of a single event that occurs as a result of a user interacting with the system.
+type AuditEvent struct {
+	ID        AuditEventID      `json:"id"`
+	User      UserID            `json:"user"`
+	Action    audit.Action      `json:"action"`
+	Resource  audit.Resource    `json:"resource"`
+	Details   map[string]string `json:"details"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// Notification represents a message sent to a User about an event in one of their Pools.
+type Notification struct {
+	ID      NotificationID     `json:"id"`
+	User    UserID             `json:"user"`
+	Kind    notifications.Kind `json:"kind"`
+	Key     string             `json:"-"` // Identifies the event notified of, so that each User is only notified once
+	Title   string             `json:"title"`
+	Message string             `json:"message"`
+	Link    string             `json:"link"` // Page on which the User can act on the Notification
+	Created time.Time          `json:"created"`
+	Read    bool               `json:"read"`
+
+	// Channels through which the Notification has been delivered
+	Channels []notifications.Channel `json:"channels"`
+
+	// Channels through which the Notification will be delivered at DeliverAt (once the User's quiet hours end)
+	Pending   []notifications.Channel `json:"pending,omitempty"`
+	DeliverAt time.Time               `json:"deliverAt,omitempty"`
+
+	// Time after which the Notification is no longer worth delivering through the Pending Channels (e.g. - the pick
+	// deadline of a reminder), if any
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+// Expired indicates whether the Notification is no longer worth delivering at the specified time.
+//
+// t is the time to check.
+func (n *Notification) Expired(t time.Time) bool {
+	return !n.Expires.IsZero() && !t.Before(n.Expires)
+}
+
+// Settings contains site-wide configuration that can be changed by Administrators at runtime.
+type Settings struct {
+	RequireAdministratorMFA bool `json:"requireAdministratorMfa"`
 }
@@ -0,0 +1,35 @@
+package notifications
+
+type Kind string
+
+// The enumerated Kinds of Notification sent to Users.
+const (
+	// An Invite sent by the User was accepted.
+	INVITE Kind = "Invite"
+	// A reminder that picks for the current week close soon and a Contestant has not Selected a pick.
+	REMINDER Kind = "Reminder"
+	// The picks for a week were published once its pick deadline passed.
+	PUBLISHED Kind = "Published"
+	// One of the User's Contestants was eliminated (or disqualified).
+	ELIMINATION Kind = "Elimination"
+	// A Pool that one of the User's Contestants belongs to was completed.
+	POOL_RESULT Kind = "PoolResult"
+)
+
+// Kinds lists every Kind of Notification, in the order they are presented to Users.
+var Kinds = []Kind{INVITE, REMINDER, PUBLISHED, ELIMINATION, POOL_RESULT}
+
+type Channel string
+
+// The enumerated Channels through which Notifications are delivered.
+const (
+	// Shown on the User's Notifications page.
+	IN_APP Channel = "InApp"
+	// Emailed to the User's email address.
+	EMAIL Channel = "Email"
+	// Posted as JSON to the User's webhook URL.
+	WEBHOOK Channel = "Webhook"
+)
+
+// Channels lists every Channel, in the order they are presented to Users.
+var Channels = []Channel{IN_APP, EMAIL, WEBHOOK}
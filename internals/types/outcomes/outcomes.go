@@ -0,0 +1,15 @@
+package outcomes
+
+type Outcome string
+
+// The enumerated Outcomes of a Contestant's Selected pick for a week.
+const (
+	// The Selected Team won its Matchup.
+	WON Outcome = "Won"
+	// The Selected Team lost (or tied) its Matchup, eliminating the Contestant.
+	LOST Outcome = "Lost"
+	// No pick was Selected before the Schedule closed, eliminating the Contestant.
+	MISSED Outcome = "Missed"
+	// The Matchup of the Selected Team (or the Schedule itself) has not yet been completed.
+	PENDING Outcome = "Pending"
+)
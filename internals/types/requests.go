@@ -3,72 +3,238 @@ package types
 import (
 	"time"
 
+	"github.com/mhs294/mulhall/internals/types/notifications"
 	"github.com/mhs294/mulhall/internals/types/roles"
+	"github.com/mhs294/mulhall/internals/types/scopes"
+	"github.com/mhs294/mulhall/internals/types/status"
 )
 
 // CreateInviteRequest contains all of the information necessary to create an Invite for a new User.
+// The Invite is sent on behalf of the acting User.
+// If no ContestantID is provided, the invited User becomes the OWNER of a new Contestant named ContestantName.
 type CreateInviteRequest struct {
+	Email          string       `json:"email" binding:"required,email"`
+	ContestantID   ContestantID `json:"contestantId,omitempty" binding:"required_without=ContestantName"`
+	ContestantName string       `json:"contestantName,omitempty" binding:"max=100"`
+	Role           roles.Role   `json:"role" binding:"required,role"`
+}
+
+// CheckInviteRequest contains a partially completed CreateInviteRequest to be checked while it is being filled out.
+// Fields that have not been provided yet are not checked.
+type CheckInviteRequest struct {
 	Email          string       `json:"email"`
 	ContestantID   ContestantID `json:"contestantId"`
+	NewContestant  bool         `json:"newContestant"` // Whether a new Contestant has been chosen instead
+	ContestantName string       `json:"contestantName"`
 	Role           roles.Role   `json:"role"`
-	InvitingUserID UserID       `json:"invitingUserId"`
 }
 
 // RegisterUserRequest contains all of the information necessary to register an account for a new User.
 type RegisterUserRequest struct {
-	Email    string `json:"email"`
-	Token    string `json:"token"`
-	Password string `json:"password"`
-	Confirm  string `json:"confirm"`
+	Email    string `json:"email" binding:"required,email"`
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Confirm  string `json:"confirm" binding:"required"`
 }
 
 // RegisterUserRequest contains all of the information necessary to log in a User.
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// ChangePasswordRequest contains all of the information necessary to change the password of a logged in User.
+type ChangePasswordRequest struct {
+	Current  string `json:"current" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Confirm  string `json:"confirm" binding:"required"`
+}
+
+// ChangeEmailRequest contains all of the information necessary to request a change to a logged in User's email address.
+type ChangeEmailRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// RevokeSessionRequest contains all of the information necessary to revoke one of a logged in User's Sessions.
+type RevokeSessionRequest struct {
+	Handle string `json:"handle" binding:"required"` // See SessionResponse
+}
+
+// VerifyMFARequest contains all of the information necessary to verify a User's second authentication factor.
+type VerifyMFARequest struct {
+	Code string `json:"code" binding:"required"` // TOTP code or single-use recovery code
+}
+
+// DisableMFARequest contains all of the information necessary to disable a logged in User's second authentication factor.
+type DisableMFARequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// SetMFARequirementRequest contains all of the information necessary to require (or stop requiring) a second
+// authentication factor for all Administrators.
+type SetMFARequirementRequest struct {
+	Required bool `json:"required"`
+}
+
+// CreateAPITokenRequest contains all of the information necessary to create a personal APIToken for a logged in User.
+type CreateAPITokenRequest struct {
+	Name       string         `json:"name" binding:"required,max=100"`
+	Scopes     []scopes.Scope `json:"scopes" binding:"required,min=1,dive,scope"`
+	Expiration *time.Time     `json:"expiration"` // Optional, the token never expires if omitted
+}
+
+// RevokeAPITokenRequest contains all of the information necessary to revoke one of a logged in User's APITokens.
+type RevokeAPITokenRequest struct {
+	TokenID APITokenID `json:"tokenId" binding:"required"`
+}
+
+// SetUserActiveRequest contains all of the information necessary for an Administrator to activate or deactivate
+// a User's account.
+type SetUserActiveRequest struct {
+	Active bool `json:"active"`
+}
+
+// SetAdministratorRequest contains all of the information necessary for an Administrator to grant or revoke
+// another User's Administrator privileges.
+type SetAdministratorRequest struct {
+	Administrator bool `json:"administrator"`
 }
 
 // CreatePoolRequest contains all of the information necessary to create a new Pool.
 type CreatePoolRequest struct {
-	Name string `json:"name"`
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// PoolContestantRequest contains all of the information necessary to add a Contestant to, or remove a Contestant
+// from, a Pool.
+type PoolContestantRequest struct {
+	ContestantID ContestantID `json:"contestantId" binding:"required"`
 }
 
 // CreateContestantRequest contains all of the information necessary to create a new Contestant.
 type CreateContestantRequest struct {
-	Name            string                `json:"name"`
-	AuthorizedUsers map[UserID]roles.Role `json:"authorizedUsers"`
+	Name            string                `json:"name" binding:"required,max=100"`
+	AuthorizedUsers map[UserID]roles.Role `json:"authorizedUsers" binding:"required,min=1,dive,keys,required,endkeys,role"`
+}
+
+// SetContestantRoleRequest contains all of the information necessary for a Contestant OWNER to promote or demote
+// one of the Contestant's members.
+type SetContestantRoleRequest struct {
+	UserID UserID     `json:"userId" binding:"required"`
+	Role   roles.Role `json:"role" binding:"required,role"`
+}
+
+// TransferOwnershipRequest contains all of the information necessary for a Contestant OWNER to give ownership
+// of the Contestant to another of its members.
+type TransferOwnershipRequest struct {
+	UserID UserID `json:"userId" binding:"required"`
+}
+
+// SetContestantStatusRequest contains the new Status to apply to a Contestant.
+type SetContestantStatusRequest struct {
+	Status status.Status `json:"status" binding:"required,status"`
 }
 
 // CreateScheduleRequest contains all of the information necessary to create a new, empty Schedule.
 type CreateScheduleRequest struct {
-	Year   int       `json:"year"`
-	Week   int       `json:"week"`
-	Date   string    `json:"date"` // ISO-8601 date-only string representation (e.g. - "2025-02-09")
-	Closes time.Time `json:"closes"`
+	Year   int       `json:"year" binding:"required,min=1920"`
+	Week   int       `json:"week" binding:"required,min=1,max=22"`
+	Date   string    `json:"date" binding:"required,datetime=2006-01-02"` // ISO-8601 date-only string representation (e.g. - "2025-02-09")
+	Closes time.Time `json:"closes" binding:"required"`
+}
+
+// UpdateScheduleClosesRequest contains all of the information necessary to change when picks close for a Schedule.
+type UpdateScheduleClosesRequest struct {
+	ScheduleID ScheduleID `json:"scheduleId" binding:"required"`
+	Closes     time.Time  `json:"closes" binding:"required"`
+}
+
+// ImportedGame describes a single game of a season, as read from a schedule file by the schedule importer.
+type ImportedGame struct {
+	Year     int       `json:"year"`
+	Week     int       `json:"week"`
+	Kickoff  time.Time `json:"kickoff"`
+	AwayTeam string    `json:"away"` // Shorthand of the away Team (e.g. - "BUF")
+	HomeTeam string    `json:"home"` // Shorthand of the home Team
+	Line     int       `json:"-"`    // Line of the CSV file (or position in the JSON array) that the game was read from
 }
 
 // CreateMatchupRequest contains all of the information necessary to create a new Machup to add to a Schedule.
 type CreateMatchupRequest struct {
-	ScheduleID ScheduleID `json:"scheduleId"`
-	Matchup    *Matchup   `json:"matchup"`
+	ScheduleID ScheduleID `json:"scheduleId" binding:"required"`
+	Matchup    *Matchup   `json:"matchup" binding:"required"`
+}
+
+// RemoveMatchupRequest contains all of the information necessary to remove a Matchup from a Schedule.
+type RemoveMatchupRequest struct {
+	ScheduleID ScheduleID `json:"scheduleId" binding:"required"`
+	MatchupID  MatchupID  `json:"matchupId" binding:"required"`
 }
 
 // UpdateMatchupRequest contains all of the information necessary to update an existing Matchup within a Schedule.
 type UpdateMatchupRequest struct {
-	ScheduleID ScheduleID `json:"scheduleId"`
-	MatchupID  MatchupID  `json:"matchupId"`
-	Matchup    *Matchup   `json:"matchup"`
+	ScheduleID ScheduleID `json:"scheduleId" binding:"required"`
+	MatchupID  MatchupID  `json:"matchupId" binding:"required"`
+	Matchup    *Matchup   `json:"matchup" binding:"required"`
+}
+
+// RecordResultRequest contains all of the information necessary to record the final score of a Matchup.
+type RecordResultRequest struct {
+	ScheduleID ScheduleID `json:"scheduleId" binding:"required"`
+	MatchupID  MatchupID  `json:"matchupId" binding:"required"`
+	AwayScore  int        `json:"awayScore" binding:"gte=0"`
+	HomeScore  int        `json:"homeScore" binding:"gte=0"`
 }
 
 // CreateEntryRequest contains all of the information necessary to create a new Entry for a Contestant in a Pool.
 type CreateEntryRequest struct {
-	ContestantID ContestantID `json:"contestantId"`
-	ScheduleID   ScheduleID   `json:"scheduleId"`
+	ContestantID ContestantID `json:"contestantId" binding:"required"`
+	ScheduleID   ScheduleID   `json:"scheduleId" binding:"required"`
 }
 
 // SavePickRequest contains all of the information necessary to save a Selected/Suggested pick for an Entry.
+// If EntryID is omitted, the pick is saved to the Contestant's Entry for the Schedule, starting one if necessary.
 type SavePickRequest struct {
-	EntryID   EntryID   `json:"entryId"`
-	MatchupID MatchupID `json:"matchupId"`
-	TeamID    TeamID    `json:"teamId"`
+	EntryID      EntryID      `json:"entryId,omitempty"`
+	ContestantID ContestantID `json:"contestantId,omitempty" binding:"required_without=EntryID"`
+	ScheduleID   ScheduleID   `json:"scheduleId,omitempty" binding:"required_without=EntryID"`
+	MatchupID    MatchupID    `json:"matchupId" binding:"required"`
+	TeamID       TeamID       `json:"teamId" binding:"required,teamid"`
+}
+
+// ClearPickRequest contains all of the information necessary to clear the Selected pick for an Entry.
+type ClearPickRequest struct {
+	EntryID EntryID `json:"entryId" binding:"required"`
+}
+
+// MarkNotificationsReadRequest contains the Notifications that a User has read. If no IDs are provided, all of the
+// User's Notifications are marked as read.
+type MarkNotificationsReadRequest struct {
+	IDs []NotificationID `json:"ids"`
+}
+
+// MuteNotificationsRequest contains all of the information necessary to opt a User in to or out of a Kind of
+// Notification. Superseded by UpdateNotificationPreferencesRequest.
+type MuteNotificationsRequest struct {
+	Kind  notifications.Kind `json:"kind" binding:"required"`
+	Muted bool               `json:"muted"`
+}
+
+// UpdateNotificationPreferencesRequest contains all of the information necessary to change how and when a User is
+// notified (see NotificationPreferences).
+type UpdateNotificationPreferencesRequest struct {
+	Channels   []notifications.Channel `json:"channels"`
+	Kinds      []notifications.Kind    `json:"kinds"`
+	WebhookURL string                  `json:"webhookUrl" binding:"max=500"`
+	TimeZone   string                  `json:"timeZone" binding:"max=100"`
+	QuietStart string                  `json:"quietStart"`
+	QuietEnd   string                  `json:"quietEnd"`
+}
+
+// RemoveSuggestedPickRequest contains all of the information necessary to remove a Suggested pick from an Entry.
+type RemoveSuggestedPickRequest struct {
+	EntryID   EntryID   `json:"entryId" binding:"required"`
+	MatchupID MatchupID `json:"matchupId" binding:"required"`
 }
@@ -0,0 +1,282 @@
+package types
+
+import (
+	"time"
+
+	"github.com/mhs294/mulhall/internals/types/events"
+	"github.com/mhs294/mulhall/internals/types/outcomes"
+	"github.com/mhs294/mulhall/internals/types/roles"
+	"github.com/mhs294/mulhall/internals/types/standings"
+	"github.com/mhs294/mulhall/internals/types/status"
+)
+
+// ErrorResponse describes why a request could not be completed. Code is a stable, machine-readable identifier
+// for the kind of failure, Message is suitable for display to a User and Details contains any additional
+// information specific to the failure (e.g. - the ID of a conflicting record).
+type ErrorResponse struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// MFAStatusResponse describes whether a User has a second authentication factor enabled, and whether one is required.
+type MFAStatusResponse struct {
+	Enabled  bool `json:"enabled"`
+	Required bool `json:"required"`
+}
+
+// MFAEnrollmentResponse contains the TOTP secret generated for a User, along with the otpauth:// provisioning
+// URI that authenticator apps can enroll from a QR code.
+type MFAEnrollmentResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// RecoveryCodesResponse contains single-use recovery codes for a User's second authentication factor.
+// These codes are only ever shown once.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// CreateAPITokenResponse contains a newly created APIToken along with its secret value.
+// The secret is only ever shown once.
+type CreateAPITokenResponse struct {
+	Token  *APIToken `json:"token"`
+	Secret string    `json:"secret"`
+}
+
+// OIDCProviderResponse describes an OpenID Connect identity provider that Users can log in with.
+type OIDCProviderResponse struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	LoginURL    string `json:"loginUrl"`
+}
+
+// SessionResponse describes one of a User's active Sessions without revealing its ID, which is the Session's cookie
+// credential. Sessions are listed and revoked by their non-secret Handle instead.
+type SessionResponse struct {
+	Handle     string    `json:"handle"`
+	Created    time.Time `json:"created"`
+	Expiration time.Time `json:"expiration"`
+	Current    bool      `json:"current"` // Whether this is the Session that made the request
+}
+
+// UserDetailsResponse contains a User's account details along with their Contestant memberships, for Administrators.
+type UserDetailsResponse struct {
+	User        *User                `json:"user"`
+	Memberships []MembershipResponse `json:"memberships"`
+}
+
+// MembershipResponse describes a User's Role within a single Contestant.
+type MembershipResponse struct {
+	Contestant ContestantID `json:"contestant"`
+	Name       string       `json:"name"`
+	Role       roles.Role   `json:"role"`
+}
+
+// WeeklyPicksResponse describes the picks that a Contestant can make for the Schedule of a single week, on behalf
+// of one of its authorized Users.
+type WeeklyPicksResponse struct {
+	Memberships    []MembershipResponse   `json:"memberships"` // All of the acting User's Contestant memberships
+	Contestant     *Contestant            `json:"contestant"`  // nil if the acting User is not a member of any Contestant
+	Role           roles.Role             `json:"role"`        // The acting User's Role within the Contestant
+	Schedule       *Schedule              `json:"schedule"`    // nil if there is no Schedule for the week
+	Entry          *Entry                 `json:"entry"`       // nil if no Entry exists and picks are closed; has no ID until the first pick is saved
+	Open           bool                   `json:"open"`        // Whether the Schedule is open and the Contestant is ACTIVE
+	Matchups       []MatchupPicksResponse `json:"matchups"`    // Sorted by kickoff
+	SelectedPick   *PickResponse          `json:"selectedPick"`
+	SuggestedPicks []PickResponse         `json:"suggestedPicks"`
+	UsedTeams      map[TeamID]ScheduleID  `json:"usedTeams"` // Teams Selected for other weeks of the season
+}
+
+// MatchupPicksResponse describes a single Matchup that can be picked, including the details of both Teams.
+type MatchupPicksResponse struct {
+	ID       MatchupID `json:"id"`
+	AwayTeam Team      `json:"awayTeam"`
+	HomeTeam Team      `json:"homeTeam"`
+	DateTime time.Time `json:"dateTime"`
+	Locked   bool      `json:"locked"` // Whether the Matchup has started
+}
+
+// ScheduleEditorResponse describes a Schedule as it is shown in the admin schedule editor.
+type ScheduleEditorResponse struct {
+	Schedule *Schedule               `json:"schedule"`
+	Matchups []MatchupEditorResponse `json:"matchups"` // Sorted by kickoff
+	Teams    []Team                  `json:"teams"`    // All Teams that can be added to a Matchup
+	Byes     []Team                  `json:"byes"`     // Teams that are not featured in any of the Schedule's Matchups
+}
+
+// MatchupEditorResponse describes a single Matchup in the admin schedule editor, including the details of both Teams.
+type MatchupEditorResponse struct {
+	ID       MatchupID      `json:"id"`
+	AwayTeam Team           `json:"awayTeam"`
+	HomeTeam Team           `json:"homeTeam"`
+	DateTime time.Time      `json:"dateTime"`
+	Result   *MatchupResult `json:"result,omitempty"`
+}
+
+// ScheduleImportResponse describes the changes that a schedule import made (or, for a dry run, would make) to
+// each week of a season.
+type ScheduleImportResponse struct {
+	DryRun bool                 `json:"dryRun"`
+	Weeks  []WeekImportResponse `json:"weeks"` // Sorted by year and week
+}
+
+// WeekImportResponse describes the changes that a schedule import made (or would make) to a single week's Schedule.
+type WeekImportResponse struct {
+	Year      int                     `json:"year"`
+	Week      int                     `json:"week"`
+	Schedule  ScheduleID              `json:"schedule,omitempty"` // Empty if the Schedule would be created by a dry run
+	Created   bool                    `json:"created"`            // Whether the Schedule was (or would be) created
+	Closes    time.Time               `json:"closes"`
+	Added     []MatchupImportResponse `json:"added"`
+	Updated   []MatchupImportResponse `json:"updated"` // Matchups whose kickoff changed
+	Unchanged int                     `json:"unchanged"`
+	Unlisted  []MatchupImportResponse `json:"unlisted"` // Existing Matchups that are not in the file (left as they are)
+}
+
+// MatchupImportResponse describes a single Matchup affected by a schedule import.
+type MatchupImportResponse struct {
+	Matchup         MatchupID  `json:"matchup,omitempty"`
+	AwayTeam        string     `json:"away"`
+	HomeTeam        string     `json:"home"`
+	Kickoff         time.Time  `json:"kickoff"`
+	PreviousKickoff *time.Time `json:"previousKickoff,omitempty"` // Set for updated Matchups
+}
+
+// Changed indicates whether the import changed (or would change) any Schedule.
+func (r *ScheduleImportResponse) Changed() bool {
+	for _, w := range r.Weeks {
+		if w.Created || len(w.Added) > 0 || len(w.Updated) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LiveUpdateResponse describes a change pushed to the subscribers of live updates. Only the fields relevant to the
+// Event are set.
+type LiveUpdateResponse struct {
+	Event      events.Event `json:"event"`
+	Pool       PoolID       `json:"pool,omitempty"`
+	Contestant ContestantID `json:"contestant,omitempty"`
+	Schedule   ScheduleID   `json:"schedule,omitempty"`
+	Matchup    MatchupID    `json:"matchup,omitempty"`
+	Week       int          `json:"week,omitempty"`
+}
+
+// NotificationPreferencesResponse describes a User's NotificationPreferences along with the Channels available to
+// them.
+type NotificationPreferencesResponse struct {
+	Preferences    NotificationPreferences `json:"preferences"`
+	EmailAvailable bool                    `json:"emailAvailable"` // Whether the site is configured to send email
+	Saved          bool                    `json:"saved,omitempty"`
+}
+
+// CalendarFeedResponse contains the URL of a User's calendar feed, which includes its secret feed token.
+type CalendarFeedResponse struct {
+	URL string `json:"url"`
+}
+
+// PickResponse describes a Selected or Suggested pick, including the details of the Team that was picked.
+type PickResponse struct {
+	Matchup     MatchupID `json:"matchup"`
+	Team        Team      `json:"team"`
+	SuggestedBy string    `json:"suggestedBy,omitempty"` // Email address of the User who suggested the pick
+	Removable   bool      `json:"removable,omitempty"`   // Whether the acting User can remove the suggestion
+}
+
+// CanManage indicates whether the acting User can Select picks and remove any suggestion for the Contestant.
+func (r *WeeklyPicksResponse) CanManage() bool {
+	return r.Role == roles.OWNER || r.Role == roles.MANAGER
+}
+
+// CanPick indicates whether the Team can currently be picked in the Matchup.
+//
+// m is the Matchup in which the Team plays.
+//
+// teamID is the unique identifier of the Team.
+func (r *WeeklyPicksResponse) CanPick(m MatchupPicksResponse, teamID TeamID) bool {
+	_, used := r.UsedTeams[teamID]
+	return r.Open && r.Entry != nil && !m.Locked && !used
+}
+
+// SeasonPicksResponse describes a Contestant's Selected pick for each week of a season, on behalf of one of its
+// authorized Users.
+type SeasonPicksResponse struct {
+	Memberships    []MembershipResponse `json:"memberships"` // All of the acting User's Contestant memberships
+	Contestant     *Contestant          `json:"contestant"`  // nil if the acting User is not a member of any Contestant
+	Year           int                  `json:"year"`
+	Weeks          []WeekPickResponse   `json:"weeks"`                    // Sorted by week
+	EliminatedWeek int                  `json:"eliminatedWeek,omitempty"` // Week in which the Contestant was eliminated, if any
+}
+
+// WeekPickResponse describes a Contestant's Selected pick for a single week and its Outcome.
+type WeekPickResponse struct {
+	Week       int              `json:"week"`
+	Schedule   ScheduleID       `json:"schedule"`
+	Pick       *PickResponse    `json:"pick"` // nil if no pick has been Selected
+	Outcome    outcomes.Outcome `json:"outcome"`
+	Eliminated bool             `json:"eliminated"` // Whether the Contestant was eliminated in (or before) this week
+}
+
+// Alive indicates whether the Contestant is still eligible to win the Pool.
+func (r *SeasonPicksResponse) Alive() bool {
+	return r.Contestant != nil && r.Contestant.Status == status.ACTIVE && r.EliminatedWeek == 0
+}
+
+// StandingsResponse describes the standings of the Contestants in a Pool for a season.
+type StandingsResponse struct {
+	Pool      *Pool              `json:"pool"`
+	Year      int                `json:"year"`
+	Status    status.Status      `json:"status,omitempty"` // Only Contestants with this Status are included, if set
+	Sort      standings.Sort     `json:"sort"`
+	Standings []StandingResponse `json:"standings"`
+}
+
+// StandingResponse describes a single Contestant's standing within a Pool.
+type StandingResponse struct {
+	Contestant     ContestantID  `json:"contestant"`
+	Name           string        `json:"name"`
+	Status         status.Status `json:"status"`
+	WeeksSurvived  int           `json:"weeksSurvived"`
+	TeamsUsed      []Team        `json:"teamsUsed"`                // Teams whose picks have been published, sorted by week
+	CurrentPick    *PickResponse `json:"currentPick"`              // nil until the current week's pick has been published
+	EliminatedWeek int           `json:"eliminatedWeek,omitempty"` // Week in which the Contestant was eliminated, if any
+}
+
+// InviteFormResponse describes the choices available to a User inviting a new User, along with the values
+// entered so far and any problems with them.
+type InviteFormResponse struct {
+	Options        []InviteOptionResponse `json:"options"`                 // Contestants that the User can invite new Users to
+	NewContestant  *InviteOptionResponse  `json:"newContestant,omitempty"` // Set if the User can invite the OWNER of a new Contestant
+	Email          string                 `json:"email"`
+	ContestantID   ContestantID           `json:"contestantId"`   // Empty if the Invite is for a new Contestant
+	ContestantName string                 `json:"contestantName"` // Name of the new Contestant, if any
+	Role           roles.Role             `json:"role"`
+	Fields         map[string]string      `json:"fields,omitempty"` // Problems with each field, keyed by JSON name
+	Sent           string                 `json:"sent,omitempty"`   // Email address of the Invite that was just sent, if any
+}
+
+// InviteOptionResponse describes a Contestant that a User can invite new Users to, and the Roles they can be given.
+type InviteOptionResponse struct {
+	Contestant ContestantID `json:"contestant"`
+	Name       string       `json:"name"`
+	Roles      []roles.Role `json:"roles"`
+}
+
+// Selected returns the InviteOptionResponse for the chosen Contestant (or the new Contestant), or nil if no valid
+// Contestant has been chosen.
+func (r *InviteFormResponse) Selected() *InviteOptionResponse {
+	if len(r.ContestantID) == 0 {
+		return r.NewContestant
+	}
+	for i := range r.Options {
+		if r.Options[i].Contestant == r.ContestantID {
+			return &r.Options[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package scopes
+
+type Scope string
+
+// The enumerated Scopes that can be granted to a personal API token.
+const (
+	// READ is authorized to make read-only (GET) requests on behalf of the token's User.
+	READ Scope = "read"
+	// PICKS_WRITE is authorized to suggest and select picks on behalf of the token's User.
+	PICKS_WRITE Scope = "picks:write"
+	// RESULTS_WRITE is authorized to record game results on behalf of the token's User (Administrators only).
+	RESULTS_WRITE Scope = "results:write"
+)
@@ -0,0 +1,13 @@
+package standings
+
+type Sort string
+
+// The enumerated orders in which a Pool's standings can be sorted.
+const (
+	// STATUS sorts ACTIVE Contestants first, then by weeks survived (most first), then by name.
+	STATUS Sort = "status"
+	// SURVIVED sorts by weeks survived (most first), then by name.
+	SURVIVED Sort = "survived"
+	// NAME sorts alphabetically by Contestant name.
+	NAME Sort = "name"
+)
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/env"
+)
+
+// SetCookie adds an HttpOnly cookie to the HTTP response, marked Secure unless secure cookies have been
+// disabled (see env.SecureCookies) for local development over plain HTTP.
+//
+// ctx is the pointer to the [gin.Context] containing the HTTP response.
+//
+// name is the name of the cookie.
+//
+// value is the value of the cookie (an empty value with a maxAge of -1 deletes the cookie).
+//
+// maxAge is the number of seconds until the cookie expires.
+//
+// path is the URL path prefix to which the browser will send the cookie.
+//
+// sameSite is the [http.SameSite] mode restricting when the browser sends the cookie on cross-site requests.
+func SetCookie(ctx *gin.Context, name string, value string, maxAge int, path string, sameSite http.SameSite) {
+	http.SetCookie(ctx.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		MaxAge:   maxAge,
+		Path:     path,
+		Secure:   env.SecureCookies,
+		HttpOnly: true,
+		SameSite: sameSite,
+	})
+}
@@ -2,17 +2,24 @@ package utils
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/mhs294/mulhall/internals/types"
 )
 
-// ParseRequestJSON reads the JSON body of an HTTP request and deserializes its contents into the object
-// referenced by the provided object pointer.
+// ParseRequestJSON reads the JSON body of an HTTP request, deserializes its contents into the object
+// referenced by the provided object pointer and validates the result against the object's "binding" struct tags.
+// Returns RequestInvalidError if the body could not be read and RequestValidationError if any of its fields are invalid.
 //
 // v is the pointer to the object into which the JSON contents should be deserialized.
 //
 // ctx is the pointer to the [gin.Context] containing the HTTP request.
 func ParseRequestJSON(v interface{}, ctx *gin.Context) error {
 	if err := ctx.ShouldBind(&v); err != nil {
-		return err
+		switch err.(type) {
+		case *types.RequestInvalidError, *types.RequestValidationError:
+			return err
+		default:
+			return &types.RequestInvalidError{Reason: err.Error()}
+		}
 	}
 
 	return nil
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	crand "crypto/rand"
+	"math/big"
 	"math/rand"
 )
 
@@ -20,6 +22,26 @@ func CreateToken(length int) string {
 	return createToken(charset, length)
 }
 
+// CreateSecureAlphaNumToken creates a token consisting only of alpha-numeric characters using a
+// cryptographically secure random source, suitable for secrets such as API tokens.
+//
+// length is the the desired amount of characters to use when creating the token.
+func CreateSecureAlphaNumToken(length int) (string, error) {
+	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	max := big.NewInt(int64(len(charset)))
+
+	token := make([]byte, length)
+	for i := range token {
+		n, err := crand.Int(crand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		token[i] = charset[n.Int64()]
+	}
+
+	return string(token), nil
+}
+
 // createToken creates a randomized token consisting only of characters from the specified character set.
 //
 // charset is the set of characters that are allowed to be used when creating the token.
@@ -0,0 +1,199 @@
+package validation
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/mhs294/mulhall/internals/repos"
+	"github.com/mhs294/mulhall/internals/types"
+	"github.com/mhs294/mulhall/internals/types/roles"
+	"github.com/mhs294/mulhall/internals/types/scopes"
+	"github.com/mhs294/mulhall/internals/types/status"
+)
+
+// RequestValidator validates request bodies against the rules declared in their "binding" struct tags.
+// In addition to the built-in rules of the [validator] package, the following rules are available:
+//
+//   - role: the value is one of the enumerated [roles.Role] values
+//   - status: the value is one of the enumerated [status.Status] values
+//   - scope: the value is one of the enumerated [scopes.Scope] values
+//   - teamid: the value is the ID of an existing Team
+//
+// Validation failures are returned as a RequestValidationError keyed by the JSON path of each invalid field.
+type RequestValidator struct {
+	logger   *log.Logger
+	validate *validator.Validate
+	teamRepo *repos.TeamRepository
+}
+
+var _ binding.StructValidator = (*RequestValidator)(nil)
+
+// NewRequestValidator creates a new RequestValidator instance and returns a pointer to it.
+//
+// l is the pointer to the [log.Logger] used to record failures to look up Teams.
+//
+// r is the TeamRepository used to verify that Team IDs refer to existing Teams.
+func NewRequestValidator(l *log.Logger, r *repos.TeamRepository) *RequestValidator {
+	v := &RequestValidator{logger: l, validate: validator.New(), teamRepo: r}
+	v.validate.SetTagName("binding")
+
+	// Report fields by their JSON names, since those are the names known to the caller
+	v.validate.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		} else if len(name) == 0 {
+			return f.Name
+		}
+		return name
+	})
+
+	v.validate.RegisterValidation("role", v.isRole)
+	v.validate.RegisterValidation("status", v.isStatus)
+	v.validate.RegisterValidation("scope", v.isScope)
+	v.validate.RegisterValidation("teamid", v.isTeamID)
+
+	return v
+}
+
+// ValidateStruct validates the struct (or pointer to a struct) provided, returning RequestInvalidError if
+// no value was provided and RequestValidationError if any of its fields are invalid.
+// Values that are not structs are not validated.
+//
+// obj is the value to validate.
+func (v *RequestValidator) ValidateStruct(obj any) error {
+	// Dereference the value (request bodies are typically bound to a pointer to a pointer)
+	value := reflect.ValueOf(obj)
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return &types.RequestInvalidError{Reason: "request body is required"}
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	err := v.validate.Struct(value.Interface())
+	if err == nil {
+		return nil
+	}
+
+	fieldErrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
+
+	// Describe each invalid field by its JSON path, excluding the name of the request type itself
+	fields := make(map[string]string, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		path := fe.Namespace()
+		if i := strings.Index(path, "."); i >= 0 {
+			path = path[i+1:]
+		}
+		if _, exists := fields[path]; !exists {
+			fields[path] = describe(fe)
+		}
+	}
+
+	return &types.RequestValidationError{Fields: fields}
+}
+
+// Engine returns the underlying [validator.Validate] instance used by the RequestValidator.
+func (v *RequestValidator) Engine() any {
+	return v.validate
+}
+
+func (v *RequestValidator) isRole(fl validator.FieldLevel) bool {
+	switch roles.Role(fl.Field().String()) {
+	case roles.OWNER, roles.MANAGER, roles.VIEWER:
+		return true
+	default:
+		return false
+	}
+}
+
+func (v *RequestValidator) isStatus(fl validator.FieldLevel) bool {
+	switch status.Status(fl.Field().String()) {
+	case status.ACTIVE, status.ELIMINATED, status.DISQUALIFIED:
+		return true
+	default:
+		return false
+	}
+}
+
+func (v *RequestValidator) isScope(fl validator.FieldLevel) bool {
+	switch scopes.Scope(fl.Field().String()) {
+	case scopes.READ, scopes.PICKS_WRITE, scopes.RESULTS_WRITE:
+		return true
+	default:
+		return false
+	}
+}
+
+func (v *RequestValidator) isTeamID(fl validator.FieldLevel) bool {
+	id := types.TeamID(fl.Field().String())
+	t, err := v.teamRepo.GetByID(id)
+	if err != nil {
+		v.logger.Printf("failed to look up team while validating request (id=%s): %v", id, err)
+		return false
+	}
+
+	return len(t.ID) > 0
+}
+
+// describe returns a human-readable description of the rule that a field failed to satisfy.
+func describe(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "min", "gte":
+		if isCollection(fe.Kind()) {
+			return fmt.Sprintf("must contain at least %s item(s)", fe.Param())
+		}
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "max", "lte":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters", fe.Param())
+		} else if isCollection(fe.Kind()) {
+			return fmt.Sprintf("must contain at most %s item(s)", fe.Param())
+		}
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "datetime":
+		if fe.Param() == time.DateOnly {
+			return "must be a date formatted as YYYY-MM-DD"
+		}
+		return fmt.Sprintf("must be formatted as %s", fe.Param())
+	case "nefield":
+		return fmt.Sprintf("must differ from %s", jsonName(fe.Param()))
+	case "role":
+		return fmt.Sprintf("must be one of %s, %s or %s", roles.OWNER, roles.MANAGER, roles.VIEWER)
+	case "status":
+		return fmt.Sprintf("must be one of %s, %s or %s", status.ACTIVE, status.ELIMINATED, status.DISQUALIFIED)
+	case "scope":
+		return fmt.Sprintf("must be one of %s, %s or %s", scopes.READ, scopes.PICKS_WRITE, scopes.RESULTS_WRITE)
+	case "teamid":
+		return "must be the ID of an existing team"
+	default:
+		return fmt.Sprintf("failed the %q rule", fe.Tag())
+	}
+}
+
+func isCollection(k reflect.Kind) bool {
+	return k == reflect.Slice || k == reflect.Array || k == reflect.Map
+}
+
+// jsonName converts the Go name of a sibling field (as referenced by rules such as nefield) to its JSON name.
+func jsonName(name string) string {
+	if len(name) == 0 {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}